@@ -1,12 +1,17 @@
 package apiAccess
 
 import (
+	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/gerp93/gameshell-framework/api"
 	"github.com/gerp93/gameshell-framework/auth"
 	gsDatabase "github.com/gerp93/gameshell-framework/database"
 	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
 )
 
 func Lobby(w http.ResponseWriter, r *http.Request) {
@@ -41,16 +46,38 @@ func Lobby(w http.ResponseWriter, r *http.Request) {
 		break
 	}
 
-	if !auth.PasswordMatchesHash(password, lobbyPasswordHash.String) {
+	userId := api.GetUserId(r)
+	if userId == uuid.Nil {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Provided password is not valid."))
+		_, _ = w.Write([]byte("Failed to get user id."))
 		return
 	}
 
-	userId := api.GetUserId(r)
-	if userId == uuid.Nil {
+	ipAddress := getRequestIpAddress(r)
+
+	waitSeconds, err := database.GetAccessWaitSeconds(ipAddress, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if waitSeconds > 0 {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(getTooManyAttemptsMessage(waitSeconds)))
+		return
+	}
+
+	if !auth.PasswordMatchesHash(password, lobbyPasswordHash.String) {
+		err = database.AddLobbyAccessFailure(ipAddress, userId, lobbyId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get user id."))
+		_, _ = w.Write([]byte("Provided password is not valid."))
 		return
 	}
 
@@ -97,12 +124,68 @@ func Deck(w http.ResponseWriter, r *http.Request) {
 		break
 	}
 
+	userId := api.GetUserId(r)
+	if userId == uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get user id."))
+		return
+	}
+
+	ipAddress := getRequestIpAddress(r)
+
+	waitSeconds, err := database.GetAccessWaitSeconds(ipAddress, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if waitSeconds > 0 {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(getTooManyAttemptsMessage(waitSeconds)))
+		return
+	}
+
 	if !auth.PasswordMatchesHash(password, deckPasswordHash) {
+		err = database.AddDeckAccessFailure(ipAddress, userId, deckId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("Provided password is not valid."))
 		return
 	}
 
+	err = gsDatabase.AddUserDeckAccess(userId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to add access."))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func Invite(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue("token")
+
+	invite, err := database.GetInviteByToken(token)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if invite.Id == uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invite link is not valid."))
+		return
+	}
+
 	userId := api.GetUserId(r)
 	if userId == uuid.Nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -110,13 +193,125 @@ func Deck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = gsDatabase.AddUserDeckAccess(userId, deckId)
+	var redirectUrl string
+	var hasAccess bool
+	if invite.LobbyId.Valid {
+		redirectUrl = fmt.Sprintf("/lobby/%s", invite.LobbyId.UUID)
+		hasAccess, err = gsDatabase.UserHasLobbyAccess(userId, invite.LobbyId.UUID)
+	} else {
+		redirectUrl = fmt.Sprintf("/deck/%s", invite.DeckId.UUID)
+		// the deck password only makes a contributor, so it is not enough to
+		// skip an edit invite
+		permission := database.DeckPermissionView
+		if invite.Access == database.InviteAccessEdit {
+			permission = database.DeckPermissionEditCards
+		}
+		var role database.DeckRole
+		role, err = database.GetUserDeckRole(userId, invite.DeckId.UUID)
+		hasAccess = role.Can(permission)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Failed to check access."))
+		return
+	}
+
+	// no need to take a use from the link if it would not change anything
+	if hasAccess {
+		w.Header().Add("HX-Redirect", redirectUrl)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	isUsed, err := database.UseInvite(invite.Id, userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !isUsed {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invite link has expired, been used up, or been revoked."))
+		return
+	}
+
+	if invite.LobbyId.Valid {
+		err = gsDatabase.AddUserLobbyAccess(userId, invite.LobbyId.UUID)
+	} else if invite.Access == database.InviteAccessEdit {
+		err = database.AddDeckEditor(invite.DeckId.UUID, userId)
+	} else {
+		err = database.AddUserDeckReadAccess(userId, invite.DeckId.UUID)
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("Failed to add access."))
 		return
 	}
 
-	w.Header().Add("HX-Refresh", "true")
+	w.Header().Add("HX-Redirect", redirectUrl)
 	w.WriteHeader(http.StatusOK)
 }
+
+// GetInviteUrl builds the full link to share for an invite token.
+func GetInviteUrl(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/invite/%s", scheme, r.Host, token)
+}
+
+// getRequestIpAddress finds the address of the client that sent the request.
+// Behind a reverse proxy every request comes from the proxy, so when the
+// connection is from one of CARD_JUDGE_TRUSTED_PROXIES the X-Forwarded-For
+// header is used instead.
+func getRequestIpAddress(r *http.Request) string {
+	trustedProxies := strings.Split(os.Getenv("CARD_JUDGE_TRUSTED_PROXIES"), ",")
+	return getClientIpAddress(r.RemoteAddr, r.Header.Values("X-Forwarded-For"), trustedProxies)
+}
+
+// getClientIpAddress walks the forwarded-for chain from the nearest hop and
+// returns the first address that is not a trusted proxy. Anything further left
+// was written by the client and cannot be trusted. The port is dropped from the
+// remote address, which changes with every connection.
+func getClientIpAddress(remoteAddr string, forwardedFor []string, trustedProxies []string) string {
+	address, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		address = remoteAddr
+	}
+
+	isTrusted := make(map[string]bool)
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy != "" {
+			isTrusted[proxy] = true
+		}
+	}
+
+	hops := make([]string, 0)
+	for _, header := range forwardedFor {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0 && isTrusted[address]; i-- {
+		if net.ParseIP(hops[i]) == nil {
+			break
+		}
+		address = hops[i]
+	}
+
+	return address
+}
+
+func getTooManyAttemptsMessage(waitSeconds int) string {
+	if waitSeconds >= 120 {
+		return fmt.Sprintf("Too many wrong passwords, please wait %d minutes to try again.", (waitSeconds+59)/60)
+	}
+	if waitSeconds == 1 {
+		return "Too many wrong passwords, please wait a second to try again."
+	}
+	return fmt.Sprintf("Too many wrong passwords, please wait %d seconds to try again.", waitSeconds)
+}
@@ -0,0 +1,29 @@
+package apiAccess
+
+import "testing"
+
+func TestGetClientIpAddress(t *testing.T) {
+	trusted := []string{"10.0.0.1", " 10.0.0.2 "}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{
+		{"direct", "203.0.113.5:5123", nil, "203.0.113.5"},
+		{"untrusted remote ignores header", "203.0.113.5:5123", []string{"198.51.100.7"}, "203.0.113.5"},
+		{"trusted proxy", "10.0.0.1:443", []string{"198.51.100.7"}, "198.51.100.7"},
+		{"client spoofed hop", "10.0.0.1:443", []string{"1.2.3.4, 198.51.100.7"}, "198.51.100.7"},
+		{"proxy chain", "10.0.0.1:443", []string{"198.51.100.7", "10.0.0.2"}, "198.51.100.7"},
+		{"bad hop", "10.0.0.1:443", []string{"not-an-ip"}, "10.0.0.1"},
+		{"no port", "203.0.113.5", nil, "203.0.113.5"},
+	}
+
+	for _, test := range tests {
+		got := getClientIpAddress(test.remoteAddr, test.forwardedFor, trusted)
+		if got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/gerp93/gameshell-framework/api"
-	gsDatabase "github.com/gerp93/gameshell-framework/database"
 	"github.com/google/uuid"
 	"github.com/grantfbarnes/card-judge/database"
 	"github.com/grantfbarnes/card-judge/static"
@@ -95,16 +94,16 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hasDeckAccess, err := gsDatabase.UserHasDeckAccess(userId, deckId)
+	role, err := database.GetUserDeckRole(userId, deckId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("Failed to check deck access."))
 		return
 	}
 
-	if !hasDeckAccess {
+	if !role.Can(database.DeckPermissionEditCards) && !role.Can(database.DeckPermissionSuggestCards) {
 		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte("User does not have access."))
+		_, _ = w.Write([]byte("User cannot add cards to this deck."))
 		return
 	}
 
@@ -140,6 +139,33 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// contributors only suggest cards, an owner or editor adds them
+	if !role.Can(database.DeckPermissionEditCards) {
+		existingSuggestionId, err := database.GetCardSuggestionId(deckId, text)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if existingSuggestionId != uuid.Nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Card text has already been suggested."))
+			return
+		}
+
+		err = database.CreateCardSuggestion(deckId, userId, category, text, youtube)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("Card sent to the deck editors for approval."))
+		return
+	}
+
 	_, err = database.CreateCard(deckId, category, text, youtube)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -167,19 +193,11 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var deckId uuid.UUID
 	var category string
 	var text string
 	var youtube string
 	for key, val := range r.Form {
 		switch key {
-		case "deckId":
-			deckId, err = uuid.Parse(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse deck id."))
-				return
-			}
 		case "category":
 			category = val[0]
 		case "text":
@@ -196,16 +214,23 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hasDeckAccess, err := gsDatabase.UserHasDeckAccess(userId, deckId)
+	card, err := database.GetCard(cardId)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get card."))
+		return
+	}
+
+	role, err := database.GetUserDeckRole(userId, card.DeckId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("Failed to check deck access."))
 		return
 	}
 
-	if !hasDeckAccess {
+	if !role.Can(database.DeckPermissionEditCards) {
 		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte("User does not have access."))
+		_, _ = w.Write([]byte("Only deck owners and editors can change cards."))
 		return
 	}
 
@@ -222,7 +247,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	existingCardId, err := database.GetCardId(deckId, text)
+	existingCardId, err := database.GetCardId(card.DeckId, text)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
@@ -268,18 +293,6 @@ func SetImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var deckId uuid.UUID
-	for key, val := range r.Form {
-		if key == "deckId" {
-			deckId, err = uuid.Parse(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse deck id."))
-				return
-			}
-		}
-	}
-
 	userId := api.GetUserId(r)
 	if userId == uuid.Nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -287,16 +300,23 @@ func SetImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hasDeckAccess, err := gsDatabase.UserHasDeckAccess(userId, deckId)
+	card, err := database.GetCard(cardId)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get card."))
+		return
+	}
+
+	role, err := database.GetUserDeckRole(userId, card.DeckId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("Failed to check deck access."))
 		return
 	}
 
-	if !hasDeckAccess {
+	if !role.Can(database.DeckPermissionEditCards) {
 		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte("User does not have access."))
+		_, _ = w.Write([]byte("Only deck owners and editors can change cards."))
 		return
 	}
 
@@ -353,16 +373,16 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hasDeckAccess, err := gsDatabase.UserHasDeckAccess(userId, card.DeckId)
+	role, err := database.GetUserDeckRole(userId, card.DeckId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("Failed to check deck access."))
 		return
 	}
 
-	if !hasDeckAccess {
+	if !role.Can(database.DeckPermissionEditCards) {
 		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte("User does not have access."))
+		_, _ = w.Write([]byte("Only deck owners and editors can delete cards."))
 		return
 	}
 
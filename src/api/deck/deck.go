@@ -3,21 +3,80 @@ package apiDeck
 import (
 	"encoding/csv"
 	"net/http"
+	"strconv"
 
 	"github.com/gerp93/gameshell-framework/api"
+	"github.com/gerp93/gameshell-framework/auth"
 	gsDatabase "github.com/gerp93/gameshell-framework/database"
 	"github.com/google/uuid"
+	apiAccess "github.com/grantfbarnes/card-judge/api/access"
 	"github.com/grantfbarnes/card-judge/database"
 )
 
-// GetCardExport streams a deck's cards as CSV. Deck management itself lives in
-// the framework's api/deck; this card-specific export stays with the game.
+// GetCardExport streams a deck's cards as CSV.
 func GetCardExport(w http.ResponseWriter, r *http.Request) {
-	deckIdString := r.PathValue("deckId")
-	deckId, err := uuid.Parse(deckIdString)
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionView)
+	if !ok {
+		return
+	}
+
+	cards, err := database.GetCardsInDeckExport(deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+	for _, card := range cards {
+		_ = writer.Write([]string{card.Category, card.Text})
+	}
+}
+
+// Create mirrors the framework's api/deck handler, and makes the user who
+// created the deck its owner.
+func Create(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get deck id from path."))
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var name string
+	var password string
+	var passwordConfirm string
+	var isPublicReadOnly bool
+	for key, val := range r.Form {
+		switch key {
+		case "name":
+			name = val[0]
+		case "password":
+			password = val[0]
+		case "passwordConfirm":
+			passwordConfirm = val[0]
+		case "isPublicReadOnly":
+			isPublicReadOnly = val[0] == "1"
+		}
+	}
+
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No name found."))
+		return
+	}
+
+	if password == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No password found."))
+		return
+	}
+
+	if password != passwordConfirm {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Passwords do not match."))
 		return
 	}
 
@@ -28,30 +87,674 @@ func GetCardExport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hasDeckAccess, err := gsDatabase.UserHasDeckAccess(userId, deckId)
+	existingDeckId, err := gsDatabase.GetDeckId(name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if existingDeckId != uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Deck name already exists."))
+		return
+	}
+
+	deckId, err := gsDatabase.CreateDeck(name, password, isPublicReadOnly)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	err = gsDatabase.AddUserDeckAccess(userId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	err = database.SetDeckCollaboratorRole(deckId, userId, database.DeckRoleOwner)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Redirect", "/deck/"+deckId.String())
+	w.WriteHeader(http.StatusCreated)
+}
+
+// SetName, SetPassword, SetIsPublicReadOnly and Delete mirror the framework's
+// api/deck handlers, but only let the deck owner in.
+func SetName(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var name string
+	for key, val := range r.Form {
+		if key == "name" {
+			name = val[0]
+		}
+	}
+
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No name found."))
+		return
+	}
+
+	existingDeckId, err := gsDatabase.GetDeckId(name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if existingDeckId != uuid.Nil && existingDeckId != deckId {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Deck name already exists."))
+		return
+	}
+
+	err = gsDatabase.SetDeckName(deckId, name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetPassword(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var currentPassword string
+	var newPassword string
+	var newPasswordConfirm string
+	for key, val := range r.Form {
+		switch key {
+		case "currentPassword":
+			currentPassword = val[0]
+		case "newPassword":
+			newPassword = val[0]
+		case "newPasswordConfirm":
+			newPasswordConfirm = val[0]
+		}
+	}
+
+	if currentPassword == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No current password found."))
+		return
+	}
+
+	passwordHash, err := gsDatabase.GetDeckPasswordHash(deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !auth.PasswordMatchesHash(currentPassword, passwordHash) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Provided current password is not valid."))
+		return
+	}
+
+	if newPassword == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No new password found."))
+		return
+	}
+
+	if newPassword != newPasswordConfirm {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("New passwords do not match."))
+		return
+	}
+
+	err = gsDatabase.SetDeckPassword(deckId, newPassword)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetIsPublicReadOnly(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var isPublicReadOnly bool
+	for key, val := range r.Form {
+		if key == "isPublicReadOnly" {
+			isPublicReadOnly = val[0] == "1"
+		}
+	}
+
+	err = gsDatabase.SetIsPublicReadOnly(deckId, isPublicReadOnly)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func Delete(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	err := gsDatabase.DeleteDeck(deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Redirect", "/decks")
+	w.WriteHeader(http.StatusOK)
+}
+
+func CreateInvite(w http.ResponseWriter, r *http.Request) {
+	deckId, userId, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var access database.InviteAccess
+	var expiresInHours int
+	var maxUses int
+	for key, val := range r.Form {
+		if key == "access" {
+			access = database.InviteAccess(val[0])
+		} else if key == "expiresInHours" {
+			expiresInHours, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse invite expiry."))
+				return
+			}
+		} else if key == "maxUses" {
+			maxUses, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse invite max uses."))
+				return
+			}
+		}
+	}
+
+	if access != database.InviteAccessRead && access != database.InviteAccessEdit {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invalid invite access."))
+		return
+	}
+
+	if expiresInHours < 0 {
+		expiresInHours = 0
+	}
+
+	if expiresInHours > 720 {
+		expiresInHours = 720
+	}
+
+	if maxUses < 0 {
+		maxUses = 0
+	}
+
+	if maxUses > 100 {
+		maxUses = 100
+	}
+
+	token, err := database.CreateDeckInvite(deckId, userId, access, expiresInHours, maxUses)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(apiAccess.GetInviteUrl(r, token)))
+}
+
+func RevokeInvite(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	inviteIdString := r.PathValue("inviteId")
+	inviteId, err := uuid.Parse(inviteIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get invite id from path."))
+		return
+	}
+
+	invite, err := database.GetInvite(inviteId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if invite.DeckId.UUID != deckId {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invite not found."))
+		return
+	}
+
+	err = database.RevokeInvite(inviteId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func AddCollaborator(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var name string
+	var role database.DeckRole
+	for key, val := range r.Form {
+		if key == "name" {
+			name = val[0]
+		} else if key == "role" {
+			role = database.DeckRole(val[0])
+		}
+	}
+
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No name found."))
+		return
+	}
+
+	if !isCollaboratorRole(role) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invalid collaborator role."))
+		return
+	}
+
+	subjectUserId, err := gsDatabase.GetUserIdByName(name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if subjectUserId == uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("User not found."))
+		return
+	}
+
+	subjectRole, err := database.GetUserDeckRole(subjectUserId, deckId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("Failed to check deck access."))
 		return
 	}
 
-	if !hasDeckAccess {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte("User does not have access."))
+	if subjectRole == database.DeckRoleOwner {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("User already owns the deck."))
 		return
 	}
 
-	cards, err := database.GetCardsInDeckExport(deckId)
+	err = database.SetDeckCollaboratorRole(deckId, subjectUserId, role)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/csv")
-	writer := csv.NewWriter(w)
-	defer writer.Flush()
-	for _, card := range cards {
-		_ = writer.Write([]string{card.Category, card.Text})
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetCollaboratorRole(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	subjectUserIdString := r.PathValue("userId")
+	subjectUserId, err := uuid.Parse(subjectUserIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get user id from path."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var role database.DeckRole
+	for key, val := range r.Form {
+		if key == "role" {
+			role = database.DeckRole(val[0])
+		}
+	}
+
+	if !isCollaboratorRole(role) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invalid collaborator role."))
+		return
+	}
+
+	subjectRole, err := database.GetUserDeckRole(subjectUserId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Failed to check deck access."))
+		return
+	}
+
+	if subjectRole == database.DeckRoleNone {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("User is not a collaborator on the deck."))
+		return
+	}
+
+	if subjectRole == database.DeckRoleOwner {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Hand the deck to someone else before changing the owner's role."))
+		return
+	}
+
+	err = database.SetDeckCollaboratorRole(deckId, subjectUserId, role)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	subjectUserIdString := r.PathValue("userId")
+	subjectUserId, err := uuid.Parse(subjectUserIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get user id from path."))
+		return
+	}
+
+	subjectRole, err := database.GetUserDeckRole(subjectUserId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Failed to check deck access."))
+		return
+	}
+
+	if subjectRole == database.DeckRoleNone {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("User is not a collaborator on the deck."))
+		return
+	}
+
+	if subjectRole == database.DeckRoleOwner {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("User already owns the deck."))
+		return
+	}
+
+	err = database.TransferDeckOwner(deckId, subjectUserId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func RemoveCollaborator(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionManageDeck)
+	if !ok {
+		return
+	}
+
+	subjectUserIdString := r.PathValue("userId")
+	subjectUserId, err := uuid.Parse(subjectUserIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get user id from path."))
+		return
+	}
+
+	subjectRole, err := database.GetUserDeckRole(subjectUserId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Failed to check deck access."))
+		return
+	}
+
+	if subjectRole == database.DeckRoleOwner {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("The deck owner cannot be removed."))
+		return
+	}
+
+	err = database.RemoveDeckCollaborator(deckId, subjectUserId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+// ApproveSuggestion adds a contributor's suggested card to the deck.
+func ApproveSuggestion(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionEditCards)
+	if !ok {
+		return
+	}
+
+	suggestion, ok := getDeckSuggestion(w, r, deckId)
+	if !ok {
+		return
+	}
+
+	existingCardId, err := database.GetCardId(deckId, suggestion.Text)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if existingCardId != uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Card text already exists."))
+		return
+	}
+
+	_, err = database.CreateCard(deckId, suggestion.Category, suggestion.Text, suggestion.YouTube.String)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	err = database.DeleteCardSuggestion(suggestion.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func RejectSuggestion(w http.ResponseWriter, r *http.Request) {
+	deckId, _, ok := authorizedDeckId(w, r, database.DeckPermissionEditCards)
+	if !ok {
+		return
+	}
+
+	suggestion, ok := getDeckSuggestion(w, r, deckId)
+	if !ok {
+		return
+	}
+
+	err := database.DeleteCardSuggestion(suggestion.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+// authorizedDeckId parses the {deckId} path value and confirms the requesting
+// user's role on the deck has the permission. It writes the error response
+// and returns ok=false on any failure.
+func authorizedDeckId(w http.ResponseWriter, r *http.Request, permission database.DeckPermission) (deckId uuid.UUID, userId uuid.UUID, ok bool) {
+	deckId, err := uuid.Parse(r.PathValue("deckId"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get deck id from path."))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userId = api.GetUserId(r)
+	if userId == uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get user id."))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	role, err := database.GetUserDeckRole(userId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Failed to check deck access."))
+		return uuid.Nil, uuid.Nil, false
 	}
+
+	if !role.Can(permission) {
+		w.WriteHeader(http.StatusUnauthorized)
+		switch permission {
+		case database.DeckPermissionEditCards:
+			_, _ = w.Write([]byte("Only deck owners and editors can change cards."))
+		case database.DeckPermissionManageDeck:
+			_, _ = w.Write([]byte("Only the deck owner can manage the deck."))
+		default:
+			_, _ = w.Write([]byte("User does not have access."))
+		}
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return deckId, userId, true
+}
+
+// getDeckSuggestion parses the {suggestionId} path value and confirms the
+// suggestion belongs to the deck.
+func getDeckSuggestion(w http.ResponseWriter, r *http.Request, deckId uuid.UUID) (database.CardSuggestion, bool) {
+	suggestionId, err := uuid.Parse(r.PathValue("suggestionId"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get suggestion id from path."))
+		return database.CardSuggestion{}, false
+	}
+
+	suggestion, err := database.GetCardSuggestion(suggestionId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return suggestion, false
+	}
+
+	if suggestion.DeckId != deckId {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Suggestion not found."))
+		return suggestion, false
+	}
+
+	return suggestion, true
+}
+
+// isCollaboratorRole reports whether the role can be given out directly.
+// Ownership only changes hands through TransferOwnership.
+func isCollaboratorRole(role database.DeckRole) bool {
+	return role == database.DeckRoleEditor ||
+		role == database.DeckRoleContributor ||
+		role == database.DeckRoleViewer
 }
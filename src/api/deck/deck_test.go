@@ -0,0 +1,29 @@
+package apiDeck
+
+import (
+	"testing"
+
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+func TestIsCollaboratorRole(t *testing.T) {
+	tests := []struct {
+		role database.DeckRole
+		want bool
+	}{
+		{database.DeckRoleEditor, true},
+		{database.DeckRoleContributor, true},
+		{database.DeckRoleViewer, true},
+		// ownership is handed over, never given out next to the current owner
+		{database.DeckRoleOwner, false},
+		{database.DeckRoleNone, false},
+		{database.DeckRole("ADMIN"), false},
+	}
+
+	for _, test := range tests {
+		got := isCollaboratorRole(test.role)
+		if got != test.want {
+			t.Errorf("%s: got %t, want %t", test.role, got, test.want)
+		}
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"log"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gerp93/gameshell-framework/api"
 	gsDatabase "github.com/gerp93/gameshell-framework/database"
 	"github.com/gerp93/gameshell-framework/websocket"
 	"github.com/google/uuid"
+	apiAccess "github.com/grantfbarnes/card-judge/api/access"
 	"github.com/grantfbarnes/card-judge/database"
+	"github.com/grantfbarnes/card-judge/game"
 	"github.com/grantfbarnes/card-judge/static"
 )
 
@@ -26,6 +31,13 @@ func GetGameInterfaceHTML(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	isStarted, err := database.IsLobbyStarted(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
 	tmpl, err := template.ParseFS(
 		static.StaticFiles,
 		"html/components/game/game-interface.html",
@@ -37,14 +49,52 @@ func GetGameInterfaceHTML(w http.ResponseWriter, r *http.Request) {
 	}
 
 	type data struct {
-		LobbyId uuid.UUID
+		LobbyId        uuid.UUID
+		LobbyIsStarted bool
 	}
 
 	_ = tmpl.ExecuteTemplate(w, "game-interface", data{
-		LobbyId: lobbyId,
+		LobbyId:        lobbyId,
+		LobbyIsStarted: isStarted,
 	})
 }
 
+func GetLobbyWaitingRoomHTML(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	data, err := database.GetLobbyWaitingRoomData(lobbyId, player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	tmpl, err := template.ParseFS(
+		static.StaticFiles,
+		"html/components/game/lobby-waiting-room.html",
+	)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("Failed to parse HTML."))
+		return
+	}
+
+	_ = tmpl.ExecuteTemplate(w, "lobby-waiting-room", data)
+}
+
 func GetLobbyGameInfoHTML(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
@@ -150,7 +200,13 @@ func GetPlayerSpecialsHTML(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_ = tmpl.ExecuteTemplate(w, "player-specials", data)
+	_ = tmpl.ExecuteTemplate(w, "player-specials", struct {
+		database.PlayerSpecialsData
+		game.SpecialsPanel
+	}{
+		PlayerSpecialsData: data,
+		SpecialsPanel:      game.GetSpecialsPanel(data),
+	})
 }
 
 func GetLobbyGameBoardHTML(w http.ResponseWriter, r *http.Request) {
@@ -244,6 +300,20 @@ func Create(w http.ResponseWriter, r *http.Request) {
 	var freeSpecialCards bool
 	var winStreakThreshold int
 	var loseStreakThreshold int
+	var freeTextMode bool
+	var freeTextHands bool
+	var freeTextMaxLength int
+	var freeTextWordFilter string
+	var captionMode bool
+	enabledSpecials := database.AllSpecials()
+	var promptChoices int
+	var customPrompts bool
+	var writePromptCount int
+	var writeResponseCount int
+	var writePhaseMinutes int
+	var allowSpectators bool
+	var maxSpectators int
+	var minPlayers int
 	var deckIdsPrompt = make([]uuid.UUID, 0)
 	var deckIdsResponse = make([]uuid.UUID, 0)
 	for key, val := range r.Form {
@@ -299,6 +369,92 @@ func Create(w http.ResponseWriter, r *http.Request) {
 				_, _ = w.Write([]byte("Failed to parse lose streak threshold."))
 				return
 			}
+		} else if key == "freeTextMode" {
+			freeTextMode, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse free text mode."))
+				return
+			}
+		} else if key == "freeTextHands" {
+			freeTextHands, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse free text hands."))
+				return
+			}
+		} else if key == "freeTextMaxLength" {
+			freeTextMaxLength, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse free text max length."))
+				return
+			}
+		} else if key == "freeTextWordFilter" {
+			freeTextWordFilter = val[0]
+		} else if key == "captionMode" {
+			captionMode, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse caption mode."))
+				return
+			}
+		} else if key == "promptChoices" {
+			promptChoices, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse prompt choices."))
+				return
+			}
+		} else if key == "customPrompts" {
+			customPrompts, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse custom prompts."))
+				return
+			}
+		} else if key == "writePromptCount" {
+			writePromptCount, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse write prompt count."))
+				return
+			}
+		} else if key == "writeResponseCount" {
+			writeResponseCount, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse write response count."))
+				return
+			}
+		} else if key == "writePhaseMinutes" {
+			writePhaseMinutes, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse write phase minutes."))
+				return
+			}
+		} else if key == "allowSpectators" {
+			allowSpectators, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse allow spectators."))
+				return
+			}
+		} else if key == "maxSpectators" {
+			maxSpectators, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse max spectators."))
+				return
+			}
+		} else if key == "minPlayers" {
+			minPlayers, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse min players."))
+				return
+			}
 		} else if strings.HasPrefix(key, "deckIdPrompt") {
 			deckId, err := uuid.Parse(val[0])
 			if err != nil {
@@ -315,6 +471,20 @@ func Create(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			deckIdsResponse = append(deckIdsResponse, deckId)
+		} else if strings.HasPrefix(key, "special") {
+			special, ok := game.GetSpecial(strings.TrimPrefix(key, "special"))
+			if !ok {
+				continue
+			}
+			enabled, err := strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse special."))
+				return
+			}
+			if !enabled {
+				delete(enabledSpecials, special.Info().Key)
+			}
 		}
 	}
 
@@ -372,18 +542,93 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		loseStreakThreshold = 5
 	}
 
+	if freeTextMaxLength < 10 {
+		freeTextMaxLength = 10
+	}
+
+	if freeTextMaxLength > 255 {
+		freeTextMaxLength = 255
+	}
+
+	if promptChoices < 1 {
+		promptChoices = 1
+	}
+
+	if promptChoices > 3 {
+		promptChoices = 3
+	}
+
+	if writePromptCount < 0 {
+		writePromptCount = 0
+	}
+
+	if writePromptCount > 5 {
+		writePromptCount = 5
+	}
+
+	if writeResponseCount < 0 {
+		writeResponseCount = 0
+	}
+
+	if writeResponseCount > 10 {
+		writeResponseCount = 10
+	}
+
+	if writePhaseMinutes < 1 {
+		writePhaseMinutes = 1
+	}
+
+	if writePhaseMinutes > 15 {
+		writePhaseMinutes = 15
+	}
+
+	if maxSpectators < 1 {
+		maxSpectators = 1
+	}
+
+	if maxSpectators > 50 {
+		maxSpectators = 50
+	}
+
+	if minPlayers < 2 {
+		minPlayers = 2
+	}
+
+	if minPlayers > 10 {
+		minPlayers = 10
+	}
+
 	if len(deckIdsPrompt) == 0 {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("At least one prompt deck is required."))
 		return
 	}
 
-	if len(deckIdsResponse) == 0 {
+	if len(deckIdsResponse) == 0 && !freeTextMode {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("At least one response deck is required."))
 		return
 	}
 
+	if captionMode {
+		mediaPromptCount, err := database.CountMediaPrompts(deckIdsPrompt)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if mediaPromptCount == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Caption mode needs an image or video prompt in the prompt decks."))
+			return
+		}
+
+		// custom and written prompts are text only
+		customPrompts = false
+		writePromptCount = 0
+	}
+
 	userId := api.GetUserId(r)
 	if userId == uuid.Nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -417,94 +662,88 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = database.SyncDecksInLobby(lobbyId, deckIdsPrompt, deckIdsResponse)
+	err = database.SetLobbyFreeTextSettings(lobbyId, freeTextMode, freeTextHands, freeTextMaxLength, strings.TrimSpace(freeTextWordFilter))
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = gsDatabase.AddUserLobbyAccess(userId, lobbyId)
+	err = database.SetLobbyCaptionMode(lobbyId, captionMode)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	w.Header().Add("HX-Redirect", "/lobby/"+lobbyId.String())
-	w.WriteHeader(http.StatusCreated)
-}
-
-func PlayCard(w http.ResponseWriter, r *http.Request) {
-	lobbyIdString := r.PathValue("lobbyId")
-	lobbyId, err := uuid.Parse(lobbyIdString)
+	err = database.SetLobbyPromptChoices(lobbyId, promptChoices)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	cardIdString := r.PathValue("cardId")
-	cardId, err := uuid.Parse(cardIdString)
+	err = database.SetLobbyCustomPrompts(lobbyId, customPrompts)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get card id from path."))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	err = database.SetLobbyWriteCounts(lobbyId, writePromptCount, writeResponseCount)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PlayCard(player.Id, cardId)
+	err = database.SetLobbyWritePhaseMinutes(lobbyId, writePhaseMinutes)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
-	w.WriteHeader(http.StatusOK)
-}
+	err = database.SetLobbySpectators(lobbyId, allowSpectators, maxSpectators)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
 
-func PlayForceCard(w http.ResponseWriter, r *http.Request) {
-	lobbyIdString := r.PathValue("lobbyId")
-	lobbyId, err := uuid.Parse(lobbyIdString)
+	err = database.SetLobbyMinPlayers(lobbyId, minPlayers)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	err = database.SetLobbySpecials(lobbyId, enabledSpecials)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	cardWasPlayed, err := database.PlayForceCard(player.Id)
+	err = database.SyncDecksInLobby(lobbyId, deckIdsPrompt, deckIdsResponse)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	if cardWasPlayed {
-		websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-		websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-		websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	err = gsDatabase.AddUserLobbyAccess(userId, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.Header().Add("HX-Redirect", "/lobby/"+lobbyId.String())
+	w.WriteHeader(http.StatusCreated)
 }
 
-func PurchaseCredits(w http.ResponseWriter, r *http.Request) {
+func PlayCard(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -513,28 +752,50 @@ func PurchaseCredits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	cardIdString := r.PathValue("cardId")
+	cardId, err := uuid.Parse(cardIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get card id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestParticipant(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	isChoosingPrompt, err := database.IsJudgeChoosingPrompt(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PurchaseCredits(player.Id)
+	if isChoosingPrompt {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("The judge is still choosing the prompt."))
+		return
+	}
+
+	err = database.PlayCard(player.Id, cardId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Attempted to purchase credits for an unfair advantage... Everyone else receives a credit as a result.")
+	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
 	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-
+	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	game.BroadcastReshuffles(lobbyId)
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("<b>Shame on you.</b><br/><br/>This action has been reported in the lobby chat and everyone else has received a credit."))
 }
 
-func SkipJudge(w http.ResponseWriter, r *http.Request) {
+func PlayForceCard(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -543,55 +804,45 @@ func SkipJudge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.SkipJudge(player.Id)
+	isChoosingPrompt, err := database.IsJudgeChoosingPrompt(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Skipped their turn as judge.")
-	websocket.LobbyBroadcast(lobbyId, "refresh")
-	w.WriteHeader(http.StatusOK)
-}
-
-func ResetResponses(w http.ResponseWriter, r *http.Request) {
-	lobbyIdString := r.PathValue("lobbyId")
-	lobbyId, err := uuid.Parse(lobbyIdString)
-	if err != nil {
+	if isChoosingPrompt {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get lobby id from path."))
-		return
-	}
-
-	player, err := getLobbyRequestPlayer(r, lobbyId)
-	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+		_, _ = w.Write([]byte("The judge is still choosing the prompt."))
 		return
 	}
 
-	err = database.ResetResponses(player.Id)
+	cardWasPlayed, err := database.PlayForceCard(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Reset responses.")
+	if cardWasPlayed {
+		websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
+		websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+		websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	}
 
-	websocket.LobbyBroadcast(lobbyId, "refresh")
+	game.BroadcastReshuffles(lobbyId)
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func AlertLobby(w http.ResponseWriter, r *http.Request) {
+func PurchaseCredits(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -600,75 +851,169 @@ func AlertLobby(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	lobby, err := database.GetLobby(lobbyId)
+	err = database.PurchaseCredits(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = r.ParseForm()
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
-		return
-	}
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Attempted to purchase credits for an unfair advantage... Everyone else receives a credit as a result.")
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
 
-	var credits int
-	var text string
-	for key, val := range r.Form {
-		switch key {
-		case "credits":
-			credits, err = strconv.Atoi(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse credits."))
-				return
-			}
-		case "text":
-			text = val[0]
+	recordPlayerAction(player.Id)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("<b>Shame on you.</b><br/><br/>This action has been reported in the lobby chat and everyone else has received a credit."))
+}
+
+// UseSpecial returns the handler for using a registered special.
+func UseSpecial(special game.Special) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyIdString := r.PathValue("lobbyId")
+		lobbyId, err := uuid.Parse(lobbyIdString)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Failed to get lobby id from path."))
+			return
+		}
+
+		player, err := getLobbyRequestParticipant(r, lobbyId)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		allowed, err := database.LobbyAllowsSpecial(lobbyId, special.Info().Key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if !allowed {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("This special is turned off in this lobby."))
+			return
+		}
+
+		// the specials panel hides what cannot be used, but requests can be sent anyway
+		data, err := database.GetPlayerSpecialsData(player.Id)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if !special.Available(data) || !special.Usable(data) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("This special cannot be used right now."))
+			return
+		}
+
+		err = r.ParseForm()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Failed to parse form."))
+			return
 		}
+
+		// specials that draw cards can run the draw pile out
+		result, err := special.Use(game.SpecialUse{LobbyId: lobbyId, Player: player, Form: r.Form})
+		if err == nil {
+			recordPlayerAction(player.Id)
+		}
+		writeSpecialResult(w, lobbyId, result, err)
+		game.BroadcastReshuffles(lobbyId)
 	}
+}
 
-	if credits < 1 {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("No credits provided."))
-		return
+// UndoSpecial returns the handler for taking back a registered special.
+func UndoSpecial(special game.UndoableSpecial) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lobbyIdString := r.PathValue("lobbyId")
+		lobbyId, err := uuid.Parse(lobbyIdString)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Failed to get lobby id from path."))
+			return
+		}
+
+		player, err := getLobbyRequestParticipant(r, lobbyId)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		// once responses are revealed or the round is decided there is nothing
+		// left to take back, whatever the request says
+		data, err := database.GetPlayerSpecialsData(player.Id)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if !special.Available(data) || !special.CanUndo(data) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("This special cannot be undone right now."))
+			return
+		}
+
+		err = r.ParseForm()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Failed to parse form."))
+			return
+		}
+
+		result, err := special.Undo(game.SpecialUse{LobbyId: lobbyId, Player: player, Form: r.Form})
+		if err == nil {
+			recordPlayerAction(player.Id)
+		}
+		writeSpecialResult(w, lobbyId, result, err)
 	}
+}
 
-	playerState, err := database.GetPlayerGameState(player.Id)
+func ResetResponses(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
 		return
 	}
 
-	if lobby.FreeCredits-playerState.CreditsSpent < credits {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("You do not have that many credits to spend."))
+	player, err := getLobbyRequestParticipant(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.AlertLobby(player.Id, credits)
+	err = database.ResetResponses(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("alert;;%d;;%s;;%s", credits, player.Name, text))
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Reset responses.")
+
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func GambleCredits(w http.ResponseWriter, r *http.Request) {
+func PlayFreeText(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -677,20 +1022,26 @@ func GambleCredits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	lobby, err := database.GetLobby(lobbyId)
+	isChoosingPrompt, err := database.IsJudgeChoosingPrompt(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
+	if isChoosingPrompt {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("The judge is still choosing the prompt."))
+		return
+	}
+
 	err = r.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -698,136 +1049,73 @@ func GambleCredits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var credits int
+	var text string
 	for key, val := range r.Form {
-		if key == "credits" {
-			credits, err = strconv.Atoi(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse credits."))
-				return
-			}
+		if key == "text" {
+			text = strings.TrimSpace(val[0])
 		}
 	}
 
-	if credits < 1 {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("No credits provided."))
-		return
-	}
-
-	playerState, err := database.GetPlayerGameState(player.Id)
+	lobby, err := database.GetLobby(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	if lobby.FreeCredits-playerState.CreditsSpent < credits {
+	if !lobby.FreeTextMode {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("You do not have that many credits to gamble."))
-		return
-	}
-
-	gambleWon, err := database.GambleCredits(player.Id, credits)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(err.Error()))
+		_, _ = w.Write([]byte("Lobby is not in free text mode."))
 		return
 	}
 
-	if gambleWon {
-		websocket.PlayerBroadcast(player.Id, "Congratulations, you <green>won</> your gamble!")
-	} else {
-		websocket.PlayerBroadcast(player.Id, "Sorry, you <red>lost</> your gamble...")
-	}
-
-	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
-}
-
-func BetOnWin(w http.ResponseWriter, r *http.Request) {
-	lobbyIdString := r.PathValue("lobbyId")
-	lobbyId, err := uuid.Parse(lobbyIdString)
-	if err != nil {
+	if text == "" {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get lobby id from path."))
-		return
-	}
-
-	player, err := getLobbyRequestPlayer(r, lobbyId)
-	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+		_, _ = w.Write([]byte("No text found."))
 		return
 	}
 
-	playerState, err := database.GetPlayerGameState(player.Id)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(err.Error()))
+	if len([]rune(text)) > lobby.FreeTextMaxLength {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(fmt.Sprintf("Text cannot be longer than %d characters.", lobby.FreeTextMaxLength)))
 		return
 	}
 
-	if playerState.BetOnWin > 0 {
-		w.WriteHeader(http.StatusNotAcceptable)
-		_, _ = w.Write([]byte(fmt.Sprintf("A bet of %d has already been placed.", playerState.BetOnWin)))
+	if textHasFilteredWord(text, lobby.FreeTextWordFilter.String) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Text contains a filtered word."))
 		return
 	}
 
-	lobby, err := database.GetLobby(lobbyId)
+	existingCardId, err := database.GetLobbyCardId(lobbyId, text)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = r.ParseForm()
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
-		return
-	}
-
-	var credits int
-	for key, val := range r.Form {
-		if key == "credits" {
-			credits, err = strconv.Atoi(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse credits."))
-				return
-			}
-		}
-	}
-
-	if credits < 1 {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("No credits provided."))
-		return
-	}
-
-	if lobby.FreeCredits-playerState.CreditsSpent < credits {
+	if existingCardId != uuid.Nil {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("You do not have that many credits to bet."))
+		_, _ = w.Write([]byte("Text has already been played."))
 		return
 	}
 
-	err = database.BetOnWin(player.Id, credits)
+	err = database.PlayFreeText(player.Id, text)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
+	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
 	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
 	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	game.BroadcastReshuffles(lobbyId)
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func BetOnWinUndo(w http.ResponseWriter, r *http.Request) {
+func WithdrawCard(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -836,40 +1124,43 @@ func BetOnWinUndo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	playerState, err := database.GetPlayerGameState(player.Id)
+	responseCardIdString := r.PathValue("responseCardId")
+	responseCardId, err := uuid.Parse(responseCardIdString)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get response card id from path."))
 		return
 	}
 
-	if playerState.BetOnWin == 0 {
-		w.WriteHeader(http.StatusNotAcceptable)
-		_, _ = w.Write([]byte("No bet has been placed."))
+	_, err = getLobbyRequestParticipant(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.BetOnWinUndo(player.Id)
+	err = database.WithdrawCard(responseCardId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
+	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
 	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func AddExtraResponse(w http.ResponseWriter, r *http.Request) {
+func DiscardCard(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -878,29 +1169,35 @@ func AddExtraResponse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	cardIdString := r.PathValue("cardId")
+	cardId, err := uuid.Parse(cardIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get card id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.AddExtraResponse(player.Id)
+	err = database.DiscardCard(player.Id, cardId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Purchased an extra response.")
-
 	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	game.BroadcastReshuffles(lobbyId)
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func AddExtraResponseUndo(w http.ResponseWriter, r *http.Request) {
+func VoteToKick(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -909,29 +1206,52 @@ func AddExtraResponseUndo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	subjectPlayerIdString := r.PathValue("playerId")
+	subjectPlayerId, err := uuid.Parse(subjectPlayerIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get player id from path."))
+		return
+	}
+
+	subjectPlayer, err := gsDatabase.GetPlayer(subjectPlayerId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.AddExtraResponseUndo(player.Id)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	isKicked, err := database.VoteToKick(player.Id, subjectPlayer.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Undid purchase of an extra response.")
+	if isKicked {
+		websocket.LobbyBroadcast(lobbyId, "<red>Player Kicked</>: <green>"+subjectPlayer.Name+"</>")
+		websocket.LobbyBroadcast(lobbyId, withEquippedAnimation("player-kicked", player.UserId, database.CosmeticKindKick))
+		go func() {
+			time.Sleep(2 * time.Second)
+			websocket.PlayerBroadcast(subjectPlayerId, "exit")
+		}()
+	} else {
+		websocket.LobbyBroadcast(lobbyId, "Someone voted to kick <green>"+subjectPlayer.Name+"</> out of the lobby")
+		websocket.PlayerBroadcast(player.Id, "refresh-lobby-game-stats")
+	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func BlockResponse(w http.ResponseWriter, r *http.Request) {
+func VoteToKickUndo(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -940,55 +1260,43 @@ func BlockResponse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	subjectPlayerIdString := r.PathValue("playerId")
+	subjectPlayerId, err := uuid.Parse(subjectPlayerIdString)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get player id from path."))
 		return
 	}
 
-	err = r.ParseForm()
+	subjectPlayer, err := gsDatabase.GetPlayer(subjectPlayerId)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	var targetPlayerId uuid.UUID
-	for key, val := range r.Form {
-		if key == "targetPlayerId" {
-			targetPlayerId, err = uuid.Parse(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse target player id."))
-				return
-			}
-		}
-	}
-
-	targetPlayer, err := gsDatabase.GetPlayer(targetPlayerId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.BlockResponse(player.Id, targetPlayerId)
+	err = database.VoteToKickUndo(player.Id, subjectPlayer.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Blocked <green>"+targetPlayer.Name+"</> from responding.")
+	websocket.LobbyBroadcast(lobbyId, "Someone removed their vote to kick <green>"+subjectPlayer.Name+"</> out of the lobby")
+	websocket.PlayerBroadcast(player.Id, "refresh-lobby-game-stats")
 
-	websocket.PlayerBroadcast(targetPlayerId, "refresh-player-hand")
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func PlaySurpriseCard(w http.ResponseWriter, r *http.Request) {
+func RevealResponse(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -997,14 +1305,22 @@ func PlaySurpriseCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	responseIdString := r.PathValue("responseId")
+	responseId, err := uuid.Parse(responseIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get response id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PlaySurpriseCard(player.Id)
+	err = database.RevealResponse(responseId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
@@ -1013,10 +1329,11 @@ func PlaySurpriseCard(w http.ResponseWriter, r *http.Request) {
 
 	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
 	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func PlayStealCard(w http.ResponseWriter, r *http.Request) {
+func ToggleRuleOutResponse(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1025,27 +1342,35 @@ func PlayStealCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	responseIdString := r.PathValue("responseId")
+	responseId, err := uuid.Parse(responseIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get response id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PlayStealCard(player.Id)
+	err = database.ToggleRuleOutResponse(responseId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-hand")
 	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
 	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func PlayFindCard(w http.ResponseWriter, r *http.Request) {
+func PickWinner(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1054,100 +1379,61 @@ func PlayFindCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
-	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
-		return
-	}
-
-	err = r.ParseForm()
+	responseIdString := r.PathValue("responseId")
+	responseId, err := uuid.Parse(responseIdString)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
+		_, _ = w.Write([]byte("Failed to get response id from path."))
 		return
 	}
 
-	var cardId uuid.UUID
-	for key, val := range r.Form {
-		if key == "cardId" {
-			cardId, err = uuid.Parse(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse card id."))
-				return
-			}
-		}
-	}
-
-	err = database.PlayFindCard(player.Id, cardId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
-	w.WriteHeader(http.StatusOK)
-}
-
-func PlayWildCard(w http.ResponseWriter, r *http.Request) {
-	lobbyIdString := r.PathValue("lobbyId")
-	lobbyId, err := uuid.Parse(lobbyIdString)
+	// a prompt that cannot be saved should not stop the round from ending
+	err = saveCustomJudgeCard(r, lobbyId, player)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get lobby id from path."))
-		return
+		log.Println(err)
+		websocket.PlayerBroadcast(player.Id, "<red>Prompt Not Saved</>: "+err.Error())
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	cardTextStart, err := database.GetResponseCardTextStart(responseId)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = r.ParseForm()
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
-		return
-	}
-
-	var text string
-	for key, val := range r.Form {
-		if key == "text" {
-			text = val[0]
-		}
-	}
+	websocket.LobbyBroadcast(lobbyId, "<blue>Winning Card</>: "+cardTextStart)
 
-	existingCardId, err := database.GetCardId(lobbyId, text)
+	roundId, err := database.GetLobbyRoundId(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	if existingCardId != uuid.Nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Wild card text has already been played."))
-		return
-	}
-
-	err = database.PlayWildCard(player.Id, text)
+	winnerName, err := database.PickWinner(responseId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	websocket.LobbyBroadcast(lobbyId, "<blue>Winner</>: <green>"+winnerName+"</>")
+	game.BroadcastStreakMilestones(lobbyId, roundId)
+
+	game.BroadcastReshuffles(lobbyId)
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func PerkHandSizeAdvantage(w http.ResponseWriter, r *http.Request) {
+func PickRandomWinner(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1156,36 +1442,52 @@ func PerkHandSizeAdvantage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PerkHandSizeAdvantage(player.Id)
+	// a prompt that cannot be saved should not stop the round from ending
+	err = saveCustomJudgeCard(r, lobbyId, player)
+	if err != nil {
+		log.Println(err)
+		websocket.PlayerBroadcast(player.Id, "<red>Prompt Not Saved</>: "+err.Error())
+	}
+
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Random Winner!")
+
+	roundId, err := database.GetLobbyRoundId(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	playerState, err := database.GetPlayerGameState(player.Id)
+	winnerName, err := database.PickRandomWinner(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
-	websocket.PlayerBroadcast(player.Id, fmt.Sprintf("Perk: Your hand size is now increased by <green>%d</> more than the lobby default.", playerState.HandSizeAdvantage))
+	if winnerName == "" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_, _ = w.Write([]byte("Could not find a random response winner that isn't ruled out."))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, "<blue>Winner</>: <green>"+winnerName+"</>")
+	game.BroadcastStreakMilestones(lobbyId, roundId)
 
+	game.BroadcastReshuffles(lobbyId)
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func PerkDiscardAdvantage(w http.ResponseWriter, r *http.Request) {
+func FlipTable(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1194,29 +1496,33 @@ func PerkDiscardAdvantage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PerkDiscardAdvantage(player.Id)
+	err = database.FlipTable(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
-	websocket.PlayerBroadcast(player.Id, "Perk: You can now discard more often (whenever you cannot play a card).")
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: FLIP THE TABLE!")
+	websocket.LobbyBroadcast(lobbyId, withEquippedAnimation("table-flipped", player.UserId, database.CosmeticKindFlipTable))
+	go func() {
+		time.Sleep(2 * time.Second)
+		websocket.PlayerBroadcast(player.Id, "exit")
+	}()
 
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
+	_, _ = w.Write([]byte("Table Flipped!"))
 }
 
-func PerkHandicapAdvantage(w http.ResponseWriter, r *http.Request) {
+func SkipPrompt(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1225,28 +1531,28 @@ func PerkHandicapAdvantage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PerkHandicapAdvantage(player.Id)
+	err = database.SkipPrompt(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
-	websocket.PlayerBroadcast(player.Id, "Perk: Your handicap is now decreased by <green>1</> (cannot go negative).")
+	game.BroadcastReshuffles(lobbyId)
+	websocket.LobbyBroadcast(lobbyId, "refresh")
 
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func PerkSpyAdvantage(w http.ResponseWriter, r *http.Request) {
+func SetCustomPrompt(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1255,73 +1561,73 @@ func PerkSpyAdvantage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestJudge(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.PerkSpyAdvantage(player.Id)
+	lobby, err := database.GetLobby(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
-	websocket.PlayerBroadcast(player.Id, "refresh-lobby-game-stats")
-	websocket.PlayerBroadcast(player.Id, "Perk: You can now spy on other player's credits.")
-
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
-}
+	if !lobby.CustomPrompts {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Custom prompts are not enabled for this lobby."))
+		return
+	}
 
-func WithdrawCard(w http.ResponseWriter, r *http.Request) {
-	lobbyIdString := r.PathValue("lobbyId")
-	lobbyId, err := uuid.Parse(lobbyIdString)
-	if err != nil {
+	if lobby.CaptionMode {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		_, _ = w.Write([]byte("Caption contests only use image and video prompts."))
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	err = r.ParseForm()
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
 		return
 	}
 
-	responseCardIdString := r.PathValue("responseCardId")
-	responseCardId, err := uuid.Parse(responseCardIdString)
-	if err != nil {
+	var text string
+	for key, val := range r.Form {
+		if key == "text" {
+			text = val[0]
+		}
+	}
+
+	text = normalizeBlanks(text)
+	if text == "" {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get response card id from path."))
+		_, _ = w.Write([]byte("Prompt text cannot be empty."))
 		return
 	}
 
-	_, err = getLobbyRequestPlayer(r, lobbyId)
-	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+	if len([]rune(text)) > 510 {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Prompt text is too long."))
 		return
 	}
 
-	err = database.WithdrawCard(responseCardId)
+	err = database.SetCustomJudgeCard(lobbyId, text)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-	websocket.PlayerBroadcast(player.Id, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Wrote a custom prompt")
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func DiscardCard(w http.ResponseWriter, r *http.Request) {
+func WriteCard(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1330,86 +1636,114 @@ func DiscardCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cardIdString := r.PathValue("cardId")
-	cardId, err := uuid.Parse(cardIdString)
+	player, err := getLobbyRequestParticipant(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get card id from path."))
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	err = r.ParseForm()
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
 		return
 	}
 
-	err = database.DiscardCard(player.Id, cardId)
+	var category string
+	var text string
+	for key, val := range r.Form {
+		if key == "category" {
+			category = val[0]
+		} else if key == "text" {
+			text = val[0]
+		}
+	}
+
+	data, err := database.GetLobbyGameBoardData(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.PlayerBroadcast(player.Id, "refresh-player-hand")
-	w.WriteHeader(http.StatusOK)
-}
+	if !data.LobbyIsWriting || data.LobbyWriteSecondsLeft <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("The writing phase is over."))
+		return
+	}
 
-func VoteToKick(w http.ResponseWriter, r *http.Request) {
-	lobbyIdString := r.PathValue("lobbyId")
-	lobbyId, err := uuid.Parse(lobbyIdString)
-	if err != nil {
+	if category == "PROMPT" {
+		if data.LobbyCaptionMode {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Caption contests only use image and video prompts."))
+			return
+		}
+		if data.PlayerWrotePromptCount >= data.LobbyWritePromptCount {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("You have already written all of your prompts."))
+			return
+		}
+	} else if category == "RESPONSE" {
+		if data.PlayerWroteResponseCount >= data.LobbyWriteResponseCount {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("You have already written all of your responses."))
+			return
+		}
+	} else {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		_, _ = w.Write([]byte("Invalid category."))
 		return
 	}
 
-	subjectPlayerIdString := r.PathValue("playerId")
-	subjectPlayerId, err := uuid.Parse(subjectPlayerIdString)
-	if err != nil {
+	text = normalizeBlanks(text)
+	if text == "" {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get player id from path."))
+		_, _ = w.Write([]byte("Card text cannot be empty."))
 		return
 	}
 
-	subjectPlayer, err := gsDatabase.GetPlayer(subjectPlayerId)
-	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+	if len([]rune(text)) > 510 {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Card text is too long."))
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	err = database.WriteCard(player.Id, category, text)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	isKicked, err := database.VoteToKick(player.Id, subjectPlayer.Id)
+	isComplete, err := database.IsWritingPhaseComplete(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	if isKicked {
-		websocket.LobbyBroadcast(lobbyId, "<red>Player Kicked</>: <green>"+subjectPlayer.Name+"</>")
-		websocket.LobbyBroadcast(lobbyId, "player-kicked")
-		go func() {
-			time.Sleep(2 * time.Second)
-			websocket.PlayerBroadcast(subjectPlayerId, "exit")
-		}()
+	if isComplete {
+		err = database.EndWritingPhase(lobbyId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		websocket.LobbyBroadcast(lobbyId, "<blue>Writing Phase</>: All cards are written, let the game begin!")
+		game.BroadcastReshuffles(lobbyId)
+		websocket.LobbyBroadcast(lobbyId, "refresh")
 	} else {
-		websocket.LobbyBroadcast(lobbyId, "Someone voted to kick <green>"+subjectPlayer.Name+"</> out of the lobby")
-		websocket.PlayerBroadcast(player.Id, "refresh-lobby-game-stats")
+		websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-info")
+		websocket.PlayerBroadcast(player.Id, "refresh-lobby-game-board")
 	}
 
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func VoteToKickUndo(w http.ResponseWriter, r *http.Request) {
+func EndWritingPhase(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1418,42 +1752,51 @@ func VoteToKickUndo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	subjectPlayerIdString := r.PathValue("playerId")
-	subjectPlayerId, err := uuid.Parse(subjectPlayerIdString)
+	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get player id from path."))
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	subjectPlayer, err := gsDatabase.GetPlayer(subjectPlayerId)
+	data, err := database.GetLobbyGameBoardData(player.Id)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
-	if err != nil {
+	if !data.LobbyIsWriting {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if data.LobbyIsPaused {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("The game is paused."))
+		return
+	}
+
+	if data.LobbyWriteSecondsLeft > 0 && !data.PlayerRole.Can(database.LobbyPermissionRunGame) {
 		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(err.Error()))
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can end the writing phase early."))
 		return
 	}
 
-	err = database.VoteToKickUndo(player.Id, subjectPlayer.Id)
+	err = database.EndWritingPhase(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "Someone removed their vote to kick <green>"+subjectPlayer.Name+"</> out of the lobby")
-	websocket.PlayerBroadcast(player.Id, "refresh-lobby-game-stats")
-
+	websocket.LobbyBroadcast(lobbyId, "<blue>Writing Phase</>: Time is up, let the game begin!")
+	game.BroadcastReshuffles(lobbyId)
+	websocket.LobbyBroadcast(lobbyId, "refresh")
 	w.WriteHeader(http.StatusOK)
 }
 
-func RevealResponse(w http.ResponseWriter, r *http.Request) {
+func SaveWrittenCards(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1462,34 +1805,112 @@ func RevealResponse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseIdString := r.PathValue("responseId")
-	responseId, err := uuid.Parse(responseIdString)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get response id from path."))
-		return
-	}
-
-	_, err = getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.RevealResponse(responseId)
+	role, err := database.GetLobbyPlayerRole(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
-	w.WriteHeader(http.StatusOK)
-}
+	if !role.Can(database.LobbyPermissionRunGame) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can save the written cards."))
+		return
+	}
 
-func ToggleRuleOutResponse(w http.ResponseWriter, r *http.Request) {
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var name string
+	var password string
+	var passwordConfirm string
+	for key, val := range r.Form {
+		if key == "name" {
+			name = val[0]
+		} else if key == "password" {
+			password = val[0]
+		} else if key == "passwordConfirm" {
+			passwordConfirm = val[0]
+		}
+	}
+
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No name found."))
+		return
+	}
+
+	if password == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No password found."))
+		return
+	}
+
+	if password != passwordConfirm {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Passwords do not match."))
+		return
+	}
+
+	existingDeckId, err := gsDatabase.GetDeckId(name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if existingDeckId != uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Deck name already exists."))
+		return
+	}
+
+	deckId, err := gsDatabase.CreateDeck(name, password, false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	err = gsDatabase.AddUserDeckAccess(player.UserId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	err = database.SetDeckCollaboratorRole(deckId, player.UserId, database.DeckRoleOwner)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	err = database.CopyWrittenCardsToDeck(lobbyId, deckId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Saved the written cards to deck "+name)
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func PickPromptCandidate(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1498,33 +1919,35 @@ func ToggleRuleOutResponse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseIdString := r.PathValue("responseId")
-	responseId, err := uuid.Parse(responseIdString)
+	cardIdString := r.PathValue("cardId")
+	cardId, err := uuid.Parse(cardIdString)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get response id from path."))
+		_, _ = w.Write([]byte("Failed to get card id from path."))
 		return
 	}
 
-	_, err = getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestJudge(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.ToggleRuleOutResponse(responseId)
+	err = database.PickPromptCandidate(lobbyId, cardId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 }
 
-func PickWinner(w http.ResponseWriter, r *http.Request) {
+func Spectate(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1533,44 +1956,65 @@ func PickWinner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseIdString := r.PathValue("responseId")
-	responseId, err := uuid.Parse(responseIdString)
+	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to get response id from path."))
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	_, err = getLobbyRequestPlayer(r, lobbyId)
+	isSpectator, err := database.IsPlayerSpectator(player.Id)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
+		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	cardTextStart, err := database.GetResponseCardTextStart(responseId)
+	if isSpectator {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	lobby, err := database.GetLobby(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<blue>Winning Card</>: "+cardTextStart)
+	if !lobby.AllowSpectators {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("This lobby does not allow spectators."))
+		return
+	}
 
-	winnerName, err := database.PickWinner(responseId)
+	spectatorCount, err := database.CountLobbySpectators(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<blue>Winner</>: <green>"+winnerName+"</>")
+	if spectatorCount >= lobby.MaxSpectators {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("This lobby has reached its spectator limit."))
+		return
+	}
+
+	err = database.SetPlayerSpectator(player.Id, true)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
 
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Is now spectating.")
+	game.BroadcastReshuffles(lobbyId)
 	websocket.LobbyBroadcast(lobbyId, "refresh")
 	w.WriteHeader(http.StatusOK)
 }
 
-func PickRandomWinner(w http.ResponseWriter, r *http.Request) {
+func JoinGame(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1586,28 +2030,32 @@ func PickRandomWinner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Random Winner!")
-
-	winnerName, err := database.PickRandomWinner(lobbyId)
+	isSpectator, err := database.IsPlayerSpectator(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	if winnerName == "" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		_, _ = w.Write([]byte("Could not find a random response winner that isn't ruled out."))
+	if !isSpectator {
+		w.WriteHeader(http.StatusOK)
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<blue>Winner</>: <green>"+winnerName+"</>")
+	err = database.SetPlayerSpectator(player.Id, false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
 
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Joined the game.")
+	game.BroadcastReshuffles(lobbyId)
 	websocket.LobbyBroadcast(lobbyId, "refresh")
 	w.WriteHeader(http.StatusOK)
 }
 
-func FlipTable(w http.ResponseWriter, r *http.Request) {
+func ReadyToStart(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1618,30 +2066,24 @@ func FlipTable(w http.ResponseWriter, r *http.Request) {
 
 	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.FlipTable(player.Id)
+	err = database.SetPlayerReadyToStart(player.Id, true)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: FLIP THE TABLE!")
-	websocket.LobbyBroadcast(lobbyId, "table-flipped")
-	go func() {
-		time.Sleep(2 * time.Second)
-		websocket.PlayerBroadcast(player.Id, "exit")
-	}()
-
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Is ready.")
+	websocket.LobbyBroadcast(lobbyId, "refresh")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("Table Flipped!"))
 }
 
-func SkipPrompt(w http.ResponseWriter, r *http.Request) {
+func ReadyToStartUndo(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1650,26 +2092,26 @@ func SkipPrompt(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.SkipPrompt(lobbyId)
+	err = database.SetPlayerReadyToStart(player.Id, false)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Is not ready.")
 	websocket.LobbyBroadcast(lobbyId, "refresh")
-
 	w.WriteHeader(http.StatusOK)
 }
 
-func SetName(w http.ResponseWriter, r *http.Request) {
+func StartGame(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1680,59 +2122,49 @@ func SetName(w http.ResponseWriter, r *http.Request) {
 
 	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = r.ParseForm()
+	data, err := database.GetLobbyWaitingRoomData(lobbyId, player.Id)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	var name string
-	for key, val := range r.Form {
-		if key == "name" {
-			name = val[0]
-		}
-	}
-
-	if name == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("No name found."))
+	if !data.PlayerRole.Can(database.LobbyPermissionRunGame) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can start the game."))
 		return
 	}
 
-	existingLobbyId, err := gsDatabase.GetLobbyId(name)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(err.Error()))
+	if data.SeatedCount < data.MinPlayers {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(fmt.Sprintf("At least %d players are needed to start.", data.MinPlayers)))
 		return
 	}
 
-	if existingLobbyId != uuid.Nil && existingLobbyId != lobbyId {
+	if data.ReadyCount < data.SeatedCount {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Lobby name already exists."))
+		_, _ = w.Write([]byte("Every player must be ready to start."))
 		return
 	}
 
-	err = gsDatabase.SetLobbyName(lobbyId, name)
+	err = database.InitLobbyGame(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Lobby name set to "+name)
-	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-info")
-
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Started the game.")
+	websocket.LobbyBroadcast(lobbyId, "refresh")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func SetMessage(w http.ResponseWriter, r *http.Request) {
+func PauseGame(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1743,39 +2175,38 @@ func SetMessage(w http.ResponseWriter, r *http.Request) {
 
 	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = r.ParseForm()
+	role, err := database.GetLobbyPlayerRole(player.Id)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	var message string
-	for key, val := range r.Form {
-		if key == "message" {
-			message = val[0]
-		}
+	if !role.Can(database.LobbyPermissionRunGame) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can pause the game."))
+		return
 	}
 
-	err = gsDatabase.SetLobbyMessage(lobbyId, message)
+	err = database.PauseLobby(lobbyId, player.UserId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Lobby message set to "+message)
-
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Paused the game.")
+	websocket.LobbyBroadcast(lobbyId, "lobby-paused")
+	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func SetDrawPriority(w http.ResponseWriter, r *http.Request) {
+func ResumeGame(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1786,39 +2217,38 @@ func SetDrawPriority(w http.ResponseWriter, r *http.Request) {
 
 	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = r.ParseForm()
+	role, err := database.GetLobbyPlayerRole(player.Id)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	var drawPriority string
-	for key, val := range r.Form {
-		if key == "drawPriority" {
-			drawPriority = val[0]
-		}
+	if !role.Can(database.LobbyPermissionRunGame) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can resume the game."))
+		return
 	}
 
-	err = database.SetLobbyDrawPriority(lobbyId, drawPriority)
+	err = database.ResumeLobby(lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby draw priority set to %s", player.Name, drawPriority))
-
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Resumed the game.")
+	websocket.LobbyBroadcast(lobbyId, "lobby-resumed")
+	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func SetHandSize(w http.ResponseWriter, r *http.Request) {
+func AddBot(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1828,12 +2258,25 @@ func SetHandSize(w http.ResponseWriter, r *http.Request) {
 	}
 
 	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
+	if !role.Can(database.LobbyPermissionRunGame) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can add bots."))
+		return
+	}
+
 	err = r.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -1841,41 +2284,30 @@ func SetHandSize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var handSize int
+	var personality string
+	var difficulty string
 	for key, val := range r.Form {
-		if key == "handSize" {
-			handSize, err = strconv.Atoi(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse hand size."))
-				return
-			}
+		if key == "personality" {
+			personality = val[0]
+		} else if key == "difficulty" {
+			difficulty = val[0]
 		}
 	}
 
-	if handSize < 6 {
-		handSize = 6
-	}
-
-	if handSize > 16 {
-		handSize = 16
-	}
-
-	err = database.SetLobbyHandSize(lobbyId, handSize)
+	botName, err := game.AddBot(lobbyId, personality, difficulty)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby hand size set to %d", player.Name, handSize))
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-hand")
-
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Added <green>%s</> to the lobby.", player.Name, botName))
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+	w.Header().Add("HX-Refresh", "true")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func SetRoundTimer(w http.ResponseWriter, r *http.Request) {
+func RemoveBot(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1884,10 +2316,1566 @@ func SetRoundTimer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	botIdString := r.PathValue("botId")
+	botId, err := uuid.Parse(botIdString)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get bot id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionRunGame) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can remove bots."))
+		return
+	}
+
+	botName, err := game.RemoveBot(lobbyId, botId)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Removed <green>%s</> from the lobby.", player.Name, botName))
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	subjectPlayerIdString := r.PathValue("playerId")
+	subjectPlayerId, err := uuid.Parse(subjectPlayerIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get player id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionManageRoles) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner can hand over the lobby."))
+		return
+	}
+
+	subject, err := getLobbyMember(lobbyId, subjectPlayerId)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if subject.Role == database.LobbyRoleOwner {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Player already owns the lobby."))
+		return
+	}
+
+	if subject.IsSpectator {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Spectators cannot own the lobby."))
+		return
+	}
+
+	err = database.TransferLobbyOwner(subject.PlayerId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Made <green>%s</> the lobby owner.", player.Name, subject.Name))
+	websocket.LobbyBroadcast(lobbyId, "reload")
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetCoHost(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	subjectPlayerIdString := r.PathValue("playerId")
+	subjectPlayerId, err := uuid.Parse(subjectPlayerIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get player id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionManageRoles) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner can add co-hosts."))
+		return
+	}
+
+	subject, err := getLobbyMember(lobbyId, subjectPlayerId)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if subject.Role != database.LobbyRolePlayer {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Player is already a host."))
+		return
+	}
+
+	err = database.SetPlayerCoHost(subject.PlayerId, true)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Made <green>%s</> a co-host.", player.Name, subject.Name))
+	websocket.LobbyBroadcast(lobbyId, "reload")
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetCoHostUndo(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	subjectPlayerIdString := r.PathValue("playerId")
+	subjectPlayerId, err := uuid.Parse(subjectPlayerIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get player id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionManageRoles) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner can remove co-hosts."))
+		return
+	}
+
+	subject, err := getLobbyMember(lobbyId, subjectPlayerId)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if subject.Role != database.LobbyRoleCoHost {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Player is not a co-host."))
+		return
+	}
+
+	err = database.SetPlayerCoHost(subject.PlayerId, false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Removed <green>%s</> as a co-host.", player.Name, subject.Name))
+	websocket.LobbyBroadcast(lobbyId, "reload")
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetName(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditDetails) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner can change the lobby name."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var name string
+	for key, val := range r.Form {
+		if key == "name" {
+			name = val[0]
+		}
+	}
+
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("No name found."))
+		return
+	}
+
+	existingLobbyId, err := gsDatabase.GetLobbyId(name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if existingLobbyId != uuid.Nil && existingLobbyId != lobbyId {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Lobby name already exists."))
+		return
+	}
+
+	err = gsDatabase.SetLobbyName(lobbyId, name)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Lobby name set to "+name)
+	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-info")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetMessage(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditDetails) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner can change the lobby message."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var message string
+	for key, val := range r.Form {
+		if key == "message" {
+			message = val[0]
+		}
+	}
+
+	err = gsDatabase.SetLobbyMessage(lobbyId, message)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Lobby message set to "+message)
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetDrawPriority(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the draw priority."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var drawPriority string
+	for key, val := range r.Form {
+		if key == "drawPriority" {
+			drawPriority = val[0]
+		}
+	}
+
+	err = database.SetLobbyDrawPriority(lobbyId, drawPriority)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby draw priority set to %s", player.Name, drawPriority))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetHandSize(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the hand size."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var handSize int
+	for key, val := range r.Form {
+		if key == "handSize" {
+			handSize, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse hand size."))
+				return
+			}
+		}
+	}
+
+	if handSize < 6 {
+		handSize = 6
+	}
+
+	if handSize > 16 {
+		handSize = 16
+	}
+
+	err = database.SetLobbyHandSize(lobbyId, handSize)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby hand size set to %d", player.Name, handSize))
+	game.BroadcastReshuffles(lobbyId)
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-hand")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetRoundTimer(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the round timer."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var roundTimer int
+	for key, val := range r.Form {
+		if key == "roundTimer" {
+			roundTimer, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse round timer."))
+				return
+			}
+		}
+	}
+
+	if roundTimer < 0 {
+		roundTimer = 0
+	}
+
+	if roundTimer > 300 {
+		roundTimer = 300
+	}
+
+	err = database.SetLobbyRoundTimer(lobbyId, roundTimer)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if roundTimer > 60 {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby round timer set to %d mintues", player.Name, roundTimer/60))
+	} else if roundTimer > 0 {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby round timer set to %d seconds", player.Name, roundTimer))
+	} else {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby round timer set to unlimited", player.Name))
+	}
+	websocket.LobbyBroadcast(lobbyId, "refresh")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func StartRoundTimer(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	lobby, err := database.GetLobby(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	isPaused, err := database.IsLobbyPaused(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if isPaused {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("The game is paused."))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("timer;;%d", lobby.RoundTimer))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(" Reset Timer"))
+}
+
+func SetFreeCredits(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the free credits."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var freeCredits int
+	for key, val := range r.Form {
+		if key == "freeCredits" {
+			freeCredits, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse free credits."))
+				return
+			}
+		}
+	}
+
+	if freeCredits < 0 {
+		freeCredits = 0
+	}
+
+	if freeCredits > 10 {
+		freeCredits = 10
+	}
+
+	err = database.SetLobbyFreeCredits(lobbyId, freeCredits)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby free credits set to %d", player.Name, freeCredits))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetFreeSpecialCards(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the free special cards."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var freeSpecialCards bool
+	for key, val := range r.Form {
+		if key == "freeSpecialCards" {
+			freeSpecialCards, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse free special cards."))
+				return
+			}
+		}
+	}
+
+	err = database.SetLobbyFreeSpecialCards(lobbyId, freeSpecialCards)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby free special cards set to %t", player.Name, freeSpecialCards))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetWinStreakThreshold(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the win streak threshold."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var winStreakThreshold int
+	for key, val := range r.Form {
+		if key == "winStreakThreshold" {
+			winStreakThreshold, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse win streak threshold."))
+				return
+			}
+		}
+	}
+
+	if winStreakThreshold < 1 {
+		winStreakThreshold = 1
+	}
+
+	if winStreakThreshold > 5 {
+		winStreakThreshold = 5
+	}
+
+	err = database.SetLobbyWinStreakThreshold(lobbyId, winStreakThreshold)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby win streak threshold set to %d", player.Name, winStreakThreshold))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetLoseStreakThreshold(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the lose streak threshold."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var loseStreakThreshold int
+	for key, val := range r.Form {
+		if key == "loseStreakThreshold" {
+			loseStreakThreshold, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse lose streak threshold."))
+				return
+			}
+		}
+	}
+
+	if loseStreakThreshold < 1 {
+		loseStreakThreshold = 1
+	}
+
+	if loseStreakThreshold > 5 {
+		loseStreakThreshold = 5
+	}
+
+	err = database.SetLobbyLoseStreakThreshold(lobbyId, loseStreakThreshold)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby lose streak threshold set to %d", player.Name, loseStreakThreshold))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func AddStreakRule(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the streak rules."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var streak string
+	var streakLength int
+	var streakReward database.StreakReward
+	var streakRewardAmount int
+	for key, val := range r.Form {
+		if key == "streak" {
+			streak = val[0]
+		} else if key == "streakLength" {
+			streakLength, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse streak length."))
+				return
+			}
+		} else if key == "streakReward" {
+			streakReward = database.StreakReward(val[0])
+		} else if key == "streakRewardAmount" {
+			streakRewardAmount, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse streak reward amount."))
+				return
+			}
+		}
+	}
+
+	if streak != "WINNING" && streak != "LOSING" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Streak must be winning or losing."))
+		return
+	}
+
+	if !database.IsStreakReward(streakReward) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Unknown streak reward."))
+		return
+	}
+
+	if streakLength < 1 {
+		streakLength = 1
+	}
+
+	if streakLength > 20 {
+		streakLength = 20
+	}
+
+	// credits can be taken away, every other reward is given at least once
+	minAmount := 1
+	if streakReward == database.StreakRewardCredits {
+		minAmount = -10
+		if streakRewardAmount == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Credit amount cannot be zero."))
+			return
+		}
+	}
+
+	if streakRewardAmount < minAmount {
+		streakRewardAmount = minAmount
+	}
+
+	if streakRewardAmount > 10 {
+		streakRewardAmount = 10
+	}
+
+	if streakReward.IsPerk() {
+		streakRewardAmount = 1
+	}
+
+	err = database.SetLobbyStreakRule(lobbyId, streak, streakLength, streakReward, streakRewardAmount)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Added a %d round %s streak rule", player.Name, streakLength, strings.ToLower(streak)))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func RemoveStreakRule(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	ruleIdString := r.PathValue("ruleId")
+	ruleId, err := uuid.Parse(ruleIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get rule id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the streak rules."))
+		return
+	}
+
+	err = database.RemoveLobbyStreakRule(lobbyId, ruleId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Removed a streak rule", player.Name))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetEconomy(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the prices and payouts."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	amounts := make(map[database.EconomyCategory]int)
+	for key, val := range r.Form {
+		category := database.EconomyCategory(key)
+		maxAmount := database.GetEconomyCategoryMaxAmount(category)
+		if maxAmount == 0 {
+			continue
+		}
+
+		amount, err := strconv.Atoi(val[0])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Failed to parse economy amount."))
+			return
+		}
+
+		if amount < 0 {
+			amount = 0
+		}
+
+		if amount > maxAmount {
+			amount = maxAmount
+		}
+
+		amounts[category] = amount
+	}
+
+	for category, amount := range amounts {
+		err = database.SetLobbyEconomyAmount(lobbyId, category, amount)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Updated the lobby prices and payouts", player.Name))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetSpecials(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can turn specials on or off."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	enabledSpecials := database.AllSpecials()
+	for key, val := range r.Form {
+		special, ok := game.GetSpecial(key)
+		if !ok {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(val[0])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Failed to parse special."))
+			return
+		}
+
+		if !enabled {
+			delete(enabledSpecials, special.Info().Key)
+		}
+	}
+
+	err = database.SetLobbySpecials(lobbyId, enabledSpecials)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Updated the lobby specials", player.Name))
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetPromptChoices(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the prompt choices."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var promptChoices int
+	for key, val := range r.Form {
+		if key == "promptChoices" {
+			promptChoices, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse prompt choices."))
+				return
+			}
+		}
+	}
+
+	if promptChoices < 1 {
+		promptChoices = 1
+	}
+
+	if promptChoices > 3 {
+		promptChoices = 3
+	}
+
+	err = database.SetLobbyPromptChoices(lobbyId, promptChoices)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby prompt choices set to %d (starting next round)", player.Name, promptChoices))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetCustomPrompts(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change custom prompts."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var customPrompts bool
+	for key, val := range r.Form {
+		if key == "customPrompts" {
+			customPrompts, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse custom prompts."))
+				return
+			}
+		}
+	}
+
+	if customPrompts {
+		lobby, err := database.GetLobby(lobbyId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if lobby.CaptionMode {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Caption contests only use image and video prompts."))
+			return
+		}
+	}
+
+	err = database.SetLobbyCustomPrompts(lobbyId, customPrompts)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby custom prompts set to %t", player.Name, customPrompts))
+	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-board")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetSpectators(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change spectator settings."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var allowSpectators bool
+	var maxSpectators int
+	for key, val := range r.Form {
+		if key == "allowSpectators" {
+			allowSpectators, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse allow spectators."))
+				return
+			}
+		} else if key == "maxSpectators" {
+			maxSpectators, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse max spectators."))
+				return
+			}
+		}
+	}
+
+	if maxSpectators < 1 {
+		maxSpectators = 1
+	}
+
+	if maxSpectators > 50 {
+		maxSpectators = 50
+	}
+
+	err = database.SetLobbySpectators(lobbyId, allowSpectators, maxSpectators)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if allowSpectators {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby spectators allowed, up to %d", player.Name, maxSpectators))
+	} else {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby spectators not allowed", player.Name))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetKickRules(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the kick rules."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var thresholdMode string
+	var voteCount int
+	var voteMinutes int
+	var banMinutes int
+	for key, val := range r.Form {
+		if key == "kickThresholdMode" {
+			thresholdMode = val[0]
+		} else if key == "kickVoteCount" {
+			voteCount, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse kick vote count."))
+				return
+			}
+		} else if key == "kickVoteMinutes" {
+			voteMinutes, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse kick vote minutes."))
+				return
+			}
+		} else if key == "kickBanMinutes" {
+			banMinutes, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse kick ban minutes."))
+				return
+			}
+		}
+	}
+
+	if thresholdMode != "COUNT" && thresholdMode != "MAJORITY" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invalid kick threshold."))
+		return
+	}
+
+	if voteCount < 1 {
+		voteCount = 1
+	}
+
+	if voteCount > 10 {
+		voteCount = 10
+	}
+
+	if voteMinutes < 0 {
+		voteMinutes = 0
+	}
+
+	if voteMinutes > 60 {
+		voteMinutes = 60
+	}
+
+	if banMinutes < 0 {
+		banMinutes = 0
+	}
+
+	if banMinutes > 1440 {
+		banMinutes = 1440
+	}
+
+	err = database.SetLobbyKickRules(lobbyId, thresholdMode, voteCount, voteMinutes, banMinutes)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if thresholdMode == "MAJORITY" {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby kicks set to a majority of players", player.Name))
+	} else {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby kicks set to %d votes", player.Name, voteCount))
+	}
+	websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-stats")
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func SetIdleRounds(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the idle rounds."))
 		return
 	}
 
@@ -1898,47 +3886,44 @@ func SetRoundTimer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var roundTimer int
+	var idleRounds int
 	for key, val := range r.Form {
-		if key == "roundTimer" {
-			roundTimer, err = strconv.Atoi(val[0])
+		if key == "idleRounds" {
+			idleRounds, err = strconv.Atoi(val[0])
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse round timer."))
+				_, _ = w.Write([]byte("Failed to parse idle rounds."))
 				return
 			}
 		}
 	}
 
-	if roundTimer < 0 {
-		roundTimer = 0
+	if idleRounds < 0 {
+		idleRounds = 0
 	}
 
-	if roundTimer > 300 {
-		roundTimer = 300
+	if idleRounds > 10 {
+		idleRounds = 10
 	}
 
-	err = database.SetLobbyRoundTimer(lobbyId, roundTimer)
+	err = database.SetLobbyIdleRounds(lobbyId, idleRounds)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	if roundTimer > 60 {
-		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby round timer set to %d mintues", player.Name, roundTimer/60))
-	} else if roundTimer > 0 {
-		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby round timer set to %d seconds", player.Name, roundTimer))
+	if idleRounds == 0 {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby idle players will be left alone", player.Name))
 	} else {
-		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby round timer set to unlimited", player.Name))
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby idle rounds set to %d", player.Name, idleRounds))
 	}
-	websocket.LobbyBroadcast(lobbyId, "refresh")
 
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("success"))
 }
 
-func StartRoundTimer(w http.ResponseWriter, r *http.Request) {
+func SetReshuffleExcludeRounds(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1947,20 +3932,71 @@ func StartRoundTimer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	lobby, err := database.GetLobby(lobbyId)
+	player, err := getLobbyRequestPlayer(r, lobbyId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("timer;;%d", lobby.RoundTimer))
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the reshuffle rules."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var excludeRounds int
+	for key, val := range r.Form {
+		if key == "reshuffleExcludeRounds" {
+			excludeRounds, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse reshuffle excluded rounds."))
+				return
+			}
+		}
+	}
+
+	if excludeRounds < 0 {
+		excludeRounds = 0
+	}
+
+	if excludeRounds > 10 {
+		excludeRounds = 10
+	}
+
+	err = database.SetLobbyReshuffleExcludeRounds(lobbyId, excludeRounds)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if excludeRounds == 0 {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby reshuffles will use every discarded card", player.Name))
+	} else {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby reshuffles will leave out the last %d rounds", player.Name, excludeRounds))
+	}
 
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(" Reset Timer"))
+	_, _ = w.Write([]byte("success"))
 }
 
-func SetFreeCredits(w http.ResponseWriter, r *http.Request) {
+func SetHandicapRules(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -1976,6 +4012,19 @@ func SetFreeCredits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the handicap rules."))
+		return
+	}
+
 	err = r.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -1983,41 +4032,204 @@ func SetFreeCredits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var freeCredits int
+	var handicapMode string
+	var handicapScale float64
+	var catchUpMode string
 	for key, val := range r.Form {
-		if key == "freeCredits" {
-			freeCredits, err = strconv.Atoi(val[0])
+		if key == "handicapMode" {
+			handicapMode = val[0]
+		} else if key == "handicapScale" {
+			handicapScale, err = strconv.ParseFloat(val[0], 64)
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse free credits."))
+				_, _ = w.Write([]byte("Failed to parse handicap scale."))
 				return
 			}
+		} else if key == "catchUpMode" {
+			catchUpMode = val[0]
 		}
 	}
 
-	if freeCredits < 0 {
-		freeCredits = 0
+	if handicapMode != "OFF" && handicapMode != "RANK" && handicapMode != "POINT-GAP" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invalid handicap."))
+		return
 	}
 
-	if freeCredits > 10 {
-		freeCredits = 10
+	if catchUpMode != "OFF" && catchUpMode != "POINTS" && catchUpMode != "CREDITS" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invalid catch-up."))
+		return
 	}
 
-	err = database.SetLobbyFreeCredits(lobbyId, freeCredits)
+	if handicapScale < 0 {
+		handicapScale = 0
+	}
+
+	if handicapScale > 10 {
+		handicapScale = 10
+	}
+
+	err = database.SetLobbyHandicapRules(lobbyId, handicapMode, handicapScale, catchUpMode)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby free credits set to %d", player.Name, freeCredits))
+	switch handicapMode {
+	case "OFF":
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby handicap turned off", player.Name))
+	case "RANK":
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby handicap set to players beaten, scaled by %g", player.Name, handicapScale))
+	case "POINT-GAP":
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby handicap set to points ahead, scaled by %g", player.Name, handicapScale))
+	}
 	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
 
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("success"))
 }
 
-func SetFreeSpecialCards(w http.ResponseWriter, r *http.Request) {
+func RemoveBan(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	banIdString := r.PathValue("banId")
+	banId, err := uuid.Parse(banIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get ban id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionManageBans) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can lift bans."))
+		return
+	}
+
+	userName, err := database.RemoveLobbyBan(lobbyId, banId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if userName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Ban not found."))
+		return
+	}
+
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lifted the ban on <green>%s</>.", player.Name, userName))
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func CreateInvite(w http.ResponseWriter, r *http.Request) {
+	lobbyIdString := r.PathValue("lobbyId")
+	lobbyId, err := uuid.Parse(lobbyIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get lobby id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionManageInvites) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner can create invite links."))
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to parse form."))
+		return
+	}
+
+	var expiresInHours int
+	var maxUses int
+	for key, val := range r.Form {
+		if key == "expiresInHours" {
+			expiresInHours, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse invite expiry."))
+				return
+			}
+		} else if key == "maxUses" {
+			maxUses, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse invite max uses."))
+				return
+			}
+		}
+	}
+
+	if expiresInHours < 0 {
+		expiresInHours = 0
+	}
+
+	if expiresInHours > 720 {
+		expiresInHours = 720
+	}
+
+	if maxUses < 0 {
+		maxUses = 0
+	}
+
+	if maxUses > 100 {
+		maxUses = 100
+	}
+
+	token, err := database.CreateLobbyInvite(lobbyId, player.UserId, expiresInHours, maxUses)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(apiAccess.GetInviteUrl(r, token)))
+}
+
+func RevokeInvite(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -2026,47 +4238,59 @@ func SetFreeSpecialCards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	inviteIdString := r.PathValue("inviteId")
+	inviteId, err := uuid.Parse(inviteIdString)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get invite id from path."))
+		return
+	}
+
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionManageInvites) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner can revoke invite links."))
+		return
+	}
+
+	invite, err := database.GetInvite(inviteId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = r.ParseForm()
-	if err != nil {
+	if invite.LobbyId.UUID != lobbyId {
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte("Failed to parse form."))
+		_, _ = w.Write([]byte("Invite not found."))
 		return
 	}
 
-	var freeSpecialCards bool
-	for key, val := range r.Form {
-		if key == "freeSpecialCards" {
-			freeSpecialCards, err = strconv.ParseBool(val[0])
-			if err != nil {
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse free special cards."))
-				return
-			}
-		}
-	}
-
-	err = database.SetLobbyFreeSpecialCards(lobbyId, freeSpecialCards)
+	err = database.RevokeInvite(inviteId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby free special cards set to %t", player.Name, freeSpecialCards))
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-
+	w.Header().Add("HX-Refresh", "true")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte("success"))
 }
 
-func SetWinStreakThreshold(w http.ResponseWriter, r *http.Request) {
+func SetMinPlayers(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -2082,6 +4306,19 @@ func SetWinStreakThreshold(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change min players."))
+		return
+	}
+
 	err = r.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -2089,41 +4326,40 @@ func SetWinStreakThreshold(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var winStreakThreshold int
+	var minPlayers int
 	for key, val := range r.Form {
-		if key == "winStreakThreshold" {
-			winStreakThreshold, err = strconv.Atoi(val[0])
+		if key == "minPlayers" {
+			minPlayers, err = strconv.Atoi(val[0])
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse win streak threshold."))
+				_, _ = w.Write([]byte("Failed to parse min players."))
 				return
 			}
 		}
 	}
 
-	if winStreakThreshold < 1 {
-		winStreakThreshold = 1
+	if minPlayers < 2 {
+		minPlayers = 2
 	}
 
-	if winStreakThreshold > 5 {
-		winStreakThreshold = 5
+	if minPlayers > 10 {
+		minPlayers = 10
 	}
 
-	err = database.SetLobbyWinStreakThreshold(lobbyId, winStreakThreshold)
+	err = database.SetLobbyMinPlayers(lobbyId, minPlayers)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby win streak threshold set to %d", player.Name, winStreakThreshold))
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
-
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby min players set to %d", player.Name, minPlayers))
+	websocket.LobbyBroadcast(lobbyId, "refresh")
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("success"))
 }
 
-func SetLoseStreakThreshold(w http.ResponseWriter, r *http.Request) {
+func SetFreeText(w http.ResponseWriter, r *http.Request) {
 	lobbyIdString := r.PathValue("lobbyId")
 	lobbyId, err := uuid.Parse(lobbyIdString)
 	if err != nil {
@@ -2139,6 +4375,19 @@ func SetLoseStreakThreshold(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change free text mode."))
+		return
+	}
+
 	err = r.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -2146,35 +4395,55 @@ func SetLoseStreakThreshold(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var loseStreakThreshold int
+	var freeTextMode bool
+	var freeTextHands bool
+	var freeTextMaxLength int
+	var freeTextWordFilter string
 	for key, val := range r.Form {
-		if key == "loseStreakThreshold" {
-			loseStreakThreshold, err = strconv.Atoi(val[0])
+		if key == "freeTextMode" {
+			freeTextMode, err = strconv.ParseBool(val[0])
 			if err != nil {
 				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write([]byte("Failed to parse lose streak threshold."))
+				_, _ = w.Write([]byte("Failed to parse free text mode."))
+				return
+			}
+		} else if key == "freeTextHands" {
+			freeTextHands, err = strconv.ParseBool(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse free text hands."))
 				return
 			}
+		} else if key == "freeTextMaxLength" {
+			freeTextMaxLength, err = strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse free text max length."))
+				return
+			}
+		} else if key == "freeTextWordFilter" {
+			freeTextWordFilter = val[0]
 		}
 	}
 
-	if loseStreakThreshold < 1 {
-		loseStreakThreshold = 1
+	if freeTextMaxLength < 10 {
+		freeTextMaxLength = 10
 	}
 
-	if loseStreakThreshold > 5 {
-		loseStreakThreshold = 5
+	if freeTextMaxLength > 255 {
+		freeTextMaxLength = 255
 	}
 
-	err = database.SetLobbyLoseStreakThreshold(lobbyId, loseStreakThreshold)
+	err = database.SetLobbyFreeTextSettings(lobbyId, freeTextMode, freeTextHands, freeTextMaxLength, strings.TrimSpace(freeTextWordFilter))
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby lose streak threshold set to %d", player.Name, loseStreakThreshold))
-	websocket.LobbyBroadcast(lobbyId, "refresh-player-specials")
+	websocket.LobbyBroadcast(lobbyId, fmt.Sprintf("<green>%s</>: Lobby free text mode set to %t", player.Name, freeTextMode))
+	game.BroadcastReshuffles(lobbyId)
+	websocket.LobbyBroadcast(lobbyId, "refresh-player-hand")
 
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("success"))
@@ -2189,9 +4458,9 @@ func SetResponseCount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = getLobbyRequestPlayer(r, lobbyId)
+	player, err := getLobbyRequestJudge(r, lobbyId)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnauthorized)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
@@ -2232,6 +4501,7 @@ func SetResponseCount(w http.ResponseWriter, r *http.Request) {
 
 	websocket.LobbyBroadcast(lobbyId, "refresh")
 
+	recordPlayerAction(player.Id)
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("success"))
 }
@@ -2245,6 +4515,26 @@ func SetDecks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	role, err := database.GetLobbyPlayerRole(player.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if !role.Can(database.LobbyPermissionEditSettings) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("Only the lobby owner or a co-host can change the draw pile decks."))
+		return
+	}
+
 	err = r.ParseForm()
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
@@ -2254,6 +4544,7 @@ func SetDecks(w http.ResponseWriter, r *http.Request) {
 
 	var deckIdsPrompt = make([]uuid.UUID, 0)
 	var deckIdsResponse = make([]uuid.UUID, 0)
+	var deckWeights = make(map[uuid.UUID]int)
 	for key, val := range r.Form {
 		if strings.HasPrefix(key, "deckIdPrompt") {
 			deckId, err := uuid.Parse(val[0])
@@ -2271,6 +4562,44 @@ func SetDecks(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			deckIdsResponse = append(deckIdsResponse, deckId)
+		} else if strings.HasPrefix(key, "deckWeight") && val[0] != "" {
+			deckId, err := uuid.Parse(strings.TrimPrefix(key, "deckWeight"))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse deck id."))
+				return
+			}
+
+			weight, err := strconv.Atoi(val[0])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Failed to parse deck weight."))
+				return
+			}
+
+			if weight < 1 {
+				weight = 1
+			}
+
+			if weight > 100 {
+				weight = 100
+			}
+
+			deckWeights[deckId] = weight
+		}
+	}
+
+	// only the chosen decks keep their weights
+	chosenDeckIds := make(map[uuid.UUID]bool)
+	for _, deckId := range deckIdsPrompt {
+		chosenDeckIds[deckId] = true
+	}
+	for _, deckId := range deckIdsResponse {
+		chosenDeckIds[deckId] = true
+	}
+	for deckId := range deckWeights {
+		if !chosenDeckIds[deckId] {
+			delete(deckWeights, deckId)
 		}
 	}
 
@@ -2280,20 +4609,42 @@ func SetDecks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(deckIdsResponse) == 0 {
+	lobby, err := database.GetLobby(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if len(deckIdsResponse) == 0 && !lobby.FreeTextMode {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("At least one response deck is required."))
 		return
 	}
 
-	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if lobby.CaptionMode {
+		mediaPromptCount, err := database.CountMediaPrompts(deckIdsPrompt)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		if mediaPromptCount == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Caption mode needs an image or video prompt in the prompt decks."))
+			return
+		}
+	}
+
+	err = database.SyncDecksInLobby(lobbyId, deckIdsPrompt, deckIdsResponse)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
 		return
 	}
 
-	err = database.SyncDecksInLobby(lobbyId, deckIdsPrompt, deckIdsResponse)
+	err = database.SetLobbyDeckWeights(lobbyId, deckWeights)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(err.Error()))
@@ -2322,5 +4673,235 @@ func getLobbyRequestPlayer(r *http.Request, lobbyId uuid.UUID) (gsDatabase.Playe
 		return player, errors.New("user not found in lobby")
 	}
 
+	// kicked and removed players keep their row until they rejoin
+	if !player.IsActive {
+		return player, errors.New("user is no longer in lobby")
+	}
+
+	isBanned, err := database.IsUserBannedFromLobby(lobbyId, userId)
+	if err != nil {
+		return player, err
+	}
+
+	if isBanned {
+		return player, errors.New("user is banned from lobby")
+	}
+
+	return player, nil
+}
+
+// getLobbyMember finds a person in the lobby who can be given a role.
+func getLobbyMember(lobbyId uuid.UUID, playerId uuid.UUID) (database.LobbyMember, error) {
+	members, err := database.GetLobbyMembers(lobbyId)
+	if err != nil {
+		return database.LobbyMember{}, err
+	}
+
+	for _, member := range members {
+		if member.PlayerId == playerId {
+			return member, nil
+		}
+	}
+
+	return database.LobbyMember{}, errors.New("player not found in lobby")
+}
+
+// getLobbyRequestParticipant is getLobbyRequestPlayer for game actions, which
+// spectators cannot take.
+func getLobbyRequestParticipant(r *http.Request, lobbyId uuid.UUID) (gsDatabase.Player, error) {
+	player, err := getLobbyRequestPlayer(r, lobbyId)
+	if err != nil {
+		return player, err
+	}
+
+	isSpectator, err := database.IsPlayerSpectator(player.Id)
+	if err != nil {
+		return player, err
+	}
+
+	if isSpectator {
+		return player, errors.New("spectators cannot play")
+	}
+
+	isStarted, err := database.IsLobbyStarted(lobbyId)
+	if err != nil {
+		return player, err
+	}
+
+	if !isStarted {
+		return player, errors.New("the game has not started yet")
+	}
+
+	isPaused, err := database.IsLobbyPaused(lobbyId)
+	if err != nil {
+		return player, err
+	}
+
+	if isPaused {
+		return player, errors.New("the game is paused")
+	}
+
+	return player, nil
+}
+
+// withEquippedAnimation appends the animation the user has equipped of the
+// kind to the message, so the lobby shows it in place of the default gif.
+func withEquippedAnimation(message string, userId uuid.UUID, kind database.CosmeticKind) string {
+	animation, err := database.GetUserEquippedCosmeticValue(userId, kind)
+	if err != nil || animation == "" {
+		return message
+	}
+	return message + "-" + animation
+}
+
+// recordPlayerAction marks the player as having taken part in the round, which
+// keeps them from being handled as idle. Only call it once an action has gone
+// through, so rejected requests do not count.
+func recordPlayerAction(playerId uuid.UUID) {
+	err := database.SetPlayerActedThisRound(playerId)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// getLobbyRequestJudge is getLobbyRequestParticipant for actions only the
+// current judge can take.
+func getLobbyRequestJudge(r *http.Request, lobbyId uuid.UUID) (gsDatabase.Player, error) {
+	player, err := getLobbyRequestParticipant(r, lobbyId)
+	if err != nil {
+		return player, err
+	}
+
+	isJudge, err := database.IsPlayerJudge(player.Id)
+	if err != nil {
+		return player, err
+	}
+
+	if !isJudge {
+		return player, errors.New("only the judge can do that")
+	}
+
 	return player, nil
 }
+
+// writeSpecialResult sends the events of a special that was used and writes
+// its response, or the reason it could not be used.
+func writeSpecialResult(w http.ResponseWriter, lobbyId uuid.UUID, result game.SpecialResult, err error) {
+	if err != nil {
+		var rejected game.RejectedError
+		if errors.As(err, &rejected) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	for _, event := range result.Events {
+		if event.PlayerId == uuid.Nil {
+			websocket.LobbyBroadcast(lobbyId, event.Message)
+		} else {
+			websocket.PlayerBroadcast(event.PlayerId, event.Message)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if result.Response != "" {
+		_, _ = w.Write([]byte(result.Response))
+	}
+}
+
+// saveCustomJudgeCard copies a judge-written prompt into the deck selected in
+// the request form, if any, so it outlives the round. Only decks the player
+// owns can be chosen.
+func saveCustomJudgeCard(r *http.Request, lobbyId uuid.UUID, player gsDatabase.Player) error {
+	err := r.ParseForm()
+	if err != nil {
+		return errors.New("failed to parse form")
+	}
+
+	var deckId uuid.UUID
+	for key, val := range r.Form {
+		if key == "saveDeckId" && val[0] != "" {
+			deckId, err = uuid.Parse(val[0])
+			if err != nil {
+				return errors.New("failed to parse save deck id")
+			}
+		}
+	}
+
+	if deckId == uuid.Nil {
+		return nil
+	}
+
+	role, err := database.GetUserDeckRole(player.UserId, deckId)
+	if err != nil {
+		return err
+	}
+
+	if role != database.DeckRoleOwner {
+		return errors.New("you can only save prompts to decks you own")
+	}
+
+	text, err := database.GetCustomJudgeCardText(lobbyId)
+	if err != nil {
+		return err
+	}
+
+	if text == "" {
+		return errors.New("the prompt card is not a custom prompt")
+	}
+
+	existingCardId, err := database.GetCardId(deckId, text)
+	if err != nil {
+		return err
+	}
+
+	if existingCardId != uuid.Nil {
+		return errors.New("prompt text already exists in the selected deck")
+	}
+
+	_, err = database.CreateCard(deckId, "PROMPT", text, "")
+	if err != nil {
+		return err
+	}
+
+	websocket.LobbyBroadcast(lobbyId, "<green>"+player.Name+"</>: Saved the custom prompt to a deck")
+	return nil
+}
+
+var blankRegExp = regexp.MustCompile(`__+`)
+
+// normalizeBlanks collapses any run of underscores into a standard five
+// character blank so the blank count matches deck prompts.
+func normalizeBlanks(text string) string {
+	return strings.TrimSpace(blankRegExp.ReplaceAllString(text, "_____"))
+}
+
+// textHasFilteredWord reports whether any word in text matches one of the
+// comma separated words in filter, ignoring case.
+func textHasFilteredWord(text string, filter string) bool {
+	filteredWords := make(map[string]bool)
+	for _, word := range strings.Split(filter, ",") {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			filteredWords[word] = true
+		}
+	}
+
+	if len(filteredWords) == 0 {
+		return false
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	})
+	for _, word := range words {
+		if filteredWords[word] {
+			return true
+		}
+	}
+
+	return false
+}
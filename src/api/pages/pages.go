@@ -10,6 +10,7 @@ import (
 	gsDatabase "github.com/gerp93/gameshell-framework/database"
 	"github.com/google/uuid"
 	"github.com/grantfbarnes/card-judge/database"
+	"github.com/grantfbarnes/card-judge/game"
 	"github.com/grantfbarnes/card-judge/static"
 )
 
@@ -82,14 +83,41 @@ func Account(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	walletBalance, err := database.GetUserWalletBalance(basePageData.User.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get wallet balance"))
+		return
+	}
+
+	walletLedger, err := database.GetUserWalletLedger(basePageData.User.Id, 10)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get wallet history"))
+		return
+	}
+
+	cosmetics, err := database.GetUserCosmetics(basePageData.User.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get cosmetics"))
+		return
+	}
+
 	type data struct {
 		api.BasePageData
-		ThemeGroups []api.ThemeGroup
+		ThemeGroups   []api.ThemeGroup
+		WalletBalance int
+		WalletLedger  []database.WalletLedgerEntry
+		Cosmetics     []database.UserCosmetic
 	}
 
 	_ = tmpl.ExecuteTemplate(w, "base", data{
-		BasePageData: basePageData,
-		ThemeGroups:  api.ThemeGroups,
+		BasePageData:  basePageData,
+		ThemeGroups:   api.ThemeGroups,
+		WalletBalance: walletBalance,
+		WalletLedger:  walletLedger,
+		Cosmetics:     cosmetics,
 	})
 }
 
@@ -500,6 +528,68 @@ func StatsCard(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func Gallery(w http.ResponseWriter, r *http.Request) {
+	basePageData := api.GetBasePageData(r)
+	basePageData.PageTitle = "Card Judge - Gallery"
+
+	var page int
+	params := r.URL.Query()
+	for key, val := range params {
+		switch key {
+		case "page":
+			page, _ = strconv.Atoi(val[0])
+		}
+	}
+
+	totalRowCount, err := database.CountGalleryCaptions(basePageData.User.Id)
+	if err != nil {
+		totalRowCount = 0
+	}
+	totalPageCount := max((totalRowCount+9)/10, 1)
+
+	if page < 1 {
+		page = 1
+	}
+
+	if page > totalPageCount {
+		page = totalPageCount
+	}
+
+	captions, err := database.SearchGalleryCaptions(basePageData.User.Id, page)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get table rows"))
+		return
+	}
+
+	tmpl, err := template.ParseFS(
+		static.StaticFiles,
+		"html/pages/base.html",
+		"html/pages/body/gallery.html",
+	)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to parse HTML"))
+		return
+	}
+
+	type data struct {
+		api.BasePageData
+		Page     int
+		LastPage int
+		RowCount int
+		Captions []database.GalleryCaption
+	}
+
+	_ = tmpl.ExecuteTemplate(w, "base", data{
+		BasePageData: basePageData,
+		Page:         page,
+		LastPage:     totalPageCount,
+		RowCount:     totalRowCount,
+		Captions:     captions,
+	})
+}
+
 func Lobbies(w http.ResponseWriter, r *http.Request) {
 	basePageData := api.GetBasePageData(r)
 	basePageData.PageTitle = "Card Judge - Lobbies"
@@ -537,7 +627,7 @@ func Lobbies(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	decks, err := gsDatabase.GetReadableDecks(basePageData.User.Id)
+	decks, err := database.GetReadableDecks(basePageData.User.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("failed to get user decks"))
@@ -563,6 +653,7 @@ func Lobbies(w http.ResponseWriter, r *http.Request) {
 		RowCount int
 		Lobbies  []database.LobbyDetails
 		Decks    []gsDatabase.Deck
+		Specials []game.SpecialSetting
 	}
 
 	_ = tmpl.ExecuteTemplate(w, "base", data{
@@ -573,6 +664,7 @@ func Lobbies(w http.ResponseWriter, r *http.Request) {
 		RowCount:     totalRowCount,
 		Lobbies:      lobbies,
 		Decks:        decks,
+		Specials:     game.GetSpecialSettings(nil),
 	})
 }
 
@@ -610,7 +702,20 @@ func Lobby(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	decks, err := gsDatabase.GetReadableDecks(basePageData.User.Id)
+	isBanned, err := database.IsUserBannedFromLobby(lobbyId, basePageData.User.Id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to check lobby bans"))
+		return
+	}
+
+	if isBanned {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("you are banned from this lobby"))
+		return
+	}
+
+	decks, err := database.GetReadableDecks(basePageData.User.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("failed to get user decks"))
@@ -628,6 +733,48 @@ func Lobby(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	spectate := r.URL.Query().Get("spectate") == "true"
+	if spectate {
+		existingPlayer, err := gsDatabase.GetLobbyUserPlayer(lobbyId, basePageData.User.Id)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get lobby player"))
+			return
+		}
+
+		isSpectator := false
+		if existingPlayer.Id != uuid.Nil {
+			isSpectator, err = database.IsPlayerSpectator(existingPlayer.Id)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("failed to check spectator status"))
+				return
+			}
+		}
+
+		if !isSpectator {
+			if !lobby.AllowSpectators {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("lobby does not allow spectators"))
+				return
+			}
+
+			spectatorCount, err := database.CountLobbySpectators(lobbyId)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("failed to count spectators"))
+				return
+			}
+
+			if spectatorCount >= lobby.MaxSpectators {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte("lobby has reached its spectator limit"))
+				return
+			}
+		}
+	}
+
+	// a new player draws a hand, which can run the draw pile out
 	playerId, err := gsDatabase.AddUserToLobby(lobbyId, basePageData.User.Id)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -635,18 +782,131 @@ func Lobby(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if spectate {
+		err = database.SetPlayerSpectator(playerId, true)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to join as spectator"))
+			return
+		}
+	}
+
+	game.BroadcastReshuffles(lobbyId)
+
+	bots, err := database.GetLobbyBots(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby bots"))
+		return
+	}
+
+	playerRole, err := database.GetLobbyPlayerRole(playerId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby role"))
+		return
+	}
+
+	members, err := database.GetLobbyMembers(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby members"))
+		return
+	}
+
+	bans, err := database.GetLobbyBans(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby bans"))
+		return
+	}
+
+	economy, err := database.GetLobbyEconomy(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby economy"))
+		return
+	}
+
+	lobbySpecials, err := database.GetLobbySpecials(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby specials"))
+		return
+	}
+
+	deckWeights, err := database.GetLobbyDeckWeights(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby deck weights"))
+		return
+	}
+
+	streakRules, err := database.GetLobbyStreakRules(lobbyId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get lobby streak rules"))
+		return
+	}
+
+	invites := make([]database.Invite, 0)
+	if playerRole.Can(database.LobbyPermissionManageInvites) {
+		invites, err = database.GetLobbyInvites(lobbyId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get lobby invites"))
+			return
+		}
+	}
+
+	accessFailures := make([]database.AccessFailure, 0)
+	if playerRole.Can(database.LobbyPermissionViewAccessFailures) || basePageData.User.IsAdmin {
+		accessFailures, err = database.GetLobbyAccessFailures(lobbyId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get lobby access failures"))
+			return
+		}
+	}
+
 	type data struct {
 		api.BasePageData
-		Lobby    database.Lobby
-		PlayerId uuid.UUID
-		Decks    []gsDatabase.Deck
+		Lobby            database.Lobby
+		PlayerId         uuid.UUID
+		PlayerRole       database.LobbyRole
+		Members          []database.LobbyMember
+		Bans             []database.LobbyBan
+		Economy          []database.LobbyEconomyItem
+		Specials         []game.SpecialSetting
+		StreakRules      []database.StreakRule
+		StreakRewards    []database.StreakRewardOption
+		Invites          []database.Invite
+		AccessFailures   []database.AccessFailure
+		Decks            []gsDatabase.Deck
+		DeckWeights      map[uuid.UUID]int
+		Bots             []database.Bot
+		BotPersonalities []game.BotPersonality
+		MaxLobbyBots     int
 	}
 
 	_ = tmpl.ExecuteTemplate(w, "base", data{
-		BasePageData: basePageData,
-		Lobby:        lobby,
-		PlayerId:     playerId,
-		Decks:        decks,
+		BasePageData:     basePageData,
+		Lobby:            lobby,
+		PlayerId:         playerId,
+		PlayerRole:       playerRole,
+		Members:          members,
+		Bans:             bans,
+		Economy:          economy,
+		Specials:         game.GetSpecialSettings(lobbySpecials),
+		StreakRules:      streakRules,
+		StreakRewards:    database.StreakRewards(),
+		Invites:          invites,
+		AccessFailures:   accessFailures,
+		Decks:            decks,
+		DeckWeights:      deckWeights,
+		Bots:             bots,
+		BotPersonalities: game.BotPersonalities(),
+		MaxLobbyBots:     game.MaxLobbyBots,
 	})
 }
 
@@ -795,14 +1055,14 @@ func Deck(w http.ResponseWriter, r *http.Request) {
 	basePageData := api.GetBasePageData(r)
 	basePageData.PageTitle = "Card Judge - Deck"
 
-	hasDeckAccess, err := gsDatabase.UserHasDeckAccess(basePageData.User.Id, deckId)
+	deckRole, err := database.GetUserDeckRole(basePageData.User.Id, deckId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("failed to check deck access"))
 		return
 	}
 
-	if !hasDeckAccess {
+	if !deckRole.Can(database.DeckPermissionView) {
 		http.Redirect(w, r, fmt.Sprintf("/deck/%s/access", deckId), http.StatusSeeOther)
 		return
 	}
@@ -843,6 +1103,42 @@ func Deck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	suggestions := make([]database.CardSuggestion, 0)
+	if deckRole.Can(database.DeckPermissionEditCards) {
+		suggestions, err = database.GetCardSuggestions(deckId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get card suggestions"))
+			return
+		}
+	}
+
+	collaborators := make([]database.DeckCollaborator, 0)
+	invites := make([]database.Invite, 0)
+	accessFailures := make([]database.AccessFailure, 0)
+	if deckRole.Can(database.DeckPermissionManageDeck) {
+		collaborators, err = database.GetDeckCollaborators(deckId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get deck collaborators"))
+			return
+		}
+
+		invites, err = database.GetDeckInvites(deckId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get deck invites"))
+			return
+		}
+
+		accessFailures, err = database.GetDeckAccessFailures(deckId)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get deck access failures"))
+			return
+		}
+	}
+
 	tmpl, err := template.ParseFS(
 		static.StaticFiles,
 		"html/pages/base.html",
@@ -856,24 +1152,34 @@ func Deck(w http.ResponseWriter, r *http.Request) {
 
 	type data struct {
 		api.BasePageData
-		Deck     gsDatabase.Deck
-		Category string
-		Text     string
-		Page     int
-		LastPage int
-		RowCount int
-		Cards    []database.Card
+		Deck           gsDatabase.Deck
+		DeckRole       database.DeckRole
+		Category       string
+		Text           string
+		Page           int
+		LastPage       int
+		RowCount       int
+		Cards          []database.Card
+		Suggestions    []database.CardSuggestion
+		Collaborators  []database.DeckCollaborator
+		Invites        []database.Invite
+		AccessFailures []database.AccessFailure
 	}
 
 	_ = tmpl.ExecuteTemplate(w, "base", data{
-		BasePageData: basePageData,
-		Deck:         deck,
-		Category:     category,
-		Text:         text,
-		Page:         page,
-		LastPage:     totalPageCount,
-		RowCount:     totalRowCount,
-		Cards:        cards,
+		BasePageData:   basePageData,
+		Deck:           deck,
+		DeckRole:       deckRole,
+		Category:       category,
+		Text:           text,
+		Page:           page,
+		LastPage:       totalPageCount,
+		RowCount:       totalRowCount,
+		Cards:          cards,
+		Suggestions:    suggestions,
+		Collaborators:  collaborators,
+		Invites:        invites,
+		AccessFailures: accessFailures,
 	})
 }
 
@@ -899,14 +1205,14 @@ func DeckAccess(w http.ResponseWriter, r *http.Request) {
 	basePageData := api.GetBasePageData(r)
 	basePageData.PageTitle = "Card Judge - Deck"
 
-	hasDeckAccess, err := gsDatabase.UserHasDeckAccess(basePageData.User.Id, deckId)
+	deckRole, err := database.GetUserDeckRole(basePageData.User.Id, deckId)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("failed to check deck access"))
 		return
 	}
 
-	if hasDeckAccess {
+	if deckRole.Can(database.DeckPermissionView) {
 		http.Redirect(w, r, fmt.Sprintf("/deck/%s", deckId), http.StatusSeeOther)
 		return
 	}
@@ -932,3 +1238,66 @@ func DeckAccess(w http.ResponseWriter, r *http.Request) {
 		Deck:         deck,
 	})
 }
+
+func Invite(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue("token")
+
+	invite, err := database.GetInviteByToken(token)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to get invite"))
+		return
+	}
+
+	if invite.Id == uuid.Nil {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	var targetName string
+	if invite.LobbyId.Valid {
+		lobby, err := database.GetLobby(invite.LobbyId.UUID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get lobby"))
+			return
+		}
+		targetName = lobby.Name
+	} else {
+		deck, err := gsDatabase.GetDeck(invite.DeckId.UUID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("failed to get deck"))
+			return
+		}
+		targetName = deck.Name
+	}
+
+	basePageData := api.GetBasePageData(r)
+	basePageData.PageTitle = "Card Judge - Invite"
+
+	tmpl, err := template.ParseFS(
+		static.StaticFiles,
+		"html/pages/base.html",
+		"html/pages/body/invite.html",
+	)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to parse HTML"))
+		return
+	}
+
+	type data struct {
+		api.BasePageData
+		Invite     database.Invite
+		Token      string
+		TargetName string
+	}
+
+	_ = tmpl.ExecuteTemplate(w, "base", data{
+		BasePageData: basePageData,
+		Invite:       invite,
+		Token:        token,
+		TargetName:   targetName,
+	})
+}
@@ -0,0 +1,120 @@
+package apiWallet
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gerp93/gameshell-framework/api"
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+func CollectAchievementRewards(w http.ResponseWriter, r *http.Request) {
+	userId := api.GetUserId(r)
+	if userId == uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get user id."))
+		return
+	}
+
+	earned, err := database.CollectAchievementRewards(userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if earned == 0 {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("No new achievement rewards to collect."))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(fmt.Sprintf("Collected %d coins.", earned)))
+}
+
+func UnlockCosmetic(w http.ResponseWriter, r *http.Request) {
+	userId, cosmetic, ok := getRequestCosmetic(w, r)
+	if !ok {
+		return
+	}
+
+	balance, err := database.GetUserWalletBalance(userId)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	if balance < cosmetic.Price {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(fmt.Sprintf("You need %d more coins to unlock this.", cosmetic.Price-balance)))
+		return
+	}
+
+	err = database.UnlockCosmetic(userId, cosmetic)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func EquipCosmetic(w http.ResponseWriter, r *http.Request) {
+	userId, cosmetic, ok := getRequestCosmetic(w, r)
+	if !ok {
+		return
+	}
+
+	err := database.EquipCosmetic(userId, cosmetic)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+func UnequipCosmetic(w http.ResponseWriter, r *http.Request) {
+	userId, cosmetic, ok := getRequestCosmetic(w, r)
+	if !ok {
+		return
+	}
+
+	err := database.UnequipCosmetic(userId, cosmetic)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Add("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
+// getRequestCosmetic reads the requesting user and the {cosmetic} path value.
+// It writes the error response and returns ok=false on any failure.
+func getRequestCosmetic(w http.ResponseWriter, r *http.Request) (userId uuid.UUID, cosmetic database.Cosmetic, ok bool) {
+	userId = api.GetUserId(r)
+	if userId == uuid.Nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get user id."))
+		return uuid.Nil, database.Cosmetic{}, false
+	}
+
+	cosmetic, ok = database.GetCosmetic(r.PathValue("cosmetic"))
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Failed to get cosmetic from path."))
+		return uuid.Nil, database.Cosmetic{}, false
+	}
+
+	return userId, cosmetic, true
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccessFailure sums up the wrong passwords one user sent for a lobby or deck
+// from one address.
+type AccessFailure struct {
+	UserName        string
+	IpAddress       string
+	FailureCount    int
+	LastFailureDate time.Time
+}
+
+// GetAccessWaitSeconds returns how long the user and address must wait before
+// they can try another lobby or deck password, or zero if they can try now.
+func GetAccessWaitSeconds(ipAddress string, userId uuid.UUID) (int, error) {
+	var waitSeconds int
+
+	sqlString := "SELECT FN_GET_ACCESS_WAIT_SECONDS (?, ?)"
+	rows, err := query(sqlString, ipAddress, userId)
+	if err != nil {
+		return waitSeconds, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&waitSeconds); err != nil {
+			log.Println(err)
+			return waitSeconds, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return waitSeconds, nil
+}
+
+func AddLobbyAccessFailure(ipAddress string, userId uuid.UUID, lobbyId uuid.UUID) error {
+	sqlString := `
+		INSERT INTO ACCESS_FAILURE (IP_ADDRESS, USER_ID, LOBBY_ID)
+		VALUES (?, ?, ?)
+	`
+	return execute(sqlString, ipAddress, userId, lobbyId)
+}
+
+func AddDeckAccessFailure(ipAddress string, userId uuid.UUID, deckId uuid.UUID) error {
+	sqlString := `
+		INSERT INTO ACCESS_FAILURE (IP_ADDRESS, USER_ID, DECK_ID)
+		VALUES (?, ?, ?)
+	`
+	return execute(sqlString, ipAddress, userId, deckId)
+}
+
+func GetLobbyAccessFailures(lobbyId uuid.UUID) ([]AccessFailure, error) {
+	return getAccessFailures("AF.LOBBY_ID = ?", lobbyId)
+}
+
+func GetDeckAccessFailures(deckId uuid.UUID) ([]AccessFailure, error) {
+	return getAccessFailures("AF.DECK_ID = ?", deckId)
+}
+
+func getAccessFailures(whereClause string, param any) ([]AccessFailure, error) {
+	sqlString := `
+		SELECT
+			U.NAME,
+			AF.IP_ADDRESS,
+			COUNT(AF.ID) AS FAILURE_COUNT,
+			MAX(AF.CREATED_ON_DATE) AS LAST_FAILURE_DATE
+		FROM ACCESS_FAILURE AS AF
+			INNER JOIN USER AS U ON U.ID = AF.USER_ID
+		WHERE ` + whereClause + `
+		GROUP BY U.NAME, AF.IP_ADDRESS
+		ORDER BY LAST_FAILURE_DATE DESC
+	`
+	rows, err := query(sqlString, param)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]AccessFailure, 0)
+	for rows.Next() {
+		var failure AccessFailure
+		if err := rows.Scan(
+			&failure.UserName,
+			&failure.IpAddress,
+			&failure.FailureCount,
+			&failure.LastFailureDate,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, failure)
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// LobbyBan keeps a kicked user out of a lobby until it expires or a host
+// lifts it.
+type LobbyBan struct {
+	Id          uuid.UUID
+	UserName    string
+	MinutesLeft int
+}
+
+func GetLobbyBans(lobbyId uuid.UUID) ([]LobbyBan, error) {
+	sqlString := `
+		SELECT
+			LB.ID,
+			U.NAME,
+			CEIL(TIMESTAMPDIFF(SECOND, NOW(), LB.EXPIRES_ON_DATE) / 60) AS MINUTES_LEFT
+		FROM LOBBY_BAN AS LB
+			INNER JOIN USER AS U ON U.ID = LB.USER_ID
+		WHERE LB.LOBBY_ID = ?
+			AND LB.EXPIRES_ON_DATE > NOW()
+		ORDER BY LB.EXPIRES_ON_DATE
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]LobbyBan, 0)
+	for rows.Next() {
+		var ban LobbyBan
+		if err := rows.Scan(
+			&ban.Id,
+			&ban.UserName,
+			&ban.MinutesLeft,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, ban)
+	}
+	return result, nil
+}
+
+func IsUserBannedFromLobby(lobbyId uuid.UUID, userId uuid.UUID) (bool, error) {
+	var isBanned bool
+
+	sqlString := `
+		SELECT
+			EXISTS(
+				SELECT
+					ID
+				FROM LOBBY_BAN
+				WHERE LOBBY_ID = ?
+					AND USER_ID = ?
+					AND EXPIRES_ON_DATE > NOW()
+			)
+	`
+	rows, err := query(sqlString, lobbyId, userId)
+	if err != nil {
+		return isBanned, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isBanned); err != nil {
+			log.Println(err)
+			return isBanned, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isBanned, nil
+}
+
+// RemoveLobbyBan lifts a ban early. It returns the name of the user who was
+// banned, or an empty string if the ban was not found in the lobby.
+func RemoveLobbyBan(lobbyId uuid.UUID, banId uuid.UUID) (string, error) {
+	var userName string
+
+	sqlString := `
+		SELECT
+			U.NAME
+		FROM LOBBY_BAN AS LB
+			INNER JOIN USER AS U ON U.ID = LB.USER_ID
+		WHERE LB.ID = ?
+			AND LB.LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, banId, lobbyId)
+	if err != nil {
+		return userName, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&userName); err != nil {
+			log.Println(err)
+			return userName, errors.New("failed to scan row in query results")
+		}
+	}
+
+	if userName == "" {
+		return userName, nil
+	}
+
+	sqlString = `
+		DELETE
+		FROM LOBBY_BAN
+		WHERE ID = ?
+			AND LOBBY_ID = ?
+	`
+	return userName, execute(sqlString, banId, lobbyId)
+}
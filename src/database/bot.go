@@ -0,0 +1,299 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+type Bot struct {
+	Id       uuid.UUID
+	LobbyId  uuid.UUID
+	PlayerId uuid.UUID
+	UserId   uuid.UUID
+	UserName string
+
+	Personality string
+	Difficulty  string
+}
+
+// BotCard is a card a bot can choose, with the play history its strategy
+// scores it by. Prompt counts are relative to the lobby's current judge card.
+type BotCard struct {
+	Id             uuid.UUID
+	PlayCount      int
+	WinCount       int
+	PromptWinCount int
+}
+
+type BotResponse struct {
+	Id    uuid.UUID
+	Cards []BotCard
+}
+
+func CreateBot(lobbyId uuid.UUID, playerId uuid.UUID, userId uuid.UUID, personality string, difficulty string) error {
+	sqlString := `
+		INSERT INTO BOT (LOBBY_ID, PLAYER_ID, USER_ID, PERSONALITY, DIFFICULTY)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	return execute(sqlString, lobbyId, playerId, userId, personality, difficulty)
+}
+
+func GetBot(id uuid.UUID) (Bot, error) {
+	var bot Bot
+
+	sqlString := `
+		SELECT
+			B.ID,
+			B.LOBBY_ID,
+			B.PLAYER_ID,
+			B.USER_ID,
+			U.NAME,
+			B.PERSONALITY,
+			B.DIFFICULTY
+		FROM BOT AS B
+			INNER JOIN USER AS U ON U.ID = B.USER_ID
+		WHERE B.ID = ?
+	`
+	rows, err := query(sqlString, id)
+	if err != nil {
+		return bot, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&bot.Id,
+			&bot.LobbyId,
+			&bot.PlayerId,
+			&bot.UserId,
+			&bot.UserName,
+			&bot.Personality,
+			&bot.Difficulty,
+		); err != nil {
+			log.Println(err)
+			return bot, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return bot, nil
+}
+
+// GetLobbyBots returns the bots seated in the lobby. Removed bots stay on as
+// inactive players until the lobby closes, so they are left out.
+func GetLobbyBots(lobbyId uuid.UUID) ([]Bot, error) {
+	sqlString := `
+		SELECT
+			B.ID,
+			B.LOBBY_ID,
+			B.PLAYER_ID,
+			B.USER_ID,
+			U.NAME,
+			B.PERSONALITY,
+			B.DIFFICULTY
+		FROM BOT AS B
+			INNER JOIN PLAYER AS P ON P.ID = B.PLAYER_ID
+			INNER JOIN USER AS U ON U.ID = B.USER_ID
+		WHERE B.LOBBY_ID = ?
+			AND P.IS_ACTIVE = 1
+		ORDER BY P.JOIN_ORDER
+	`
+	return queryBots(sqlString, lobbyId)
+}
+
+// GetActiveBots returns every seated bot across all lobbies.
+func GetActiveBots() ([]Bot, error) {
+	sqlString := `
+		SELECT
+			B.ID,
+			B.LOBBY_ID,
+			B.PLAYER_ID,
+			B.USER_ID,
+			U.NAME,
+			B.PERSONALITY,
+			B.DIFFICULTY
+		FROM BOT AS B
+			INNER JOIN PLAYER AS P ON P.ID = B.PLAYER_ID
+			INNER JOIN USER AS U ON U.ID = B.USER_ID
+		WHERE P.IS_ACTIVE = 1
+		ORDER BY B.LOBBY_ID,
+			P.JOIN_ORDER
+	`
+	return queryBots(sqlString)
+}
+
+func queryBots(sqlString string, args ...any) ([]Bot, error) {
+	rows, err := query(sqlString, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]Bot, 0)
+	for rows.Next() {
+		var bot Bot
+		if err := rows.Scan(
+			&bot.Id,
+			&bot.LobbyId,
+			&bot.PlayerId,
+			&bot.UserId,
+			&bot.UserName,
+			&bot.Personality,
+			&bot.Difficulty,
+		); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to scan row in query results")
+		}
+		result = append(result, bot)
+	}
+	return result, nil
+}
+
+// GetBotHandCards returns the bot's hand along with each card's play history.
+func GetBotHandCards(playerId uuid.UUID) ([]BotCard, error) {
+	sqlString := `
+		SELECT
+			H.CARD_ID,
+			(
+				SELECT
+					COUNT(*)
+				FROM LOG_RESPONSE_CARD
+				WHERE PLAYER_CARD_ID = H.CARD_ID
+			) AS PLAY_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM LOG_RESPONSE_CARD AS LRC
+					INNER JOIN LOG_WIN AS LW ON LW.RESPONSE_ID = LRC.RESPONSE_ID
+				WHERE LRC.PLAYER_CARD_ID = H.CARD_ID
+			) AS WIN_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM LOG_RESPONSE_CARD AS LRC
+					INNER JOIN LOG_WIN AS LW ON LW.RESPONSE_ID = LRC.RESPONSE_ID
+				WHERE LRC.PLAYER_CARD_ID = H.CARD_ID
+					AND LRC.JUDGE_CARD_ID = J.CARD_ID
+			) AS PROMPT_WIN_COUNT
+		FROM HAND AS H
+			INNER JOIN PLAYER AS P ON P.ID = H.PLAYER_ID
+			INNER JOIN JUDGE AS J ON J.LOBBY_ID = P.LOBBY_ID
+		WHERE H.PLAYER_ID = ?
+	`
+	rows, err := query(sqlString, playerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]BotCard, 0)
+	for rows.Next() {
+		var card BotCard
+		if err := rows.Scan(
+			&card.Id,
+			&card.PlayCount,
+			&card.WinCount,
+			&card.PromptWinCount,
+		); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to scan row in query results")
+		}
+		result = append(result, card)
+	}
+	return result, nil
+}
+
+// GetBotBoardResponses returns the filled responses on the lobby board, with
+// the same card history as GetBotHandCards, for a bot judge to choose from.
+func GetBotBoardResponses(lobbyId uuid.UUID) ([]BotResponse, error) {
+	sqlString := `
+		SELECT
+			R.ID AS RESPONSE_ID,
+			RC.CARD_ID,
+			(
+				SELECT
+					COUNT(*)
+				FROM LOG_RESPONSE_CARD
+				WHERE PLAYER_CARD_ID = RC.CARD_ID
+			) AS PLAY_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM LOG_RESPONSE_CARD AS LRC
+					INNER JOIN LOG_WIN AS LW ON LW.RESPONSE_ID = LRC.RESPONSE_ID
+				WHERE LRC.PLAYER_CARD_ID = RC.CARD_ID
+			) AS WIN_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM LOG_RESPONSE_CARD AS LRC
+					INNER JOIN LOG_WIN AS LW ON LW.RESPONSE_ID = LRC.RESPONSE_ID
+				WHERE LRC.PLAYER_CARD_ID = RC.CARD_ID
+					AND LRC.JUDGE_CARD_ID = J.CARD_ID
+			) AS PROMPT_WIN_COUNT
+		FROM RESPONSE AS R
+			INNER JOIN RESPONSE_CARD AS RC ON RC.RESPONSE_ID = R.ID
+			INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
+			INNER JOIN JUDGE AS J ON J.LOBBY_ID = P.LOBBY_ID
+		WHERE P.LOBBY_ID = ?
+			AND P.IS_ACTIVE = 1
+			AND R.IS_RULEDOUT = 0
+		ORDER BY R.ID,
+			RC.CREATED_ON_DATE
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]BotResponse, 0)
+	for rows.Next() {
+		var responseId uuid.UUID
+		var card BotCard
+		if err := rows.Scan(
+			&responseId,
+			&card.Id,
+			&card.PlayCount,
+			&card.WinCount,
+			&card.PromptWinCount,
+		); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to scan row in query results")
+		}
+
+		if len(result) == 0 || result[len(result)-1].Id != responseId {
+			result = append(result, BotResponse{Id: responseId})
+		}
+		result[len(result)-1].Cards = append(result[len(result)-1].Cards, card)
+	}
+	return result, nil
+}
+
+// GetPromptCandidateIds returns the prompt cards the lobby judge is choosing
+// between, if any.
+func GetPromptCandidateIds(lobbyId uuid.UUID) ([]uuid.UUID, error) {
+	sqlString := `
+		SELECT
+			CARD_ID
+		FROM PROMPT_CANDIDATE
+		WHERE LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var cardId uuid.UUID
+		if err := rows.Scan(&cardId); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to scan row in query results")
+		}
+		result = append(result, cardId)
+	}
+	return result, nil
+}
@@ -489,6 +489,34 @@ func GetCardId(deckId uuid.UUID, text string) (uuid.UUID, error) {
 	return id, nil
 }
 
+// GetLobbyCardId returns the id of the card written in the lobby with the
+// given text, such as a wild card or free-text answer.
+func GetLobbyCardId(lobbyId uuid.UUID, text string) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	sqlString := `
+		SELECT
+			ID
+		FROM CARD
+		WHERE LOBBY_ID = ?
+			AND TEXT = ?
+	`
+	rows, err := query(sqlString, lobbyId, text)
+	if err != nil {
+		return id, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			log.Println(err)
+			return id, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return id, nil
+}
+
 func GetResponseCardTextStart(responseId uuid.UUID) (string, error) {
 	var text string
 
@@ -0,0 +1,355 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeckRole is what a user is allowed to do with a deck. There is one owner
+// per deck. Anyone who got in with the password is a contributor unless the
+// owner gave them another role.
+type DeckRole string
+
+const (
+	DeckRoleOwner       DeckRole = "OWNER"
+	DeckRoleEditor      DeckRole = "EDITOR"
+	DeckRoleContributor DeckRole = "CONTRIBUTOR"
+	DeckRoleViewer      DeckRole = "VIEWER"
+	DeckRoleNone        DeckRole = "NONE"
+)
+
+type DeckPermission string
+
+const (
+	// DeckPermissionView covers seeing the cards and using the deck in lobbies.
+	DeckPermissionView DeckPermission = "VIEW"
+	// DeckPermissionSuggestCards covers sending new cards for approval.
+	DeckPermissionSuggestCards DeckPermission = "SUGGEST-CARDS"
+	// DeckPermissionEditCards covers adding, changing and deleting cards, and
+	// approving suggestions.
+	DeckPermissionEditCards DeckPermission = "EDIT-CARDS"
+	// DeckPermissionManageDeck covers the deck settings, invites and
+	// collaborators.
+	DeckPermissionManageDeck DeckPermission = "MANAGE-DECK"
+)
+
+var deckRolePermissions = map[DeckRole][]DeckPermission{
+	DeckRoleOwner: {
+		DeckPermissionView,
+		DeckPermissionEditCards,
+		DeckPermissionManageDeck,
+	},
+	DeckRoleEditor: {
+		DeckPermissionView,
+		DeckPermissionEditCards,
+	},
+	DeckRoleContributor: {
+		DeckPermissionView,
+		DeckPermissionSuggestCards,
+	},
+	DeckRoleViewer: {
+		DeckPermissionView,
+	},
+}
+
+func (role DeckRole) Can(permission DeckPermission) bool {
+	for _, p := range deckRolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (role DeckRole) Name() string {
+	switch role {
+	case DeckRoleOwner:
+		return "Owner"
+	case DeckRoleEditor:
+		return "Editor"
+	case DeckRoleContributor:
+		return "Contributor"
+	case DeckRoleViewer:
+		return "Viewer"
+	default:
+		return "None"
+	}
+}
+
+type DeckCollaborator struct {
+	UserId uuid.UUID
+	Name   string
+	Role   DeckRole
+}
+
+type CardSuggestion struct {
+	Id            uuid.UUID
+	CreatedOnDate time.Time
+
+	DeckId   uuid.UUID
+	UserName string
+	Category string
+	Text     string
+	YouTube  sql.NullString
+}
+
+func GetUserDeckRole(userId uuid.UUID, deckId uuid.UUID) (DeckRole, error) {
+	role := DeckRoleNone
+
+	sqlString := `
+		SELECT
+			FN_GET_USER_DECK_ROLE(?, ?)
+	`
+	rows, err := query(sqlString, userId, deckId)
+	if err != nil {
+		return role, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&role); err != nil {
+			log.Println(err)
+			return role, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return role, nil
+}
+
+// GetDeckCollaborators lists everyone who can get into the deck, however they
+// got in, with their role.
+func GetDeckCollaborators(deckId uuid.UUID) ([]DeckCollaborator, error) {
+	sqlString := `
+		SELECT
+			DC.ID,
+			DC.NAME,
+			DC.ROLE
+		FROM (
+				SELECT
+					U.ID,
+					U.NAME,
+					FN_GET_USER_DECK_ROLE(U.ID, ?) AS ROLE
+				FROM USER AS U
+				WHERE U.ID IN (
+						SELECT USER_ID FROM DECK_COLLABORATOR WHERE DECK_ID = ?
+						UNION
+						SELECT USER_ID FROM USER_ACCESS_DECK WHERE DECK_ID = ?
+						UNION
+						SELECT USER_ID FROM USER_READ_ACCESS_DECK WHERE DECK_ID = ?
+					)
+			) AS DC
+		ORDER BY FIELD(DC.ROLE, 'OWNER', 'EDITOR', 'CONTRIBUTOR', 'VIEWER'), DC.NAME
+	`
+	rows, err := query(sqlString, deckId, deckId, deckId, deckId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]DeckCollaborator, 0)
+	for rows.Next() {
+		var collaborator DeckCollaborator
+		if err := rows.Scan(
+			&collaborator.UserId,
+			&collaborator.Name,
+			&collaborator.Role,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, collaborator)
+	}
+	return result, nil
+}
+
+func SetDeckCollaboratorRole(deckId uuid.UUID, userId uuid.UUID, role DeckRole) error {
+	sqlString := `
+		INSERT INTO DECK_COLLABORATOR (USER_ID, DECK_ID, ROLE)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE ROLE = VALUES(ROLE)
+	`
+	return execute(sqlString, userId, deckId, role)
+}
+
+// AddDeckEditor makes the user an editor of the deck, as an edit invite
+// does. Users who already have a higher role keep it.
+func AddDeckEditor(deckId uuid.UUID, userId uuid.UUID) error {
+	sqlString := `
+		INSERT INTO DECK_COLLABORATOR (USER_ID, DECK_ID, ROLE)
+		VALUES (?, ?, 'EDITOR')
+		ON DUPLICATE KEY UPDATE ROLE = IF(ROLE IN ('CONTRIBUTOR', 'VIEWER'), 'EDITOR', ROLE)
+	`
+	return execute(sqlString, userId, deckId)
+}
+
+// TransferDeckOwner hands the deck to the user. The old owner is kept on as
+// an editor.
+func TransferDeckOwner(deckId uuid.UUID, userId uuid.UUID) error {
+	sqlString := "CALL SP_TRANSFER_DECK_OWNER (?, ?)"
+	return execute(sqlString, deckId, userId)
+}
+
+// RemoveDeckCollaborator takes away every way the user had into the deck,
+// so they need the password or a new invite to get back in.
+func RemoveDeckCollaborator(deckId uuid.UUID, userId uuid.UUID) error {
+	sqlString := `
+		DELETE
+		FROM DECK_COLLABORATOR
+		WHERE DECK_ID = ?
+			AND USER_ID = ?
+	`
+	err := execute(sqlString, deckId, userId)
+	if err != nil {
+		return err
+	}
+
+	sqlString = `
+		DELETE
+		FROM USER_ACCESS_DECK
+		WHERE DECK_ID = ?
+			AND USER_ID = ?
+	`
+	err = execute(sqlString, deckId, userId)
+	if err != nil {
+		return err
+	}
+
+	sqlString = `
+		DELETE
+		FROM USER_READ_ACCESS_DECK
+		WHERE DECK_ID = ?
+			AND USER_ID = ?
+	`
+	return execute(sqlString, deckId, userId)
+}
+
+func CreateCardSuggestion(deckId uuid.UUID, userId uuid.UUID, category string, text string, youtube string) error {
+	sqlString := `
+		INSERT INTO CARD_SUGGESTION (DECK_ID, USER_ID, CATEGORY, TEXT, YOUTUBE)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	if len(youtube) == 0 {
+		return execute(sqlString, deckId, userId, category, text, nil)
+	}
+	return execute(sqlString, deckId, userId, category, text, youtube)
+}
+
+func GetCardSuggestionId(deckId uuid.UUID, text string) (uuid.UUID, error) {
+	var id uuid.UUID
+
+	sqlString := `
+		SELECT
+			ID
+		FROM CARD_SUGGESTION
+		WHERE DECK_ID = ?
+			AND TEXT = ?
+	`
+	rows, err := query(sqlString, deckId, text)
+	if err != nil {
+		return id, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			log.Println(err)
+			return id, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return id, nil
+}
+
+func GetCardSuggestions(deckId uuid.UUID) ([]CardSuggestion, error) {
+	sqlString := `
+		SELECT
+			CS.ID,
+			CS.CREATED_ON_DATE,
+			CS.DECK_ID,
+			U.NAME,
+			CS.CATEGORY,
+			CS.TEXT,
+			CS.YOUTUBE
+		FROM CARD_SUGGESTION AS CS
+			INNER JOIN USER AS U ON U.ID = CS.USER_ID
+		WHERE CS.DECK_ID = ?
+		ORDER BY CS.CREATED_ON_DATE
+	`
+	rows, err := query(sqlString, deckId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]CardSuggestion, 0)
+	for rows.Next() {
+		var suggestion CardSuggestion
+		if err := rows.Scan(
+			&suggestion.Id,
+			&suggestion.CreatedOnDate,
+			&suggestion.DeckId,
+			&suggestion.UserName,
+			&suggestion.Category,
+			&suggestion.Text,
+			&suggestion.YouTube,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, suggestion)
+	}
+	return result, nil
+}
+
+func GetCardSuggestion(id uuid.UUID) (CardSuggestion, error) {
+	var suggestion CardSuggestion
+
+	sqlString := `
+		SELECT
+			CS.ID,
+			CS.CREATED_ON_DATE,
+			CS.DECK_ID,
+			U.NAME,
+			CS.CATEGORY,
+			CS.TEXT,
+			CS.YOUTUBE
+		FROM CARD_SUGGESTION AS CS
+			INNER JOIN USER AS U ON U.ID = CS.USER_ID
+		WHERE CS.ID = ?
+	`
+	rows, err := query(sqlString, id)
+	if err != nil {
+		return suggestion, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(
+			&suggestion.Id,
+			&suggestion.CreatedOnDate,
+			&suggestion.DeckId,
+			&suggestion.UserName,
+			&suggestion.Category,
+			&suggestion.Text,
+			&suggestion.YouTube,
+		); err != nil {
+			log.Println(err)
+			return suggestion, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return suggestion, nil
+}
+
+func DeleteCardSuggestion(id uuid.UUID) error {
+	sqlString := `
+		DELETE
+		FROM CARD_SUGGESTION
+		WHERE ID = ?
+	`
+	return execute(sqlString, id)
+}
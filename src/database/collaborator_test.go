@@ -0,0 +1,28 @@
+package database
+
+import "testing"
+
+func TestDeckRoleCan(t *testing.T) {
+	tests := []struct {
+		role       DeckRole
+		permission DeckPermission
+		want       bool
+	}{
+		{DeckRoleOwner, DeckPermissionManageDeck, true},
+		{DeckRoleOwner, DeckPermissionEditCards, true},
+		{DeckRoleEditor, DeckPermissionEditCards, true},
+		{DeckRoleEditor, DeckPermissionManageDeck, false},
+		{DeckRoleContributor, DeckPermissionSuggestCards, true},
+		{DeckRoleContributor, DeckPermissionEditCards, false},
+		{DeckRoleViewer, DeckPermissionView, true},
+		{DeckRoleViewer, DeckPermissionSuggestCards, false},
+		{DeckRoleNone, DeckPermissionView, false},
+	}
+
+	for _, test := range tests {
+		got := test.role.Can(test.permission)
+		if got != test.want {
+			t.Errorf("%s can %s: got %t, want %t", test.role, test.permission, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// CosmeticKind is the slot a cosmetic fills. A user can have one cosmetic of
+// each kind equipped at a time.
+type CosmeticKind string
+
+const (
+	CosmeticKindCardBack  CosmeticKind = "CARD-BACK"
+	CosmeticKindNameColor CosmeticKind = "NAME-COLOR"
+	CosmeticKindFlipTable CosmeticKind = "FLIP-TABLE"
+	CosmeticKindKick      CosmeticKind = "KICK"
+)
+
+type Cosmetic struct {
+	Key   string
+	Kind  CosmeticKind
+	Name  string
+	Price int
+	// Value is the CSS class of card backs and name colors, or the
+	// animation shown in place of the default flip-table and kick gifs.
+	Value string
+}
+
+// cosmetics is every cosmetic that can be unlocked, in the order they are
+// shown on the account page.
+var cosmetics = []Cosmetic{
+	{"CARD-BACK-STRIPES", CosmeticKindCardBack, "Striped Card Back", 20, "card-back-stripes"},
+	{"CARD-BACK-DOTS", CosmeticKindCardBack, "Dotted Card Back", 20, "card-back-dots"},
+	{"CARD-BACK-GOLD", CosmeticKindCardBack, "Gold Card Back", 50, "card-back-gold"},
+	{"NAME-COLOR-RED", CosmeticKindNameColor, "Red Name", 15, "name-color-red"},
+	{"NAME-COLOR-BLUE", CosmeticKindNameColor, "Blue Name", 15, "name-color-blue"},
+	{"NAME-COLOR-GREEN", CosmeticKindNameColor, "Green Name", 15, "name-color-green"},
+	{"NAME-COLOR-PURPLE", CosmeticKindNameColor, "Purple Name", 15, "name-color-purple"},
+	{"NAME-COLOR-GOLD", CosmeticKindNameColor, "Gold Name", 30, "name-color-gold"},
+	{"FLIP-TABLE-SPIN", CosmeticKindFlipTable, "Spinning Table Flip", 40, "spin"},
+	{"FLIP-TABLE-RAGE", CosmeticKindFlipTable, "Rage Table Flip", 40, "rage"},
+	{"KICK-BOOT", CosmeticKindKick, "Boot Kick", 40, "boot"},
+	{"KICK-LAUNCH", CosmeticKindKick, "Launch Kick", 40, "launch"},
+}
+
+func GetCosmetic(key string) (Cosmetic, bool) {
+	for _, cosmetic := range cosmetics {
+		if cosmetic.Key == key {
+			return cosmetic, true
+		}
+	}
+	return Cosmetic{}, false
+}
+
+// cosmeticValue returns the value of the cosmetic with the key, or an empty
+// string if there is none, so having nothing equipped shows the default.
+func cosmeticValue(key string) string {
+	cosmetic, _ := GetCosmetic(key)
+	return cosmetic.Value
+}
+
+type UserCosmetic struct {
+	Cosmetic
+	IsUnlocked bool
+	IsEquipped bool
+}
+
+// GetUserCosmetics returns every cosmetic with whether the user has unlocked
+// and equipped it.
+func GetUserCosmetics(userId uuid.UUID) ([]UserCosmetic, error) {
+	result := make([]UserCosmetic, 0, len(cosmetics))
+
+	sqlString := `
+		SELECT
+			COSMETIC,
+			IS_EQUIPPED
+		FROM USER_COSMETIC
+		WHERE USER_ID = ?
+	`
+	rows, err := query(sqlString, userId)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	unlocked := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		var isEquipped bool
+		if err := rows.Scan(&key, &isEquipped); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		unlocked[key] = isEquipped
+	}
+
+	for _, cosmetic := range cosmetics {
+		isEquipped, isUnlocked := unlocked[cosmetic.Key]
+		result = append(result, UserCosmetic{
+			Cosmetic:   cosmetic,
+			IsUnlocked: isUnlocked,
+			IsEquipped: isEquipped,
+		})
+	}
+
+	return result, nil
+}
+
+// GetUserEquippedCosmeticValue returns the value of the cosmetic the user has
+// equipped of the kind, or an empty string if they have none equipped.
+func GetUserEquippedCosmeticValue(userId uuid.UUID, kind CosmeticKind) (string, error) {
+	sqlString := `
+		SELECT
+			COSMETIC
+		FROM USER_COSMETIC
+		WHERE USER_ID = ?
+			AND KIND = ?
+			AND IS_EQUIPPED = 1
+	`
+	rows, err := query(sqlString, userId, kind)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var key string
+	for rows.Next() {
+		if err := rows.Scan(&key); err != nil {
+			log.Println(err)
+			return "", errors.New("failed to scan row in query results")
+		}
+	}
+
+	return cosmeticValue(key), nil
+}
+
+// UnlockCosmetic pays for the cosmetic from the user's wallet. Nothing
+// happens if the user cannot afford it or already has it.
+func UnlockCosmetic(userId uuid.UUID, cosmetic Cosmetic) error {
+	sqlString := "CALL SP_UNLOCK_COSMETIC (?, ?, ?, ?)"
+	return execute(sqlString, userId, cosmetic.Kind, cosmetic.Key, cosmetic.Price)
+}
+
+// EquipCosmetic equips the cosmetic and unequips any other of the same kind.
+// It fails if the user has not unlocked the cosmetic.
+func EquipCosmetic(userId uuid.UUID, cosmetic Cosmetic) error {
+	sqlString := `
+		SELECT
+			ID
+		FROM USER_COSMETIC
+		WHERE USER_ID = ?
+			AND COSMETIC = ?
+	`
+	rows, err := query(sqlString, userId, cosmetic.Key)
+	if err != nil {
+		return err
+	}
+	isUnlocked := rows.Next()
+	rows.Close()
+
+	if !isUnlocked {
+		return errors.New("cosmetic has not been unlocked")
+	}
+
+	sqlString = `
+		UPDATE USER_COSMETIC
+		SET IS_EQUIPPED = (COSMETIC = ?)
+		WHERE USER_ID = ?
+			AND KIND = ?
+	`
+	return execute(sqlString, cosmetic.Key, userId, cosmetic.Kind)
+}
+
+func UnequipCosmetic(userId uuid.UUID, cosmetic Cosmetic) error {
+	sqlString := `
+		UPDATE USER_COSMETIC
+		SET IS_EQUIPPED = 0
+		WHERE USER_ID = ?
+			AND COSMETIC = ?
+	`
+	return execute(sqlString, userId, cosmetic.Key)
+}
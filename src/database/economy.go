@@ -0,0 +1,145 @@
+package database
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// EconomyCategory is one price, reward or payout in a lobby's economy. The
+// names match the CREDITS_SPENT categories they are charged under.
+type EconomyCategory string
+
+const (
+	EconomyCategorySkipJudge     EconomyCategory = "SKIP-JUDGE"
+	EconomyCategoryExtraResponse EconomyCategory = "EXTRA-RESPONSE"
+	EconomyCategoryBlockResponse EconomyCategory = "BLOCK-RESPONSE"
+	EconomyCategorySurprise      EconomyCategory = "SURPRISE"
+	EconomyCategorySteal         EconomyCategory = "STEAL"
+	EconomyCategoryFind          EconomyCategory = "FIND"
+	EconomyCategoryWild          EconomyCategory = "WILD"
+	EconomyCategoryPerk          EconomyCategory = "PERK"
+	EconomyCategorySwap          EconomyCategory = "SWAP"
+	EconomyCategoryVeto          EconomyCategory = "VETO"
+	EconomyCategoryMulligan      EconomyCategory = "MULLIGAN"
+	EconomyCategoryStealVictim   EconomyCategory = "STEAL-VICTIM"
+	EconomyCategoryPurchase      EconomyCategory = "PURCHASE"
+	EconomyCategoryWinningStreak EconomyCategory = "WINNING-STREAK"
+	EconomyCategoryLosingStreak  EconomyCategory = "LOSING-STREAK"
+	// EconomyCategoryBetWin and EconomyCategoryGambleWin are multiples of
+	// the stake paid back on a win.
+	EconomyCategoryBetWin    EconomyCategory = "BET-WIN"
+	EconomyCategoryGambleWin EconomyCategory = "GAMBLE-WIN"
+	// EconomyCategoryGambleChance is the percent chance of winning a gamble.
+	EconomyCategoryGambleChance EconomyCategory = "GAMBLE-CHANCE"
+)
+
+type economyCategoryInfo struct {
+	Category  EconomyCategory
+	Name      string
+	MaxAmount int
+}
+
+// economyCategories is the order the economy settings are shown in.
+var economyCategories = []economyCategoryInfo{
+	{EconomyCategorySkipJudge, "Skip Being Judge Cost", 50},
+	{EconomyCategoryExtraResponse, "Extra Response Cost", 50},
+	{EconomyCategoryBlockResponse, "Block Response Cost", 50},
+	{EconomyCategorySurprise, "Surprise Card Cost", 50},
+	{EconomyCategorySteal, "Steal Card Cost", 50},
+	{EconomyCategoryFind, "Find Card Cost", 50},
+	{EconomyCategoryWild, "Wild Card Cost", 50},
+	{EconomyCategoryPerk, "Perk Cost", 50},
+	{EconomyCategorySwap, "Swap Card Cost", 50},
+	{EconomyCategoryVeto, "Veto Cost", 50},
+	{EconomyCategoryMulligan, "Mulligan Cost", 50},
+	{EconomyCategoryStealVictim, "Steal Victim Reward", 50},
+	{EconomyCategoryPurchase, "Purchase Reward", 50},
+	{EconomyCategoryWinningStreak, "Win Streak Penalty", 50},
+	{EconomyCategoryLosingStreak, "Lose Streak Reward", 50},
+	{EconomyCategoryBetWin, "Bet Payout (x Stake)", 10},
+	{EconomyCategoryGambleWin, "Gamble Payout (x Stake)", 10},
+	{EconomyCategoryGambleChance, "Gamble Win Chance (%)", 100},
+}
+
+type LobbyEconomyItem struct {
+	Category  EconomyCategory
+	Name      string
+	Amount    int
+	MaxAmount int
+}
+
+// GetLobbyEconomy returns every price, reward and payout of the lobby in the
+// order they are shown in the settings.
+func GetLobbyEconomy(lobbyId uuid.UUID) ([]LobbyEconomyItem, error) {
+	result := make([]LobbyEconomyItem, len(economyCategories))
+
+	columns := make([]string, 0, len(economyCategories))
+	params := make([]any, 0, len(economyCategories)*2)
+	dest := make([]any, 0, len(economyCategories))
+	for i, info := range economyCategories {
+		result[i] = LobbyEconomyItem{
+			Category:  info.Category,
+			Name:      info.Name,
+			MaxAmount: info.MaxAmount,
+		}
+		columns = append(columns, "FN_GET_ECONOMY_VALUE(?, ?)")
+		params = append(params, lobbyId, info.Category)
+		dest = append(dest, &result[i].Amount)
+	}
+
+	sqlString := "SELECT " + strings.Join(columns, ", ")
+	rows, err := query(sqlString, params...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return result, nil
+}
+
+// GetLobbyEconomyAmounts returns every price, reward and payout of the lobby
+// keyed by category.
+func GetLobbyEconomyAmounts(lobbyId uuid.UUID) (map[EconomyCategory]int, error) {
+	result := make(map[EconomyCategory]int, len(economyCategories))
+
+	items, err := GetLobbyEconomy(lobbyId)
+	if err != nil {
+		return result, err
+	}
+
+	for _, item := range items {
+		result[item.Category] = item.Amount
+	}
+
+	return result, nil
+}
+
+// GetEconomyCategoryMaxAmount returns the highest amount the category can be
+// set to, or zero if the category does not exist.
+func GetEconomyCategoryMaxAmount(category EconomyCategory) int {
+	for _, info := range economyCategories {
+		if info.Category == category {
+			return info.MaxAmount
+		}
+	}
+	return 0
+}
+
+func SetLobbyEconomyAmount(lobbyId uuid.UUID, category EconomyCategory, amount int) error {
+	sqlString := `
+		INSERT INTO CJ_LOBBY_ECONOMY (LOBBY_ID, CATEGORY, AMOUNT)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE AMOUNT = VALUES(AMOUNT)
+	`
+	return execute(sqlString, lobbyId, category, amount)
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GalleryCaption struct {
+	Id            uuid.UUID
+	CreatedOnDate time.Time
+
+	DeckName       string
+	PromptText     string
+	PromptYouTube  sql.NullString
+	PromptImage    sql.NullString
+	WinnerUserName string
+	CaptionText    string
+}
+
+func SearchGalleryCaptions(userId uuid.UUID, page int) ([]GalleryCaption, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	sqlString := `
+		SELECT
+			LCW.ID,
+			LCW.CREATED_ON_DATE,
+			D.NAME AS DECK_NAME,
+			C.TEXT AS PROMPT_TEXT,
+			C.YOUTUBE AS PROMPT_YOUTUBE,
+			C.IMAGE AS PROMPT_IMAGE,
+			U.NAME AS WINNER_USER_NAME,
+			LCW.CAPTION_TEXT
+		FROM LOG_CAPTION_WIN AS LCW
+			INNER JOIN CARD AS C ON C.ID = LCW.JUDGE_CARD_ID
+			INNER JOIN DECK AS D ON D.ID = C.DECK_ID
+			INNER JOIN USER AS U ON U.ID = LCW.PLAYER_USER_ID
+		WHERE FN_USER_HAS_DECK_ACCESS(?, C.DECK_ID)
+		ORDER BY LCW.CREATED_ON_DATE DESC
+		LIMIT 10 OFFSET ?
+	`
+	rows, err := query(sqlString, userId, (page-1)*10)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]GalleryCaption, 0)
+	for rows.Next() {
+		var caption GalleryCaption
+		var imageBytes []byte
+		if err := rows.Scan(
+			&caption.Id,
+			&caption.CreatedOnDate,
+			&caption.DeckName,
+			&caption.PromptText,
+			&caption.PromptYouTube,
+			&imageBytes,
+			&caption.WinnerUserName,
+			&caption.CaptionText,
+		); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to scan row in query results")
+		}
+
+		caption.PromptImage.Valid = imageBytes != nil
+		if caption.PromptImage.Valid {
+			caption.PromptImage.String = base64.StdEncoding.EncodeToString(imageBytes)
+		}
+
+		result = append(result, caption)
+	}
+	return result, nil
+}
+
+func CountGalleryCaptions(userId uuid.UUID) (int, error) {
+	sqlString := `
+		SELECT
+			COUNT(*)
+		FROM LOG_CAPTION_WIN AS LCW
+			INNER JOIN CARD AS C ON C.ID = LCW.JUDGE_CARD_ID
+			INNER JOIN DECK AS D ON D.ID = C.DECK_ID
+			INNER JOIN USER AS U ON U.ID = LCW.PLAYER_USER_ID
+		WHERE FN_USER_HAS_DECK_ACCESS(?, C.DECK_ID)
+	`
+	rows, err := query(sqlString, userId)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			log.Println(err)
+			return 0, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return count, nil
+}
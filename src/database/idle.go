@@ -0,0 +1,104 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// IdlePlayer is a seated player who has not done anything this round and is
+// close to, or past, the lobby's idle limit.
+type IdlePlayer struct {
+	PlayerId uuid.UUID
+	LobbyId  uuid.UUID
+	UserId   uuid.UUID
+	Name     string
+
+	MissedRounds int
+	IdleRounds   int
+	IsWarned     bool
+}
+
+// SetPlayerActedThisRound records that the player did something this round,
+// which clears their missed rounds.
+func SetPlayerActedThisRound(playerId uuid.UUID) error {
+	sqlString := `
+		UPDATE CJ_PLAYER_STATE AS CJPS
+			INNER JOIN PLAYER AS P ON P.ID = CJPS.PLAYER_ID
+			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = P.LOBBY_ID
+		SET CJPS.LAST_ACTION_ROUND_ID = CJLS.ROUND_ID,
+			CJPS.MISSED_ROUNDS = 0
+		WHERE CJPS.PLAYER_ID = ?
+	`
+	return execute(sqlString, playerId)
+}
+
+// GetIdlePlayers lists players in running lobbies who will be warned or
+// handled for being idle. Players only show up from the round before their
+// first missed-round step.
+func GetIdlePlayers() ([]IdlePlayer, error) {
+	sqlString := `
+		SELECT
+			P.ID,
+			P.LOBBY_ID,
+			P.USER_ID,
+			U.NAME,
+			CJPS.MISSED_ROUNDS,
+			CJLS.IDLE_ROUNDS,
+			CJPS.IDLE_WARNED_ROUND_ID <=> CJLS.ROUND_ID AS IS_WARNED
+		FROM PLAYER AS P
+			INNER JOIN USER AS U ON U.ID = P.USER_ID
+			INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = P.LOBBY_ID
+		WHERE P.IS_ACTIVE = 1
+			AND CJPS.IS_SPECTATOR = 0
+			AND CJLS.IS_STARTED = 1
+			AND CJLS.PAUSED_ON_DATE IS NULL
+			AND CJLS.IDLE_ROUNDS > 0
+			AND CJPS.MISSED_ROUNDS >= CJLS.IDLE_ROUNDS - 1
+			AND NOT CJPS.LAST_ACTION_ROUND_ID <=> CJLS.ROUND_ID
+			AND NOT EXISTS(SELECT ID FROM BOT WHERE PLAYER_ID = P.ID)
+	`
+	rows, err := query(sqlString)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]IdlePlayer, 0)
+	for rows.Next() {
+		var player IdlePlayer
+		if err := rows.Scan(
+			&player.PlayerId,
+			&player.LobbyId,
+			&player.UserId,
+			&player.Name,
+			&player.MissedRounds,
+			&player.IdleRounds,
+			&player.IsWarned,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, player)
+	}
+	return result, nil
+}
+
+func SetPlayerIdleWarned(playerId uuid.UUID) error {
+	sqlString := `
+		UPDATE CJ_PLAYER_STATE AS CJPS
+			INNER JOIN PLAYER AS P ON P.ID = CJPS.PLAYER_ID
+			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = P.LOBBY_ID
+		SET CJPS.IDLE_WARNED_ROUND_ID = CJLS.ROUND_ID
+		WHERE CJPS.PLAYER_ID = ?
+	`
+	return execute(sqlString, playerId)
+}
+
+// SkipIdleJudge passes the judge role on without charging anyone for it.
+func SkipIdleJudge(lobbyId uuid.UUID) error {
+	sqlString := "CALL SP_SKIP_IDLE_JUDGE (?)"
+	return execute(sqlString, lobbyId)
+}
@@ -0,0 +1,302 @@
+package database
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	gsDatabase "github.com/gerp93/gameshell-framework/database"
+	"github.com/google/uuid"
+)
+
+// InviteAccess is what an invite link grants. Lobby invites always grant
+// full access; deck invites can be limited to using the deck in lobbies.
+type InviteAccess string
+
+const (
+	InviteAccessRead InviteAccess = "READ"
+	InviteAccessEdit InviteAccess = "EDIT"
+)
+
+// Invite is a link that gives access to a lobby or deck without its
+// password. Only a hash of the link token is stored, so the link itself is
+// shown once when it is created.
+type Invite struct {
+	Id            uuid.UUID
+	CreatedOnDate time.Time
+	CreatedByName string
+
+	Access        InviteAccess
+	ExpiresOnDate sql.NullTime
+	MaxUses       int
+	UseCount      int
+	IsExpired     bool
+	IsRevoked     bool
+
+	LobbyId uuid.NullUUID
+	DeckId  uuid.NullUUID
+
+	// UsedBy lists the names of the users who used the link.
+	UsedBy string
+}
+
+func (invite Invite) IsActive() bool {
+	return !invite.IsRevoked && !invite.IsExpired && (invite.MaxUses == 0 || invite.UseCount < invite.MaxUses)
+}
+
+func (invite Invite) Status() string {
+	if invite.IsRevoked {
+		return "Revoked"
+	}
+	if invite.IsExpired {
+		return "Expired"
+	}
+	if invite.MaxUses > 0 && invite.UseCount >= invite.MaxUses {
+		return "Used Up"
+	}
+	return "Active"
+}
+
+// CreateLobbyInvite makes a new invite link for the lobby and returns its
+// token. A zero expiry never expires and zero max uses is unlimited.
+func CreateLobbyInvite(lobbyId uuid.UUID, userId uuid.UUID, expiresInHours int, maxUses int) (string, error) {
+	token, tokenHash, err := getNewInviteToken()
+	if err != nil {
+		return "", err
+	}
+
+	sqlString := `
+		INSERT INTO INVITE (CREATED_BY_USER_ID, LOBBY_ID, TOKEN_HASH, ACCESS, EXPIRES_ON_DATE, MAX_USES)
+		VALUES (?, ?, ?, 'EDIT', IF(? > 0, DATE_ADD(NOW(), INTERVAL ? HOUR), NULL), ?)
+	`
+	return token, execute(sqlString, userId, lobbyId, tokenHash, expiresInHours, expiresInHours, maxUses)
+}
+
+// CreateDeckInvite makes a new invite link for the deck and returns its
+// token. A zero expiry never expires and zero max uses is unlimited.
+func CreateDeckInvite(deckId uuid.UUID, userId uuid.UUID, access InviteAccess, expiresInHours int, maxUses int) (string, error) {
+	token, tokenHash, err := getNewInviteToken()
+	if err != nil {
+		return "", err
+	}
+
+	sqlString := `
+		INSERT INTO INVITE (CREATED_BY_USER_ID, DECK_ID, TOKEN_HASH, ACCESS, EXPIRES_ON_DATE, MAX_USES)
+		VALUES (?, ?, ?, ?, IF(? > 0, DATE_ADD(NOW(), INTERVAL ? HOUR), NULL), ?)
+	`
+	return token, execute(sqlString, userId, deckId, tokenHash, access, expiresInHours, expiresInHours, maxUses)
+}
+
+func GetLobbyInvites(lobbyId uuid.UUID) ([]Invite, error) {
+	return getInvites("I.LOBBY_ID = ?", lobbyId)
+}
+
+func GetDeckInvites(deckId uuid.UUID) ([]Invite, error) {
+	return getInvites("I.DECK_ID = ?", deckId)
+}
+
+func GetInvite(id uuid.UUID) (Invite, error) {
+	var invite Invite
+
+	invites, err := getInvites("I.ID = ?", id)
+	if err != nil {
+		return invite, err
+	}
+
+	if len(invites) > 0 {
+		invite = invites[0]
+	}
+
+	return invite, nil
+}
+
+// GetInviteByToken looks up the invite for a link token. The returned
+// invite has a nil id if the token does not match any invite.
+func GetInviteByToken(token string) (Invite, error) {
+	var invite Invite
+
+	invites, err := getInvites("I.TOKEN_HASH = ?", getInviteTokenHash(token))
+	if err != nil {
+		return invite, err
+	}
+
+	if len(invites) > 0 {
+		invite = invites[0]
+	}
+
+	return invite, nil
+}
+
+// UseInvite takes one use of the invite for the user and records who used
+// it. It returns false if the invite can no longer be used.
+func UseInvite(inviteId uuid.UUID, userId uuid.UUID) (bool, error) {
+	var isUsed bool
+
+	sqlString := "CALL SP_USE_INVITE (?, ?)"
+	rows, err := query(sqlString, inviteId, userId)
+	if err != nil {
+		return isUsed, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isUsed); err != nil {
+			log.Println(err)
+			return isUsed, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isUsed, nil
+}
+
+func RevokeInvite(id uuid.UUID) error {
+	sqlString := `
+		UPDATE INVITE
+		SET REVOKED_ON_DATE = NOW()
+		WHERE ID = ?
+			AND REVOKED_ON_DATE IS NULL
+	`
+	return execute(sqlString, id)
+}
+
+// AddUserDeckReadAccess lets the user pick the deck for their lobbies
+// without being able to edit it.
+func AddUserDeckReadAccess(userId uuid.UUID, deckId uuid.UUID) error {
+	sqlString := `
+		INSERT IGNORE INTO USER_READ_ACCESS_DECK (USER_ID, DECK_ID)
+		VALUES (?, ?)
+	`
+	return execute(sqlString, userId, deckId)
+}
+
+// GetReadableDecks adds the decks the user was given read access to by an
+// invite or a collaborator role to the decks the framework already lets them
+// read.
+func GetReadableDecks(userId uuid.UUID) ([]gsDatabase.Deck, error) {
+	result, err := gsDatabase.GetReadableDecks(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlString := `
+		SELECT
+			D.ID,
+			D.NAME
+		FROM DECK AS D
+		WHERE D.ID IN (
+				SELECT DECK_ID FROM USER_READ_ACCESS_DECK WHERE USER_ID = ?
+				UNION
+				SELECT DECK_ID FROM DECK_COLLABORATOR WHERE USER_ID = ?
+			)
+	`
+	rows, err := query(sqlString, userId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deckIds := make(map[uuid.UUID]bool)
+	for _, deck := range result {
+		deckIds[deck.Id] = true
+	}
+
+	for rows.Next() {
+		var deck gsDatabase.Deck
+		if err := rows.Scan(
+			&deck.Id,
+			&deck.Name,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		if !deckIds[deck.Id] {
+			result = append(result, deck)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+func getInvites(whereClause string, param any) ([]Invite, error) {
+	sqlString := `
+		SELECT
+			I.ID,
+			I.CREATED_ON_DATE,
+			COALESCE(CU.NAME, ''),
+			I.ACCESS,
+			I.EXPIRES_ON_DATE,
+			I.MAX_USES,
+			I.USE_COUNT,
+			COALESCE(I.EXPIRES_ON_DATE <= NOW(), 0) AS IS_EXPIRED,
+			I.REVOKED_ON_DATE IS NOT NULL AS IS_REVOKED,
+			I.LOBBY_ID,
+			I.DECK_ID,
+			COALESCE((
+				SELECT
+					GROUP_CONCAT(U.NAME ORDER BY IU.CREATED_ON_DATE SEPARATOR ', ')
+				FROM INVITE_USE AS IU
+					INNER JOIN USER AS U ON U.ID = IU.USER_ID
+				WHERE IU.INVITE_ID = I.ID
+			), '') AS USED_BY
+		FROM INVITE AS I
+			LEFT JOIN USER AS CU ON CU.ID = I.CREATED_BY_USER_ID
+		WHERE ` + whereClause + `
+		ORDER BY I.CREATED_ON_DATE DESC
+	`
+	rows, err := query(sqlString, param)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]Invite, 0)
+	for rows.Next() {
+		var invite Invite
+		if err := rows.Scan(
+			&invite.Id,
+			&invite.CreatedOnDate,
+			&invite.CreatedByName,
+			&invite.Access,
+			&invite.ExpiresOnDate,
+			&invite.MaxUses,
+			&invite.UseCount,
+			&invite.IsExpired,
+			&invite.IsRevoked,
+			&invite.LobbyId,
+			&invite.DeckId,
+			&invite.UsedBy,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, invite)
+	}
+	return result, nil
+}
+
+// getNewInviteToken returns a random link token and the hash to store.
+func getNewInviteToken() (string, string, error) {
+	bytes := make([]byte, 32)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		log.Println(err)
+		return "", "", errors.New("failed to create invite token")
+	}
+
+	token := hex.EncodeToString(bytes)
+	return token, getInviteTokenHash(token), nil
+}
+
+func getInviteTokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
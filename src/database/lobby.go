@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -28,6 +29,41 @@ type Lobby struct {
 	FreeSpecialCards    bool
 	WinStreakThreshold  int
 	LoseStreakThreshold int
+
+	FreeTextMode       bool
+	FreeTextHands      bool
+	FreeTextMaxLength  int
+	FreeTextWordFilter sql.NullString
+
+	CaptionMode bool
+
+	PromptChoices int
+
+	CustomPrompts bool
+
+	WritePromptCount   int
+	WriteResponseCount int
+	WritePhaseEnd      sql.NullTime
+	WritePhaseMinutes  int
+
+	AllowSpectators bool
+	MaxSpectators   int
+
+	MinPlayers int
+	IsStarted  bool
+
+	KickThresholdMode string
+	KickVoteCount     int
+	KickVoteMinutes   int
+	KickBanMinutes    int
+
+	IdleRounds int
+
+	HandicapMode  string
+	HandicapScale float64
+	CatchUpMode   string
+
+	ReshuffleExcludeRounds int
 }
 
 type LobbyDetails struct {
@@ -38,22 +74,69 @@ type LobbyDetails struct {
 type LobbyGameInfo struct {
 	LobbyName string
 
-	PlayerIsLobbyOwner bool
+	PlayerRole LobbyRole
 
 	JudgeName sql.NullString
 
+	SpectatorCount int
+
 	RoundTimer int
 
 	DrawPilePromptCount   int
 	DrawPileResponseCount int
-	DrawPileDeckNames     string
+	DrawPileDecks         []DrawPileDeck
+	DrawPileIsWeighted    bool
+
+	DiscardPilePromptCount   int
+	DiscardPileResponseCount int
+}
+
+// DrawPileDeck is a deck in the lobby and the chance the next card of each
+// category is drawn from it.
+type DrawPileDeck struct {
+	Name            string
+	Weight          int
+	PromptCount     int
+	ResponseCount   int
+	PromptPercent   int
+	ResponsePercent int
+}
+
+type WaitingRoomPlayer struct {
+	Name        string
+	IsConnected bool
+	IsReady     bool
+	IsBot       bool
+	IsSpectator bool
+	Role        LobbyRole
+}
+
+type LobbyWaitingRoomData struct {
+	LobbyId    uuid.UUID
+	MinPlayers int
+
+	PlayerRole        LobbyRole
+	PlayerIsReady     bool
+	PlayerIsSpectator bool
+
+	Players     []WaitingRoomPlayer
+	SeatedCount int
+	ReadyCount  int
+	CanStart    bool
 }
 
 type PlayerHandData struct {
-	LobbyId uuid.UUID
+	LobbyId                uuid.UUID
+	LobbyFreeTextMode      bool
+	LobbyFreeTextMaxLength int
+
+	JudgeIsChoosingPrompt bool
+
+	LobbyIsWriting bool
 
 	PlayerId               uuid.UUID
 	PlayerIsJudge          bool
+	PlayerIsSpectator      bool
 	PlayerDiscardAdvantage bool
 	PlayerIsReady          bool
 	PlayerHand             []Card
@@ -65,15 +148,31 @@ type PlayerSpecialsData struct {
 	LobbyFreeSpecialCards    bool
 	LobbyWinStreakThreshold  int
 	LobbyLoseStreakThreshold int
+	LobbyHandicapMode        string
+	LobbySpecials            SpecialSet
+
+	JudgeIsChoosingPrompt bool
 
 	BoardHasAnySpecial  bool
 	BoardHasAnyRevealed bool
+	BoardIsAllRevealed  bool
 	BoardResponses      []boardResponse
 
+	// RevealedResponses are the revealed responses of other players that are
+	// not ruled out, which the player can place a side bet on or veto.
+	RevealedResponses []revealedResponse
+	SideBetPool       int
+
 	Opponents []opponentData
 
+	// Handicaps is the current handicap of every seated player.
+	Handicaps []nameCountRow
+
+	StreakRules []StreakRule
+
 	PlayerId                uuid.UUID
 	PlayerIsJudge           bool
+	PlayerIsSpectator       bool
 	PlayerIsReady           bool
 	PlayerHandicap          int
 	PlayerWinningStreak     int
@@ -84,34 +183,22 @@ type PlayerSpecialsData struct {
 	PlayerDiscardAdvantage  bool
 	PlayerHandicapAdvantage bool
 	PlayerSpyAdvantage      bool
+	PlayerJudgeImmunity     int
+	PlayerSideBet           int
 	PlayerCreditsRemaining  int
 
 	CreditHistory []creditHistoryData
 
-	SpecialCostSkipBeingJudge int
-	SpecialCostExtraResponse  int
-	SpecialCostBlockResponse  int
-	SpecialCostSurpriseCard   int
-	SpecialCostStealCard      int
-	SpecialCostFindCard       int
-	SpecialCostWildCard       int
-	SpecialCostPerk           int
-
-	CannotAffordAlert          bool
-	CannotAffordGamble         bool
-	CannotAffordBet            bool
-	CannotAffordSkipBeingJudge bool
-	CannotAffordExtraResponse  bool
-	CannotAffordBlockResponse  bool
-	CannotAffordSurpriseCard   bool
-	CannotAffordStealCard      bool
-	CannotAffordFindCard       bool
-	CannotAffordWildCard       bool
-	CannotAffordPerk           bool
+	// Economy is every price, reward and payout of the lobby. Prices do not
+	// include the player's handicap.
+	Economy map[EconomyCategory]int
 }
 
 type LobbyGameBoardData struct {
-	LobbyId uuid.UUID
+	LobbyId            uuid.UUID
+	LobbyCaptionMode   bool
+	LobbyCustomPrompts bool
+	LobbyIsPaused      bool
 
 	JudgeCardText      sql.NullString
 	JudgeCardYouTube   sql.NullString
@@ -119,6 +206,20 @@ type LobbyGameBoardData struct {
 	JudgeBlankCount    int
 	JudgeResponseCount int
 
+	JudgeIsChoosingPrompt bool
+	PromptCandidates      []Card
+
+	JudgeCardIsCustom bool
+	JudgeSaveDecks    []deckOption
+
+	LobbyIsWriting           bool
+	LobbyWriteSecondsLeft    int
+	LobbyWritePromptCount    int
+	LobbyWriteResponseCount  int
+	PlayerWrotePromptCount   int
+	PlayerWroteResponseCount int
+	PlayerRole               LobbyRole
+
 	RoundTimer int
 
 	BoardIsReady        bool
@@ -139,7 +240,7 @@ type LobbyGameStatsData struct {
 	PlayerId           uuid.UUID
 	PlayerSpyAdvantage bool
 
-	Wins           []nameCountRow
+	Wins           []winsRow
 	Credits        []nameCountRow
 	UpcomingJudges []string
 	KickVotes      []kickVote
@@ -150,6 +251,11 @@ type opponentData struct {
 	UserName string
 }
 
+type revealedResponse struct {
+	ResponseId uuid.UUID
+	Text       string
+}
+
 type creditHistoryData struct {
 	Category      string
 	BalanceChange int
@@ -159,8 +265,10 @@ type boardResponse struct {
 	ResponseId     uuid.UUID
 	IsRevealed     bool
 	IsRuledOut     bool
+	IsVetoed       bool
 	PlayerId       uuid.UUID
 	PlayerUserName string
+	CardBack       string
 	ResponseCards  []boardResponseCard
 }
 
@@ -175,10 +283,22 @@ type nameCountRow struct {
 	Count int
 }
 
+type winsRow struct {
+	nameCountRow
+	NameColor string
+}
+
+type deckOption struct {
+	Id   uuid.UUID
+	Name string
+}
+
 type kickVote struct {
-	PlayerId uuid.UUID
-	UserName string
-	Voted    bool
+	PlayerId  uuid.UUID
+	UserName  string
+	Voted     bool
+	VoteCount int
+	Threshold int
 }
 
 func SearchLobbies(name string, page int) ([]LobbyDetails, error) {
@@ -201,6 +321,7 @@ func SearchLobbies(name string, page int) ([]LobbyDetails, error) {
 			CJLS.FREE_SPECIAL_CARDS,
 			CJLS.WIN_STREAK_THRESHOLD,
 			CJLS.LOSE_STREAK_THRESHOLD,
+			CJLS.ALLOW_SPECTATORS,
 			COUNT(P.ID) AS USER_COUNT
 		FROM LOBBY AS L
 			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = L.ID
@@ -232,6 +353,7 @@ func SearchLobbies(name string, page int) ([]LobbyDetails, error) {
 			&ld.FreeSpecialCards,
 			&ld.WinStreakThreshold,
 			&ld.LoseStreakThreshold,
+			&ld.AllowSpectators,
 			&ld.UserCount,
 		); err != nil {
 			log.Println(err)
@@ -284,7 +406,31 @@ func GetLobby(id uuid.UUID) (Lobby, error) {
 			CJLS.FREE_CREDITS,
 			CJLS.FREE_SPECIAL_CARDS,
 			CJLS.WIN_STREAK_THRESHOLD,
-			CJLS.LOSE_STREAK_THRESHOLD
+			CJLS.LOSE_STREAK_THRESHOLD,
+			CJLS.FREE_TEXT_MODE,
+			CJLS.FREE_TEXT_HANDS,
+			CJLS.FREE_TEXT_MAX_LENGTH,
+			CJLS.FREE_TEXT_WORD_FILTER,
+			CJLS.CAPTION_MODE,
+			CJLS.PROMPT_CHOICES,
+			CJLS.CUSTOM_PROMPTS,
+			CJLS.WRITE_PROMPT_COUNT,
+			CJLS.WRITE_RESPONSE_COUNT,
+			CJLS.WRITE_PHASE_END,
+			CJLS.WRITE_PHASE_MINUTES,
+			CJLS.ALLOW_SPECTATORS,
+			CJLS.MAX_SPECTATORS,
+			CJLS.MIN_PLAYERS,
+			CJLS.IS_STARTED,
+			CJLS.KICK_THRESHOLD_MODE,
+			CJLS.KICK_VOTE_COUNT,
+			CJLS.KICK_VOTE_MINUTES,
+			CJLS.KICK_BAN_MINUTES,
+			CJLS.IDLE_ROUNDS,
+			CJLS.HANDICAP_MODE,
+			CJLS.HANDICAP_SCALE,
+			CJLS.CATCH_UP_MODE,
+			CJLS.RESHUFFLE_EXCLUDE_ROUNDS
 		FROM LOBBY AS L
 			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = L.ID
 		WHERE L.ID = ?
@@ -308,7 +454,31 @@ func GetLobby(id uuid.UUID) (Lobby, error) {
 			&lobby.FreeCredits,
 			&lobby.FreeSpecialCards,
 			&lobby.WinStreakThreshold,
-			&lobby.LoseStreakThreshold); err != nil {
+			&lobby.LoseStreakThreshold,
+			&lobby.FreeTextMode,
+			&lobby.FreeTextHands,
+			&lobby.FreeTextMaxLength,
+			&lobby.FreeTextWordFilter,
+			&lobby.CaptionMode,
+			&lobby.PromptChoices,
+			&lobby.CustomPrompts,
+			&lobby.WritePromptCount,
+			&lobby.WriteResponseCount,
+			&lobby.WritePhaseEnd,
+			&lobby.WritePhaseMinutes,
+			&lobby.AllowSpectators,
+			&lobby.MaxSpectators,
+			&lobby.MinPlayers,
+			&lobby.IsStarted,
+			&lobby.KickThresholdMode,
+			&lobby.KickVoteCount,
+			&lobby.KickVoteMinutes,
+			&lobby.KickBanMinutes,
+			&lobby.IdleRounds,
+			&lobby.HandicapMode,
+			&lobby.HandicapScale,
+			&lobby.CatchUpMode,
+			&lobby.ReshuffleExcludeRounds); err != nil {
 			log.Println(err)
 			return lobby, errors.New("failed to scan row in query results")
 		}
@@ -332,15 +502,134 @@ func SetLobbySettings(lobbyId uuid.UUID, drawPriority string, handSize int, roun
 	return execute(sqlString, drawPriority, handSize, roundTimer, freeCredits, freeSpecialCards, winStreakThreshold, loseStreakThreshold, lobbyId)
 }
 
+func SetLobbyFreeTextSettings(id uuid.UUID, freeTextMode bool, freeTextHands bool, freeTextMaxLength int, freeTextWordFilter string) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET FREE_TEXT_MODE = ?,
+			FREE_TEXT_HANDS = ?,
+			FREE_TEXT_MAX_LENGTH = ?,
+			FREE_TEXT_WORD_FILTER = NULLIF(?, '')
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, freeTextMode, freeTextHands, freeTextMaxLength, freeTextWordFilter, id)
+}
+
+func SetLobbyPromptChoices(id uuid.UUID, promptChoices int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET PROMPT_CHOICES = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, promptChoices, id)
+}
+
+func SetLobbyCustomPrompts(id uuid.UUID, customPrompts bool) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET CUSTOM_PROMPTS = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, customPrompts, id)
+}
+
+func SetLobbyWriteCounts(id uuid.UUID, writePromptCount int, writeResponseCount int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET WRITE_PROMPT_COUNT = ?,
+			WRITE_RESPONSE_COUNT = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, writePromptCount, writeResponseCount, id)
+}
+
+// SetLobbyWritePhaseMinutes sets how long the writing phase runs once the
+// game starts.
+func SetLobbyWritePhaseMinutes(id uuid.UUID, minutes int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET WRITE_PHASE_MINUTES = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, minutes, id)
+}
+
+func SetLobbySpectators(id uuid.UUID, allowSpectators bool, maxSpectators int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET ALLOW_SPECTATORS = ?,
+			MAX_SPECTATORS = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, allowSpectators, maxSpectators, id)
+}
+
+func SetLobbyMinPlayers(id uuid.UUID, minPlayers int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET MIN_PLAYERS = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, minPlayers, id)
+}
+
+func SetLobbyKickRules(id uuid.UUID, thresholdMode string, voteCount int, voteMinutes int, banMinutes int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET KICK_THRESHOLD_MODE = ?,
+			KICK_VOTE_COUNT = ?,
+			KICK_VOTE_MINUTES = ?,
+			KICK_BAN_MINUTES = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, thresholdMode, voteCount, voteMinutes, banMinutes, id)
+}
+
+func SetLobbyIdleRounds(id uuid.UUID, idleRounds int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET IDLE_ROUNDS = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, idleRounds, id)
+}
+
+func SetLobbyReshuffleExcludeRounds(id uuid.UUID, excludeRounds int) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET RESHUFFLE_EXCLUDE_ROUNDS = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, excludeRounds, id)
+}
+
+func SetLobbyHandicapRules(id uuid.UUID, handicapMode string, handicapScale float64, catchUpMode string) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET HANDICAP_MODE = ?,
+			HANDICAP_SCALE = ?,
+			CATCH_UP_MODE = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, handicapMode, handicapScale, catchUpMode, id)
+}
+
+func SetLobbyCaptionMode(id uuid.UUID, captionMode bool) error {
+	sqlString := `
+		UPDATE CJ_LOBBY_SETTINGS
+		SET CAPTION_MODE = ?
+		WHERE LOBBY_ID = ?
+	`
+	return execute(sqlString, captionMode, id)
+}
+
+// SyncDecksInLobby makes the lobby draw pile match the given decks. Response
+// decks may be empty for free-text lobbies, in which case every deck response
+// card is removed from the draw pile.
 func SyncDecksInLobby(lobbyId uuid.UUID, deckIdsPrompt []uuid.UUID, deckIdsResponse []uuid.UUID) error {
 	if len(deckIdsPrompt) == 0 {
 		return errors.New("cannot sync decks in lobby, no prompt deck ids provided")
 	}
 
-	if len(deckIdsResponse) == 0 {
-		return errors.New("cannot sync decks in lobby, no response deck ids provided")
-	}
-
 	var err error
 
 	err = addDecksToLobby(lobbyId, deckIdsPrompt, "PROMPT")
@@ -366,13 +655,113 @@ func SyncDecksInLobby(lobbyId uuid.UUID, deckIdsPrompt []uuid.UUID, deckIdsRespo
 	return nil
 }
 
+// CountMediaPrompts counts the image and video prompts in the decks, the only
+// prompts a caption contest can draw.
+func CountMediaPrompts(deckIds []uuid.UUID) (int, error) {
+	if len(deckIds) == 0 {
+		return 0, nil
+	}
+
+	sqlString := fmt.Sprintf(`
+		SELECT
+			COUNT(*)
+		FROM CARD
+		WHERE CATEGORY = 'PROMPT'
+			AND DECK_ID IN (%s)
+			AND (
+				IMAGE IS NOT NULL
+				OR YOUTUBE IS NOT NULL
+			)
+	`, strings.Repeat("?,", len(deckIds)-1)+"?")
+
+	args := make([]any, len(deckIds))
+	for i, deckId := range deckIds {
+		args[i] = deckId
+	}
+
+	rows, err := query(sqlString, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			log.Println(err)
+			return 0, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return count, nil
+}
+
+// GetLobbyDeckWeights returns the draw weight of every weighted deck in the
+// lobby. A lobby without weights draws every card with the same odds.
+func GetLobbyDeckWeights(lobbyId uuid.UUID) (map[uuid.UUID]int, error) {
+	sqlString := `
+		SELECT
+			DECK_ID,
+			WEIGHT
+		FROM CJ_LOBBY_DECK_WEIGHT
+		WHERE LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var deckId uuid.UUID
+		var weight int
+		if err := rows.Scan(&deckId, &weight); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result[deckId] = weight
+	}
+	return result, nil
+}
+
+// SetLobbyDeckWeights replaces the lobby deck weights. Once any deck has a
+// weight, a deck is picked by weight before a card is drawn from it, and
+// decks without one count as 1. The weights are replaced in one transaction,
+// so a draw never sees only some of them.
+func SetLobbyDeckWeights(lobbyId uuid.UUID, deckWeights map[uuid.UUID]int) error {
+	type deckWeight struct {
+		DeckId uuid.UUID `json:"deckId"`
+		Weight int       `json:"weight"`
+	}
+
+	rows := make([]deckWeight, 0, len(deckWeights))
+	for deckId, weight := range deckWeights {
+		rows = append(rows, deckWeight{DeckId: deckId, Weight: weight})
+	}
+
+	rowsJson, err := json.Marshal(rows)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to encode deck weights")
+	}
+
+	sqlString := "CALL SP_SET_LOBBY_DECK_WEIGHTS (?, ?)"
+	return execute(sqlString, lobbyId, string(rowsJson))
+}
+
 func addDecksToLobby(lobbyId uuid.UUID, deckIds []uuid.UUID, cardCategory string) error {
+	if len(deckIds) == 0 {
+		return nil
+	}
+
 	sqlString := fmt.Sprintf(`
 		INSERT INTO DRAW_PILE(LOBBY_ID, CARD_ID)
 		SELECT
 			? AS LOBBY_ID,
 			C.ID AS CARD_ID
 		FROM CARD AS C
+			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = ?
 			LEFT JOIN (
 				SELECT DISTINCT
 					E_C.DECK_ID
@@ -380,19 +769,35 @@ func addDecksToLobby(lobbyId uuid.UUID, deckIds []uuid.UUID, cardCategory string
 					INNER JOIN CARD AS E_C ON E_C.ID = E_DP.CARD_ID
 				WHERE E_DP.LOBBY_ID = ?
 					AND E_C.CATEGORY = ?
+				UNION
+				SELECT DISTINCT
+					E_C.DECK_ID
+				FROM DISCARD_PILE AS E_DSP
+					INNER JOIN CARD AS E_C ON E_C.ID = E_DSP.CARD_ID
+				WHERE E_DSP.LOBBY_ID = ?
+					AND E_C.CATEGORY = ?
 			) AS E ON E.DECK_ID = C.DECK_ID
 		WHERE C.CATEGORY = ?
 			AND C.DECK_ID IN (%s)
 			AND E.DECK_ID IS NULL
+			AND (
+				C.CATEGORY = 'RESPONSE'
+				OR CJLS.CAPTION_MODE = 0
+				OR C.IMAGE IS NOT NULL
+				OR C.YOUTUBE IS NOT NULL
+			)
 	`, strings.Repeat("?,", len(deckIds)-1)+"?")
 
-	args := make([]any, len(deckIds)+4)
+	args := make([]any, len(deckIds)+7)
 	args[0] = lobbyId
 	args[1] = lobbyId
-	args[2] = cardCategory
+	args[2] = lobbyId
 	args[3] = cardCategory
+	args[4] = lobbyId
+	args[5] = cardCategory
+	args[6] = cardCategory
 	for i, deckId := range deckIds {
-		args[i+4] = deckId
+		args[i+7] = deckId
 	}
 
 	err := execute(sqlString, args...)
@@ -404,35 +809,221 @@ func addDecksToLobby(lobbyId uuid.UUID, deckIds []uuid.UUID, cardCategory string
 }
 
 func removeDecksFromLobby(lobbyId uuid.UUID, deckIds []uuid.UUID, cardCategory string) error {
-	sqlString := fmt.Sprintf(`
-		DELETE DP
-		FROM DRAW_PILE AS DP
-			INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
-		WHERE DP.LOBBY_ID = ?
-			AND C.CATEGORY = ?
-			AND C.DECK_ID NOT IN (%s)
-	`, strings.Repeat("?,", len(deckIds)-1)+"?")
+	// the discard pile is cleared the same way, so removed decks do not come
+	// back in a reshuffle
+	for _, pile := range []string{"DRAW_PILE", "DISCARD_PILE"} {
+		if len(deckIds) == 0 {
+			sqlString := fmt.Sprintf(`
+				DELETE DP
+				FROM %s AS DP
+					INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
+				WHERE DP.LOBBY_ID = ?
+					AND C.CATEGORY = ?
+					AND C.DECK_ID IS NOT NULL
+			`, pile)
+			err := execute(sqlString, lobbyId, cardCategory)
+			if err != nil {
+				return err
+			}
+			continue
+		}
 
-	args := make([]any, len(deckIds)+2)
-	args[0] = lobbyId
-	args[1] = cardCategory
-	for i, deckId := range deckIds {
-		args[i+2] = deckId
-	}
+		sqlString := fmt.Sprintf(`
+			DELETE DP
+			FROM %s AS DP
+				INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
+			WHERE DP.LOBBY_ID = ?
+				AND C.CATEGORY = ?
+				AND C.DECK_ID NOT IN (%s)
+		`, pile, strings.Repeat("?,", len(deckIds)-1)+"?")
+
+		args := make([]any, len(deckIds)+2)
+		args[0] = lobbyId
+		args[1] = cardCategory
+		for i, deckId := range deckIds {
+			args[i+2] = deckId
+		}
 
-	err := execute(sqlString, args...)
-	if err != nil {
-		return err
+		err := execute(sqlString, args...)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// OpenLobbyGame creates the settings for a new lobby, which waits for the
+// host to start the game with InitLobbyGame.
+func OpenLobbyGame(lobbyId uuid.UUID) error {
+	sqlString := "CALL SP_CJ_OPEN_LOBBY (?)"
+	return execute(sqlString, lobbyId)
+}
+
+// InitLobbyGame starts the game: it seats the judge, deals every player in
+// and begins the writing phase if the lobby has one.
 func InitLobbyGame(lobbyId uuid.UUID) error {
 	sqlString := "CALL SP_CJ_INIT_LOBBY (?)"
 	return execute(sqlString, lobbyId)
 }
 
+func IsLobbyStarted(lobbyId uuid.UUID) (bool, error) {
+	isStarted := false
+
+	sqlString := `
+		SELECT
+			IS_STARTED
+		FROM CJ_LOBBY_SETTINGS
+		WHERE LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return isStarted, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isStarted); err != nil {
+			log.Println(err)
+			return isStarted, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isStarted, nil
+}
+
+func IsLobbyPaused(lobbyId uuid.UUID) (bool, error) {
+	isPaused := false
+
+	sqlString := `
+		SELECT
+			PAUSED_ON_DATE IS NOT NULL
+		FROM CJ_LOBBY_SETTINGS
+		WHERE LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return isPaused, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isPaused); err != nil {
+			log.Println(err)
+			return isPaused, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isPaused, nil
+}
+
+// PauseLobby freezes play in the lobby until ResumeLobby. Each pause is logged
+// with its duration once resumed.
+func PauseLobby(lobbyId uuid.UUID, userId uuid.UUID) error {
+	sqlString := "CALL SP_PAUSE_LOBBY (?, ?)"
+	return execute(sqlString, lobbyId, userId)
+}
+
+func ResumeLobby(lobbyId uuid.UUID) error {
+	sqlString := "CALL SP_RESUME_LOBBY (?)"
+	return execute(sqlString, lobbyId)
+}
+
+func SetPlayerReadyToStart(playerId uuid.UUID, isReady bool) error {
+	sqlString := `
+		UPDATE CJ_PLAYER_STATE
+		SET IS_READY_TO_START = ?
+		WHERE PLAYER_ID = ?
+	`
+	return execute(sqlString, isReady, playerId)
+}
+
+// GetLobbyWaitingRoomData lists who is in the lobby before the game starts.
+// Removed bots are left out. Bots and hosts count as ready.
+func GetLobbyWaitingRoomData(lobbyId uuid.UUID, playerId uuid.UUID) (LobbyWaitingRoomData, error) {
+	data := LobbyWaitingRoomData{LobbyId: lobbyId}
+
+	sqlString := `
+		SELECT
+			MIN_PLAYERS
+		FROM CJ_LOBBY_SETTINGS
+		WHERE LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&data.MinPlayers); err != nil {
+			log.Println(err)
+			return data, errors.New("failed to scan row in query results")
+		}
+	}
+
+	sqlString = `
+		SELECT
+			P.ID,
+			U.NAME,
+			P.IS_ACTIVE,
+			CJPS.IS_READY_TO_START,
+			B.ID IS NOT NULL AS IS_BOT,
+			CJPS.IS_SPECTATOR,
+			FN_GET_PLAYER_LOBBY_ROLE(P.ID) AS ROLE
+		FROM PLAYER AS P
+			INNER JOIN USER AS U ON U.ID = P.USER_ID
+			INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+			LEFT JOIN BOT AS B ON B.PLAYER_ID = P.ID
+		WHERE P.LOBBY_ID = ?
+			AND (B.ID IS NULL OR P.IS_ACTIVE = 1)
+		ORDER BY P.JOIN_ORDER
+	`
+	rows, err = query(sqlString, lobbyId)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var waitingPlayerId uuid.UUID
+		var player WaitingRoomPlayer
+		if err := rows.Scan(
+			&waitingPlayerId,
+			&player.Name,
+			&player.IsConnected,
+			&player.IsReady,
+			&player.IsBot,
+			&player.IsSpectator,
+			&player.Role,
+		); err != nil {
+			log.Println(err)
+			return data, errors.New("failed to scan row in query results")
+		}
+
+		player.IsReady = player.IsReady || player.IsBot || player.Role != LobbyRolePlayer
+
+		if waitingPlayerId == playerId {
+			data.PlayerRole = player.Role
+			data.PlayerIsReady = player.IsReady
+			data.PlayerIsSpectator = player.IsSpectator
+		}
+
+		if player.IsConnected && !player.IsSpectator {
+			data.SeatedCount++
+			if player.IsReady {
+				data.ReadyCount++
+			}
+		}
+
+		data.Players = append(data.Players, player)
+	}
+
+	data.CanStart = data.SeatedCount >= data.MinPlayers && data.ReadyCount == data.SeatedCount
+
+	return data, nil
+}
+
 func InitPlayerGame(playerId uuid.UUID) error {
 	sqlString := "CALL SP_CJ_INIT_PLAYER (?)"
 	return execute(sqlString, playerId)
@@ -443,6 +1034,96 @@ func SetPlayerActiveGame(playerId uuid.UUID) error {
 	return execute(sqlString, playerId)
 }
 
+func SetPlayerSpectator(playerId uuid.UUID, isSpectator bool) error {
+	sqlString := "CALL SP_SET_PLAYER_SPECTATOR (?, ?)"
+	return execute(sqlString, playerId, isSpectator)
+}
+
+func ReturnHand(playerId uuid.UUID) error {
+	sqlString := "CALL SP_RETURN_HAND (?)"
+	return execute(sqlString, playerId)
+}
+
+func IsPlayerSpectator(playerId uuid.UUID) (bool, error) {
+	isSpectator := false
+
+	sqlString := `
+		SELECT
+			IS_SPECTATOR
+		FROM CJ_PLAYER_STATE
+		WHERE PLAYER_ID = ?
+	`
+	rows, err := query(sqlString, playerId)
+	if err != nil {
+		return isSpectator, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isSpectator); err != nil {
+			log.Println(err)
+			return isSpectator, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isSpectator, nil
+}
+
+func IsPlayerJudge(playerId uuid.UUID) (bool, error) {
+	isJudge := false
+
+	sqlString := `
+		SELECT
+			FN_GET_LOBBY_JUDGE_PLAYER_ID(LOBBY_ID) = ID
+		FROM PLAYER
+		WHERE ID = ?
+	`
+	rows, err := query(sqlString, playerId)
+	if err != nil {
+		return isJudge, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result sql.NullBool
+		if err := rows.Scan(&result); err != nil {
+			log.Println(err)
+			return isJudge, errors.New("failed to scan row in query results")
+		}
+		isJudge = result.Valid && result.Bool
+	}
+
+	return isJudge, nil
+}
+
+func CountLobbySpectators(lobbyId uuid.UUID) (int, error) {
+	count := 0
+
+	sqlString := `
+		SELECT
+			COUNT(*)
+		FROM PLAYER AS P
+			INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+		WHERE P.LOBBY_ID = ?
+			AND P.IS_ACTIVE = 1
+			AND CJPS.IS_SPECTATOR = 1
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return count, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			log.Println(err)
+			return count, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return count, nil
+}
+
 func SetPlayerInactiveGame(playerId uuid.UUID) error {
 	sqlString := "CALL SP_CJ_PLAYER_INACTIVE (?)"
 	return execute(sqlString, playerId)
@@ -522,15 +1203,7 @@ func GetLobbyGameInfo(lobbyId uuid.UUID, playerId uuid.UUID) (LobbyGameInfo, err
 	sqlString := `
 		SELECT
 			L.NAME AS LOBBY_NAME,
-			(
-				SELECT
-					P.ID
-				FROM PLAYER AS P
-				WHERE P.LOBBY_ID = L.ID
-					AND P.IS_ACTIVE = 1
-				ORDER BY P.JOIN_ORDER ASC
-				LIMIT 1
-			) AS LOBBY_OWNER_ID,
+			FN_GET_PLAYER_LOBBY_ROLE(?) AS PLAYER_ROLE,
 			(
 				SELECT
 					U.NAME
@@ -540,6 +1213,15 @@ func GetLobbyGameInfo(lobbyId uuid.UUID, playerId uuid.UUID) (LobbyGameInfo, err
 				WHERE J.LOBBY_ID = L.ID
 				LIMIT 1
 			) AS JUDGE_NAME,
+			(
+				SELECT
+					COUNT(*)
+				FROM PLAYER AS P
+					INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+				WHERE P.LOBBY_ID = L.ID
+					AND P.IS_ACTIVE = 1
+					AND CJPS.IS_SPECTATOR = 1
+			) AS SPECTATOR_COUNT,
 			(
 				SELECT
 					ROUND_TIMER
@@ -561,68 +1243,178 @@ func GetLobbyGameInfo(lobbyId uuid.UUID, playerId uuid.UUID) (LobbyGameInfo, err
 					INNER JOIN CARD AS DPC ON DPC.ID = DP.CARD_ID
 				WHERE DP.LOBBY_ID = L.ID
 					AND DPC.CATEGORY = 'RESPONSE'
-			) AS DRAW_PILE_RESPONSE_COUNT
+			) AS DRAW_PILE_RESPONSE_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM DISCARD_PILE AS DSP
+					INNER JOIN CARD AS DSPC ON DSPC.ID = DSP.CARD_ID
+				WHERE DSP.LOBBY_ID = L.ID
+					AND DSPC.CATEGORY = 'PROMPT'
+			) AS DISCARD_PILE_PROMPT_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM DISCARD_PILE AS DSP
+					INNER JOIN CARD AS DSPC ON DSPC.ID = DSP.CARD_ID
+				WHERE DSP.LOBBY_ID = L.ID
+					AND DSPC.CATEGORY = 'RESPONSE'
+			) AS DISCARD_PILE_RESPONSE_COUNT
 		FROM LOBBY AS L
 		WHERE L.ID = ?
 	`
-	rows, err := query(sqlString, lobbyId)
+	rows, err := query(sqlString, playerId, lobbyId)
 	if err != nil {
 		return data, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var lobbyOwnerId uuid.UUID
 		if err := rows.Scan(
 			&data.LobbyName,
-			&lobbyOwnerId,
+			&data.PlayerRole,
 			&data.JudgeName,
+			&data.SpectatorCount,
 			&data.RoundTimer,
 			&data.DrawPilePromptCount,
 			&data.DrawPileResponseCount,
+			&data.DiscardPilePromptCount,
+			&data.DiscardPileResponseCount,
 		); err != nil {
 			log.Println(err)
 			return data, errors.New("failed to scan row in query results")
 		}
-
-		data.PlayerIsLobbyOwner = playerId == lobbyOwnerId
 	}
 
+	// decks only in the discard pile are listed too, with no cards to draw
 	sqlString = `
-		SELECT DISTINCT
-			DPD.NAME
-		FROM DRAW_PILE AS DP
-			INNER JOIN CARD AS DPC ON DPC.ID = DP.CARD_ID
-			INNER JOIN DECK AS DPD ON DPD.ID = DPC.DECK_ID
-		WHERE DP.LOBBY_ID = ?
-		ORDER BY DPD.NAME
+		SELECT
+			C.DECK_ID,
+			COALESCE(D.NAME, 'Written Cards') AS DECK_NAME,
+			C.CATEGORY,
+			SUM(P.IS_DRAW) AS CARD_COUNT,
+			COALESCE(MAX(CJLDW.WEIGHT), 0) AS WEIGHT
+		FROM (
+				SELECT
+					CARD_ID,
+					1 AS IS_DRAW
+				FROM DRAW_PILE
+				WHERE LOBBY_ID = ?
+				UNION ALL
+				SELECT
+					CARD_ID,
+					0 AS IS_DRAW
+				FROM DISCARD_PILE
+				WHERE LOBBY_ID = ?
+			) AS P
+			INNER JOIN CARD AS C ON C.ID = P.CARD_ID
+			LEFT JOIN DECK AS D ON D.ID = C.DECK_ID
+			LEFT JOIN CJ_LOBBY_DECK_WEIGHT AS CJLDW ON CJLDW.LOBBY_ID = ?
+			AND CJLDW.DECK_ID = C.DECK_ID
+		GROUP BY C.DECK_ID,
+			D.NAME,
+			C.CATEGORY
+		ORDER BY DECK_NAME
 	`
-	rows, err = query(sqlString, lobbyId)
+	rows, err = query(sqlString, lobbyId, lobbyId, lobbyId)
 	if err != nil {
 		return data, err
 	}
 	defer rows.Close()
 
+	deckIndexes := make(map[uuid.NullUUID]int)
 	for rows.Next() {
+		var deckId uuid.NullUUID
 		var deckName string
-		if err := rows.Scan(&deckName); err != nil {
+		var category string
+		var cardCount int
+		var weight int
+		if err := rows.Scan(&deckId, &deckName, &category, &cardCount, &weight); err != nil {
 			log.Println(err)
 			return data, errors.New("failed to scan row in query results")
 		}
-		data.DrawPileDeckNames += deckName + "&#010;"
+
+		index, ok := deckIndexes[deckId]
+		if !ok {
+			index = len(data.DrawPileDecks)
+			deckIndexes[deckId] = index
+			data.DrawPileDecks = append(data.DrawPileDecks, DrawPileDeck{Name: deckName})
+		}
+
+		deck := &data.DrawPileDecks[index]
+		if weight > 0 {
+			deck.Weight = weight
+			data.DrawPileIsWeighted = true
+		}
+		if category == "PROMPT" {
+			deck.PromptCount = cardCount
+		} else {
+			deck.ResponseCount = cardCount
+		}
 	}
 
+	setDrawPileDeckPercents(data.DrawPileDecks, data.DrawPileIsWeighted)
+
 	return data, nil
 }
 
+// setDrawPileDeckPercents works out the chance of drawing from each deck the
+// same way FN_GET_DRAW_PILE_CARD_ID does: by card count, or by deck weight
+// once the lobby has weights.
+func setDrawPileDeckPercents(decks []DrawPileDeck, isWeighted bool) {
+	share := func(deck DrawPileDeck, cardCount int) int {
+		if cardCount == 0 {
+			return 0
+		}
+		if !isWeighted {
+			return cardCount
+		}
+		if deck.Weight == 0 {
+			return 1
+		}
+		return deck.Weight
+	}
+
+	promptTotal := 0
+	responseTotal := 0
+	for _, deck := range decks {
+		promptTotal += share(deck, deck.PromptCount)
+		responseTotal += share(deck, deck.ResponseCount)
+	}
+
+	for i := range decks {
+		if promptTotal > 0 {
+			decks[i].PromptPercent = 100 * share(decks[i], decks[i].PromptCount) / promptTotal
+		}
+		if responseTotal > 0 {
+			decks[i].ResponsePercent = 100 * share(decks[i], decks[i].ResponseCount) / responseTotal
+		}
+	}
+}
+
 func GetPlayerHandData(playerId uuid.UUID) (PlayerHandData, error) {
 	var data PlayerHandData
 
 	sqlString := `
 		SELECT
 			L.ID AS LOBBY_ID,
+			CJLS.FREE_TEXT_MODE AS LOBBY_FREE_TEXT_MODE,
+			CJLS.FREE_TEXT_MAX_LENGTH AS LOBBY_FREE_TEXT_MAX_LENGTH,
+			EXISTS(
+				SELECT
+					ID
+				FROM PROMPT_CANDIDATE
+				WHERE LOBBY_ID = L.ID
+			) AS JUDGE_IS_CHOOSING_PROMPT,
+			CJLS.WRITE_PHASE_END IS NOT NULL AS LOBBY_IS_WRITING,
 			P.ID AS PLAYER_ID,
 			IF(FN_GET_LOBBY_JUDGE_PLAYER_ID(L.ID) = P.ID, 1, 0) AS PLAYER_IS_JUDGE,
+			(
+				SELECT
+					IS_SPECTATOR
+				FROM CJ_PLAYER_STATE
+				WHERE PLAYER_ID = P.ID
+			) AS PLAYER_IS_SPECTATOR,
 			(
 				SELECT
 					DISCARD_ADVANTAGE
@@ -631,6 +1423,7 @@ func GetPlayerHandData(playerId uuid.UUID) (PlayerHandData, error) {
 			) AS PLAYER_DISCARD_ADVANTAGE
 		FROM PLAYER AS P
 			INNER JOIN LOBBY AS L ON L.ID = P.LOBBY_ID
+			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = L.ID
 		WHERE P.ID = ?
 	`
 	rows, err := query(sqlString, playerId)
@@ -642,8 +1435,13 @@ func GetPlayerHandData(playerId uuid.UUID) (PlayerHandData, error) {
 	for rows.Next() {
 		if err := rows.Scan(
 			&data.LobbyId,
+			&data.LobbyFreeTextMode,
+			&data.LobbyFreeTextMaxLength,
+			&data.JudgeIsChoosingPrompt,
+			&data.LobbyIsWriting,
 			&data.PlayerId,
 			&data.PlayerIsJudge,
+			&data.PlayerIsSpectator,
 			&data.PlayerDiscardAdvantage,
 		); err != nil {
 			log.Println(err)
@@ -738,8 +1536,16 @@ func GetPlayerSpecialsData(playerId uuid.UUID) (PlayerSpecialsData, error) {
 			CJLS.FREE_SPECIAL_CARDS AS LOBBY_FREE_SPECIAL_CARDS,
 			CJLS.WIN_STREAK_THRESHOLD AS LOBBY_WIN_STREAK_THRESHOLD,
 			CJLS.LOSE_STREAK_THRESHOLD AS LOBBY_LOSE_STREAK_THRESHOLD,
+			CJLS.HANDICAP_MODE AS LOBBY_HANDICAP_MODE,
+			EXISTS(
+				SELECT
+					ID
+				FROM PROMPT_CANDIDATE
+				WHERE LOBBY_ID = L.ID
+			) AS JUDGE_IS_CHOOSING_PROMPT,
 			P.ID AS PLAYER_ID,
 			IF(FN_GET_LOBBY_JUDGE_PLAYER_ID(L.ID) = P.ID, 1, 0) AS PLAYER_IS_JUDGE,
+			CJPS.IS_SPECTATOR AS PLAYER_IS_SPECTATOR,
 			FN_GET_PLAYER_HANDICAP(P.ID) AS PLAYER_HANDICAP,
 			CJPS.WINNING_STREAK AS PLAYER_WINNING_STREAK,
 			CJPS.LOSING_STREAK AS PLAYER_LOSING_STREAK,
@@ -748,7 +1554,8 @@ func GetPlayerSpecialsData(playerId uuid.UUID) (PlayerSpecialsData, error) {
 			CJPS.EXTRA_RESPONSES AS PLAYER_EXTRA_RESPONSES,
 			CJPS.DISCARD_ADVANTAGE AS PLAYER_DISCARD_ADVANTAGE,
 			CJPS.HANDICAP_ADVANTAGE AS PLAYER_HANDICAP_ADVANTAGE,
-			CJPS.SPY_ADVANTAGE AS PLAYER_SPY_ADVANTAGE
+			CJPS.SPY_ADVANTAGE AS PLAYER_SPY_ADVANTAGE,
+			CJPS.JUDGE_IMMUNITY AS PLAYER_JUDGE_IMMUNITY
 		FROM PLAYER AS P
 			INNER JOIN LOBBY AS L ON L.ID = P.LOBBY_ID
 			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = L.ID
@@ -769,8 +1576,11 @@ func GetPlayerSpecialsData(playerId uuid.UUID) (PlayerSpecialsData, error) {
 			&data.LobbyFreeSpecialCards,
 			&data.LobbyWinStreakThreshold,
 			&data.LobbyLoseStreakThreshold,
+			&data.LobbyHandicapMode,
+			&data.JudgeIsChoosingPrompt,
 			&data.PlayerId,
 			&data.PlayerIsJudge,
+			&data.PlayerIsSpectator,
 			&data.PlayerHandicap,
 			&data.PlayerWinningStreak,
 			&data.PlayerLosingStreak,
@@ -780,29 +1590,114 @@ func GetPlayerSpecialsData(playerId uuid.UUID) (PlayerSpecialsData, error) {
 			&data.PlayerDiscardAdvantage,
 			&data.PlayerHandicapAdvantage,
 			&data.PlayerSpyAdvantage,
+			&data.PlayerJudgeImmunity,
+		); err != nil {
+			log.Println(err)
+			return data, errors.New("failed to scan row in query results")
+		}
+	}
+
+	data.PlayerCreditsRemaining = data.LobbyFreeCredits - data.PlayerCreditsSpent
+
+	sqlString = `
+		SELECT
+			R.ID
+		FROM PLAYER AS P
+			INNER JOIN RESPONSE AS R ON R.PLAYER_ID = P.ID
+		WHERE P.LOBBY_ID = ?
+			AND R.IS_REVEALED = 1
+	`
+	rows, err = query(sqlString, data.LobbyId)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	data.BoardHasAnyRevealed = rows.Next()
+
+	sqlString = `
+		SELECT
+			R.ID AS RESPONSE_ID,
+			R.PLAYER_ID AS PLAYER_ID,
+			R.IS_REVEALED AS IS_REVEALED,
+			R.IS_RULEDOUT AS IS_RULEDOUT,
+			COALESCE(
+				GROUP_CONCAT(
+					C.TEXT
+					ORDER BY RC.CREATED_ON_DATE SEPARATOR ' / '
+				),
+				''
+			) AS RESPONSE_TEXT
+		FROM PLAYER AS P
+			INNER JOIN RESPONSE AS R ON R.PLAYER_ID = P.ID
+			LEFT JOIN JUDGE AS J ON J.PLAYER_ID = P.ID
+			LEFT JOIN RESPONSE_CARD AS RC ON RC.RESPONSE_ID = R.ID
+			LEFT JOIN CARD AS C ON C.ID = RC.CARD_ID
+		WHERE P.LOBBY_ID = ?
+			AND P.IS_ACTIVE = 1
+			AND J.ID IS NULL
+		GROUP BY R.ID
+		ORDER BY RESPONSE_TEXT
+	`
+	rows, err = query(sqlString, data.LobbyId)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	responseCount := 0
+	data.BoardIsAllRevealed = true
+	for rows.Next() {
+		var row revealedResponse
+		var responsePlayerId uuid.UUID
+		var isRevealed bool
+		var isRuledOut bool
+		if err := rows.Scan(
+			&row.ResponseId,
+			&responsePlayerId,
+			&isRevealed,
+			&isRuledOut,
+			&row.Text,
 		); err != nil {
 			log.Println(err)
 			return data, errors.New("failed to scan row in query results")
 		}
-	}
 
-	data.PlayerCreditsRemaining = data.LobbyFreeCredits - data.PlayerCreditsSpent
+		responseCount++
+		if !isRevealed {
+			data.BoardIsAllRevealed = false
+			continue
+		}
+
+		if !isRuledOut && responsePlayerId != data.PlayerId {
+			data.RevealedResponses = append(data.RevealedResponses, row)
+		}
+	}
+	data.BoardIsAllRevealed = data.BoardIsAllRevealed && responseCount > 0
 
 	sqlString = `
 		SELECT
-			R.ID
-		FROM PLAYER AS P
-			INNER JOIN RESPONSE AS R ON R.PLAYER_ID = P.ID
+			COALESCE(SUM(SB.AMOUNT), 0) AS SIDE_BET_POOL,
+			COALESCE(SUM(IF(SB.PLAYER_ID = ?, SB.AMOUNT, 0)), 0) AS PLAYER_SIDE_BET
+		FROM SIDE_BET AS SB
+			INNER JOIN PLAYER AS P ON P.ID = SB.PLAYER_ID
 		WHERE P.LOBBY_ID = ?
-			AND R.IS_REVEALED = 1
 	`
-	rows, err = query(sqlString, data.LobbyId)
+	rows, err = query(sqlString, data.PlayerId, data.LobbyId)
 	if err != nil {
 		return data, err
 	}
 	defer rows.Close()
 
-	data.BoardHasAnyRevealed = rows.Next()
+	for rows.Next() {
+		if err := rows.Scan(
+			&data.SideBetPool,
+			&data.PlayerSideBet,
+		); err != nil {
+			log.Println(err)
+			return data, errors.New("failed to scan row in query results")
+		}
+	}
 
 	sqlString = `
 		SELECT
@@ -818,7 +1713,9 @@ func GetPlayerSpecialsData(playerId uuid.UUID) (PlayerSpecialsData, error) {
 				'STEAL',
 				'SURPRISE',
 				'FIND',
-				'WILD'
+				'WILD',
+				'SWAP',
+				'VETO'
 			)
 		LIMIT 1
 	`
@@ -894,6 +1791,39 @@ func GetPlayerSpecialsData(playerId uuid.UUID) (PlayerSpecialsData, error) {
 		data.Opponents = append(data.Opponents, row)
 	}
 
+	sqlString = `
+		SELECT
+			U.NAME AS USER_NAME,
+			FN_GET_PLAYER_HANDICAP(P.ID) AS HANDICAP
+		FROM PLAYER AS P
+			INNER JOIN USER AS U ON U.ID = P.USER_ID
+			INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+		WHERE P.LOBBY_ID = ?
+			AND P.IS_ACTIVE = 1
+			AND CJPS.IS_SPECTATOR = 0
+		ORDER BY HANDICAP DESC,
+			U.NAME ASC
+	`
+	rows, err = query(sqlString, data.LobbyId)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row nameCountRow
+		if err := rows.Scan(&row.Name, &row.Count); err != nil {
+			log.Println(err)
+			return data, errors.New("failed to scan row in query results")
+		}
+		data.Handicaps = append(data.Handicaps, row)
+	}
+
+	data.StreakRules, err = GetLobbyStreakRules(data.LobbyId)
+	if err != nil {
+		return data, err
+	}
+
 	sqlString = `
 		SELECT
 			LCS.CATEGORY,
@@ -923,70 +1853,14 @@ func GetPlayerSpecialsData(playerId uuid.UUID) (PlayerSpecialsData, error) {
 		data.CreditHistory = append(data.CreditHistory, row)
 	}
 
-	sqlString = `
-		SELECT
-			FN_GET_SPECIAL_COST('SKIP-JUDGE'),
-			FN_GET_SPECIAL_COST('EXTRA-RESPONSE'),
-			FN_GET_SPECIAL_COST('BLOCK-RESPONSE'),
-			FN_GET_SPECIAL_COST('SURPRISE'),
-			FN_GET_SPECIAL_COST('STEAL'),
-			FN_GET_SPECIAL_COST('FIND'),
-			FN_GET_SPECIAL_COST('WILD'),
-			FN_GET_SPECIAL_COST('PERK')
-	`
-	rows, err = query(sqlString)
+	data.Economy, err = GetLobbyEconomyAmounts(data.LobbyId)
 	if err != nil {
 		return data, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		if err := rows.Scan(
-			&data.SpecialCostSkipBeingJudge,
-			&data.SpecialCostExtraResponse,
-			&data.SpecialCostBlockResponse,
-			&data.SpecialCostSurpriseCard,
-			&data.SpecialCostStealCard,
-			&data.SpecialCostFindCard,
-			&data.SpecialCostWildCard,
-			&data.SpecialCostPerk,
-		); err != nil {
-			log.Println(err)
-			return data, errors.New("failed to scan row in query results")
-		}
-	}
 
-	data.SpecialCostSkipBeingJudge += data.PlayerHandicap
-	data.SpecialCostExtraResponse += data.PlayerHandicap
-	data.SpecialCostBlockResponse += data.PlayerHandicap
-	data.SpecialCostSurpriseCard += data.PlayerHandicap
-	data.SpecialCostStealCard += data.PlayerHandicap
-	data.SpecialCostFindCard += data.PlayerHandicap
-	data.SpecialCostWildCard += data.PlayerHandicap
-	data.SpecialCostPerk += data.PlayerHandicap
-
-	data.CannotAffordAlert = data.PlayerCreditsRemaining < 1
-	data.CannotAffordGamble = data.PlayerCreditsRemaining < 1
-	data.CannotAffordBet = data.PlayerCreditsRemaining < 1
-	data.CannotAffordSkipBeingJudge = data.PlayerCreditsRemaining < data.SpecialCostSkipBeingJudge
-	data.CannotAffordExtraResponse = data.PlayerCreditsRemaining < data.SpecialCostExtraResponse
-	data.CannotAffordBlockResponse = data.PlayerCreditsRemaining < data.SpecialCostBlockResponse
-	data.CannotAffordSurpriseCard = data.PlayerCreditsRemaining < data.SpecialCostSurpriseCard
-	data.CannotAffordStealCard = data.PlayerCreditsRemaining < data.SpecialCostStealCard
-	data.CannotAffordFindCard = data.PlayerCreditsRemaining < data.SpecialCostFindCard
-	data.CannotAffordWildCard = data.PlayerCreditsRemaining < data.SpecialCostWildCard
-	data.CannotAffordPerk = data.PlayerCreditsRemaining < data.SpecialCostPerk
-
-	if data.LobbyFreeSpecialCards {
-		data.SpecialCostSurpriseCard = 0
-		data.SpecialCostStealCard = 0
-		data.SpecialCostFindCard = 0
-		data.SpecialCostWildCard = 0
-
-		data.CannotAffordSurpriseCard = false
-		data.CannotAffordStealCard = false
-		data.CannotAffordFindCard = false
-		data.CannotAffordWildCard = false
+	data.LobbySpecials, err = GetLobbySpecials(data.LobbyId)
+	if err != nil {
+		return data, err
 	}
 
 	return data, nil
@@ -998,9 +1872,42 @@ func GetLobbyGameBoardData(playerId uuid.UUID) (LobbyGameBoardData, error) {
 	sqlString := `
 		SELECT
 			L.ID AS LOBBY_ID,
+			CJLS.CAPTION_MODE AS LOBBY_CAPTION_MODE,
+			CJLS.CUSTOM_PROMPTS AS LOBBY_CUSTOM_PROMPTS,
+			CJLS.PAUSED_ON_DATE IS NOT NULL AS LOBBY_IS_PAUSED,
 			(SELECT TEXT FROM CARD WHERE ID = J.CARD_ID) AS JUDGE_CARD_TEXT,
 			(SELECT YOUTUBE FROM CARD WHERE ID = J.CARD_ID) AS JUDGE_CARD_YOUTUBE,
 			(SELECT IMAGE FROM CARD WHERE ID = J.CARD_ID) AS JUDGE_CARD_IMAGE,
+			(
+				SELECT
+					IF(C.LOBBY_ID IS NULL OR WC.ID IS NOT NULL, 0, 1)
+				FROM CARD AS C
+					LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+				WHERE C.ID = J.CARD_ID
+			) AS JUDGE_CARD_IS_CUSTOM,
+			CJLS.WRITE_PHASE_END IS NOT NULL AS LOBBY_IS_WRITING,
+			GREATEST(COALESCE(TIMESTAMPDIFF(SECOND, NOW(), CJLS.WRITE_PHASE_END), 0), 0) AS LOBBY_WRITE_SECONDS_LEFT,
+			CJLS.WRITE_PROMPT_COUNT AS LOBBY_WRITE_PROMPT_COUNT,
+			CJLS.WRITE_RESPONSE_COUNT AS LOBBY_WRITE_RESPONSE_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM WRITTEN_CARD AS WC
+					INNER JOIN CARD AS C ON C.ID = WC.CARD_ID
+				WHERE WC.LOBBY_ID = L.ID
+					AND WC.USER_ID = P.USER_ID
+					AND C.CATEGORY = 'PROMPT'
+			) AS PLAYER_WROTE_PROMPT_COUNT,
+			(
+				SELECT
+					COUNT(*)
+				FROM WRITTEN_CARD AS WC
+					INNER JOIN CARD AS C ON C.ID = WC.CARD_ID
+				WHERE WC.LOBBY_ID = L.ID
+					AND WC.USER_ID = P.USER_ID
+					AND C.CATEGORY = 'RESPONSE'
+			) AS PLAYER_WROTE_RESPONSE_COUNT,
+			FN_GET_PLAYER_LOBBY_ROLE(P.ID) AS PLAYER_ROLE,
 			J.BLANK_COUNT AS JUDGE_BLANK_COUNT,
 			J.RESPONSE_COUNT AS JUDGE_RESPONSE_COUNT,
 			(
@@ -1014,6 +1921,7 @@ func GetLobbyGameBoardData(playerId uuid.UUID) (LobbyGameBoardData, error) {
 		FROM PLAYER AS P
 			INNER JOIN LOBBY AS L ON L.ID = P.LOBBY_ID
 			INNER JOIN JUDGE AS J ON J.LOBBY_ID = L.ID
+			INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = L.ID
 		WHERE P.ID = ?
 	`
 	rows, err := query(sqlString, playerId)
@@ -1024,11 +1932,23 @@ func GetLobbyGameBoardData(playerId uuid.UUID) (LobbyGameBoardData, error) {
 
 	for rows.Next() {
 		var imageBytes []byte
+		var judgeCardIsCustom sql.NullBool
 		if err := rows.Scan(
 			&data.LobbyId,
+			&data.LobbyCaptionMode,
+			&data.LobbyCustomPrompts,
+			&data.LobbyIsPaused,
 			&data.JudgeCardText,
 			&data.JudgeCardYouTube,
 			&imageBytes,
+			&judgeCardIsCustom,
+			&data.LobbyIsWriting,
+			&data.LobbyWriteSecondsLeft,
+			&data.LobbyWritePromptCount,
+			&data.LobbyWriteResponseCount,
+			&data.PlayerWrotePromptCount,
+			&data.PlayerWroteResponseCount,
+			&data.PlayerRole,
 			&data.JudgeBlankCount,
 			&data.JudgeResponseCount,
 			&data.RoundTimer,
@@ -1043,20 +1963,96 @@ func GetLobbyGameBoardData(playerId uuid.UUID) (LobbyGameBoardData, error) {
 		if data.JudgeCardImage.Valid {
 			data.JudgeCardImage.String = base64.StdEncoding.EncodeToString(imageBytes)
 		}
+
+		data.JudgeCardIsCustom = judgeCardIsCustom.Valid && judgeCardIsCustom.Bool
+	}
+
+	if data.PlayerIsJudge && data.JudgeCardIsCustom {
+		sqlString = `
+			SELECT
+				D.ID,
+				D.NAME
+			FROM DECK AS D
+				INNER JOIN PLAYER AS P ON P.ID = ?
+			WHERE FN_GET_USER_DECK_ROLE(P.USER_ID, D.ID) = 'OWNER'
+			ORDER BY D.NAME
+		`
+		rows, err = query(sqlString, playerId)
+		if err != nil {
+			return data, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var deck deckOption
+			if err := rows.Scan(
+				&deck.Id,
+				&deck.Name,
+			); err != nil {
+				log.Println(err)
+				return data, errors.New("failed to scan row in query results")
+			}
+			data.JudgeSaveDecks = append(data.JudgeSaveDecks, deck)
+		}
+	}
+
+	sqlString = `
+		SELECT
+			C.ID,
+			C.TEXT,
+			C.YOUTUBE,
+			C.IMAGE
+		FROM PROMPT_CANDIDATE AS PC
+			INNER JOIN CARD AS C ON C.ID = PC.CARD_ID
+		WHERE PC.LOBBY_ID = ?
+		ORDER BY C.TEXT
+	`
+	rows, err = query(sqlString, data.LobbyId)
+	if err != nil {
+		return data, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var card Card
+		var imageBytes []byte
+		if err := rows.Scan(
+			&card.Id,
+			&card.Text,
+			&card.YouTube,
+			&imageBytes,
+		); err != nil {
+			log.Println(err)
+			return data, errors.New("failed to scan row in query results")
+		}
+
+		card.Image.Valid = imageBytes != nil
+		if card.Image.Valid {
+			card.Image.String = base64.StdEncoding.EncodeToString(imageBytes)
+		}
+
+		data.PromptCandidates = append(data.PromptCandidates, card)
 	}
 
+	data.JudgeIsChoosingPrompt = len(data.PromptCandidates) > 0
+
 	sqlString = `
 		SELECT
 			R.ID AS RESPONSE_ID,
 			R.IS_REVEALED AS IS_REVEALED,
 			R.IS_RULEDOUT AS IS_RULEDOUT,
+			R.IS_VETOED AS IS_VETOED,
 			P.ID AS PLAYER_ID,
-			U.NAME AS PLAYER_USER_NAME
+			U.NAME AS PLAYER_USER_NAME,
+			COALESCE(UC.COSMETIC, '') AS CARD_BACK
 		FROM LOBBY AS L
 			INNER JOIN PLAYER AS P ON P.LOBBY_ID = L.ID
 			INNER JOIN USER AS U ON U.ID = P.USER_ID
 			INNER JOIN RESPONSE AS R ON R.PLAYER_ID = P.ID
 			LEFT JOIN JUDGE AS J ON J.PLAYER_ID = P.ID
+			LEFT JOIN USER_COSMETIC AS UC ON UC.USER_ID = U.ID
+				AND UC.KIND = 'CARD-BACK'
+				AND UC.IS_EQUIPPED = 1
 		WHERE L.ID = ?
 			AND P.IS_ACTIVE = 1
 			AND J.ID IS NULL
@@ -1075,11 +2071,14 @@ func GetLobbyGameBoardData(playerId uuid.UUID) (LobbyGameBoardData, error) {
 			&br.ResponseId,
 			&br.IsRevealed,
 			&br.IsRuledOut,
+			&br.IsVetoed,
 			&br.PlayerId,
-			&br.PlayerUserName); err != nil {
+			&br.PlayerUserName,
+			&br.CardBack); err != nil {
 			log.Println(err)
 			return data, errors.New("failed to scan row in query results")
 		}
+		br.CardBack = cosmeticValue(br.CardBack)
 		data.BoardResponses = append(data.BoardResponses, br)
 	}
 
@@ -1164,7 +2163,9 @@ func GetLobbyGameBoardData(playerId uuid.UUID) (LobbyGameBoardData, error) {
 				'STEAL',
 				'SURPRISE',
 				'FIND',
-				'WILD'
+				'WILD',
+				'SWAP',
+				'VETO'
 			)
 		LIMIT 1
 	`
@@ -1267,14 +2268,19 @@ func GetLobbyGameStatsData(playerId uuid.UUID) (LobbyGameStatsData, error) {
 	sqlString = `
 		SELECT
 			U.NAME AS USER_NAME,
-			COUNT(W.ID) AS WINS
+			COUNT(W.ID) + MAX(CJPS.BONUS_POINTS) AS WINS,
+			COALESCE(MAX(UC.COSMETIC), '') AS NAME_COLOR
 		FROM PLAYER AS P
 			INNER JOIN USER AS U ON U.ID = P.USER_ID
+			INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
 			LEFT JOIN WIN AS W ON W.PLAYER_ID = P.ID
+			LEFT JOIN USER_COSMETIC AS UC ON UC.USER_ID = U.ID
+				AND UC.KIND = 'NAME-COLOR'
+				AND UC.IS_EQUIPPED = 1
 		WHERE P.LOBBY_ID = ?
 			AND P.IS_ACTIVE = 1
 		GROUP BY P.USER_ID
-		ORDER BY COUNT(W.ID) DESC,
+		ORDER BY WINS DESC,
 			U.NAME ASC
 	`
 	rows, err = query(sqlString, data.LobbyId)
@@ -1284,11 +2290,12 @@ func GetLobbyGameStatsData(playerId uuid.UUID) (LobbyGameStatsData, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		var row nameCountRow
-		if err := rows.Scan(&row.Name, &row.Count); err != nil {
+		var row winsRow
+		if err := rows.Scan(&row.Name, &row.Count, &row.NameColor); err != nil {
 			log.Println(err)
 			return data, errors.New("failed to scan row in query results")
 		}
+		row.NameColor = cosmeticValue(row.NameColor)
 		data.Wins = append(data.Wins, row)
 	}
 
@@ -1303,6 +2310,7 @@ func GetLobbyGameStatsData(playerId uuid.UUID) (LobbyGameStatsData, error) {
 				INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
 			WHERE P.LOBBY_ID = ?
 				AND P.IS_ACTIVE = 1
+				AND CJPS.IS_SPECTATOR = 0
 			ORDER BY CREDITS DESC,
 				U.NAME ASC
 		`
@@ -1326,10 +2334,12 @@ func GetLobbyGameStatsData(playerId uuid.UUID) (LobbyGameStatsData, error) {
 		SELECT
 			U.NAME
 		FROM PLAYER AS P
+			INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
 			INNER JOIN JUDGE AS J ON J.LOBBY_ID = P.LOBBY_ID
 			INNER JOIN USER AS U ON U.ID = P.USER_ID
 		WHERE P.LOBBY_ID = ?
 			AND P.IS_ACTIVE = 1
+			AND CJPS.IS_SPECTATOR = 0
 			AND P.ID <> J.PLAYER_ID
 		ORDER BY P.JOIN_ORDER <= J.POSITION,
 			P.JOIN_ORDER
@@ -1363,7 +2373,21 @@ func GetLobbyGameStatsData(playerId uuid.UUID) (LobbyGameStatsData, error) {
 				),
 				1,
 				0
-			) AS VOTED
+			) AS VOTED,
+			(
+				SELECT
+					COUNT(*)
+				FROM KICK AS K
+					INNER JOIN PLAYER AS VP ON VP.ID = K.VOTER_PLAYER_ID
+					INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = VP.LOBBY_ID
+				WHERE K.SUBJECT_PLAYER_ID = P.ID
+					AND VP.IS_ACTIVE = 1
+					AND (
+						CJLS.KICK_VOTE_MINUTES = 0
+						OR K.CREATED_ON_DATE >= DATE_SUB(NOW(), INTERVAL CJLS.KICK_VOTE_MINUTES MINUTE)
+					)
+			) AS VOTE_COUNT,
+			FN_GET_KICK_THRESHOLD(P.ID) AS THRESHOLD
 		FROM PLAYER AS P
 			INNER JOIN USER AS U ON U.ID = P.USER_ID
 		WHERE P.IS_ACTIVE = 1
@@ -1382,7 +2406,9 @@ func GetLobbyGameStatsData(playerId uuid.UUID) (LobbyGameStatsData, error) {
 		if err := rows.Scan(
 			&row.PlayerId,
 			&row.UserName,
-			&row.Voted); err != nil {
+			&row.Voted,
+			&row.VoteCount,
+			&row.Threshold); err != nil {
 			log.Println(err)
 			return data, errors.New("failed to scan row in query results")
 		}
@@ -1465,6 +2491,16 @@ func BetOnWinUndo(playerId uuid.UUID) error {
 	return execute(sqlString, playerId)
 }
 
+func SideBet(playerId uuid.UUID, responseId uuid.UUID, credits int) error {
+	sqlString := "CALL SP_SIDE_BET (?, ?, ?)"
+	return execute(sqlString, playerId, responseId, credits)
+}
+
+func SideBetUndo(playerId uuid.UUID) error {
+	sqlString := "CALL SP_SIDE_BET_UNDO (?)"
+	return execute(sqlString, playerId)
+}
+
 func AddExtraResponse(playerId uuid.UUID) error {
 	sqlString := "CALL SP_ADD_EXTRA_RESPONSE (?)"
 	return execute(sqlString, playerId)
@@ -1480,6 +2516,35 @@ func BlockResponse(playerId uuid.UUID, targetPlayerId uuid.UUID) error {
 	return execute(sqlString, playerId, targetPlayerId)
 }
 
+func SwapCard(playerId uuid.UUID, targetPlayerId uuid.UUID) error {
+	sqlString := "CALL SP_SWAP_CARD (?, ?)"
+	return execute(sqlString, playerId, targetPlayerId)
+}
+
+func VetoResponse(playerId uuid.UUID, responseId uuid.UUID) (bool, error) {
+	var isVetoed bool
+	sqlString := "CALL SP_VETO_RESPONSE (?, ?)"
+	rows, err := query(sqlString, playerId, responseId)
+	if err != nil {
+		return isVetoed, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isVetoed); err != nil {
+			log.Println(err)
+			return isVetoed, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isVetoed, nil
+}
+
+func Mulligan(playerId uuid.UUID) error {
+	sqlString := "CALL SP_MULLIGAN (?)"
+	return execute(sqlString, playerId)
+}
+
 func PlaySurpriseCard(playerId uuid.UUID) error {
 	sqlString := "CALL SP_RESPOND_WITH_SURPRISE_CARD (?)"
 	return execute(sqlString, playerId)
@@ -1500,6 +2565,11 @@ func PlayWildCard(playerId uuid.UUID, text string) error {
 	return execute(sqlString, playerId, text)
 }
 
+func PlayFreeText(playerId uuid.UUID, text string) error {
+	sqlString := "CALL SP_RESPOND_WITH_FREE_TEXT (?, ?)"
+	return execute(sqlString, playerId, text)
+}
+
 func PerkHandSizeAdvantage(playerId uuid.UUID) error {
 	sqlString := "CALL SP_PERK_HAND_SIZE_ADVANTAGE (?)"
 	return execute(sqlString, playerId)
@@ -1573,6 +2643,7 @@ func ToggleRuleOutResponse(responseId uuid.UUID) error {
 		UPDATE RESPONSE
 		SET IS_RULEDOUT = !IS_RULEDOUT
 		WHERE ID = ?
+			AND IS_VETOED = 0
 	`
 	return execute(sqlString, responseId)
 }
@@ -1615,6 +2686,187 @@ func PickRandomWinner(lobbyId uuid.UUID) (string, error) {
 	return playerName, nil
 }
 
+// IsJudgeChoosingPrompt reports whether the judge still has prompt candidates
+// to choose from. Until they choose there is no prompt to respond to.
+func IsJudgeChoosingPrompt(lobbyId uuid.UUID) (bool, error) {
+	isChoosing := false
+
+	sqlString := `
+		SELECT
+			EXISTS(
+				SELECT
+					ID
+				FROM PROMPT_CANDIDATE
+				WHERE LOBBY_ID = ?
+			)
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return isChoosing, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isChoosing); err != nil {
+			log.Println(err)
+			return isChoosing, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isChoosing, nil
+}
+
+func PickPromptCandidate(lobbyId uuid.UUID, cardId uuid.UUID) error {
+	sqlString := "CALL SP_PICK_PROMPT_CANDIDATE (?, ?)"
+	return execute(sqlString, lobbyId, cardId)
+}
+
+func SetCustomJudgeCard(lobbyId uuid.UUID, text string) error {
+	sqlString := "CALL SP_SET_CUSTOM_JUDGE_CARD (?, ?)"
+	return execute(sqlString, lobbyId, text)
+}
+
+// GetCustomJudgeCardText returns the text of the lobby's judge card when it was
+// written by the judge, or an empty string when it came from a deck.
+func GetCustomJudgeCardText(lobbyId uuid.UUID) (string, error) {
+	var text string
+
+	sqlString := `
+		SELECT
+			C.TEXT
+		FROM JUDGE AS J
+			INNER JOIN CARD AS C ON C.ID = J.CARD_ID
+			LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+		WHERE J.LOBBY_ID = ?
+			AND C.LOBBY_ID = J.LOBBY_ID
+			AND WC.ID IS NULL
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return text, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&text); err != nil {
+			log.Println(err)
+			return text, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return text, nil
+}
+
+func WriteCard(playerId uuid.UUID, category string, text string) error {
+	sqlString := "CALL SP_WRITE_CARD (?, ?, ?)"
+	return execute(sqlString, playerId, category, text)
+}
+
+func EndWritingPhase(lobbyId uuid.UUID) error {
+	sqlString := "CALL SP_END_WRITING_PHASE (?)"
+	return execute(sqlString, lobbyId)
+}
+
+// GetExpiredWritingPhaseLobbyIds lists running lobbies whose writing phase
+// deadline has passed. The deadline does not run while a lobby is paused.
+func GetExpiredWritingPhaseLobbyIds() ([]uuid.UUID, error) {
+	sqlString := `
+		SELECT
+			LOBBY_ID
+		FROM CJ_LOBBY_SETTINGS
+		WHERE WRITE_PHASE_END <= NOW()
+			AND PAUSED_ON_DATE IS NULL
+	`
+	rows, err := query(sqlString)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var lobbyId uuid.UUID
+		if err := rows.Scan(&lobbyId); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, lobbyId)
+	}
+	return result, nil
+}
+
+// IsWritingPhaseComplete reports whether every seated player has written all
+// of the prompts and responses the lobby asks for. Bots do not write cards.
+func IsWritingPhaseComplete(lobbyId uuid.UUID) (bool, error) {
+	isComplete := false
+
+	sqlString := `
+		SELECT
+			NOT EXISTS(
+				SELECT
+					P.ID
+				FROM PLAYER AS P
+					INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = P.LOBBY_ID
+					INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+				WHERE P.LOBBY_ID = ?
+					AND P.IS_ACTIVE = 1
+					AND CJPS.IS_SPECTATOR = 0
+					AND NOT EXISTS(SELECT ID FROM BOT WHERE PLAYER_ID = P.ID)
+					AND (
+						CJLS.WRITE_PROMPT_COUNT > (
+							SELECT
+								COUNT(*)
+							FROM WRITTEN_CARD AS WC
+								INNER JOIN CARD AS C ON C.ID = WC.CARD_ID
+							WHERE WC.LOBBY_ID = P.LOBBY_ID
+								AND WC.USER_ID = P.USER_ID
+								AND C.CATEGORY = 'PROMPT'
+						)
+						OR CJLS.WRITE_RESPONSE_COUNT > (
+							SELECT
+								COUNT(*)
+							FROM WRITTEN_CARD AS WC
+								INNER JOIN CARD AS C ON C.ID = WC.CARD_ID
+							WHERE WC.LOBBY_ID = P.LOBBY_ID
+								AND WC.USER_ID = P.USER_ID
+								AND C.CATEGORY = 'RESPONSE'
+						)
+					)
+			)
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return isComplete, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isComplete); err != nil {
+			log.Println(err)
+			return isComplete, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isComplete, nil
+}
+
+// CopyWrittenCardsToDeck saves every card written in the lobby into the deck.
+// Texts already in the deck are skipped.
+func CopyWrittenCardsToDeck(lobbyId uuid.UUID, deckId uuid.UUID) error {
+	sqlString := `
+		INSERT IGNORE INTO CARD(ID, DECK_ID, CATEGORY, TEXT)
+		SELECT
+			UUID(),
+			?,
+			C.CATEGORY,
+			C.TEXT
+		FROM WRITTEN_CARD AS WC
+			INNER JOIN CARD AS C ON C.ID = WC.CARD_ID
+		WHERE WC.LOBBY_ID = ?
+	`
+	return execute(sqlString, deckId, lobbyId)
+}
+
 func SkipPrompt(lobbyId uuid.UUID) error {
 	sqlString := "CALL SP_SKIP_PROMPT (?)"
 	return execute(sqlString, lobbyId)
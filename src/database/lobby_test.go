@@ -0,0 +1,96 @@
+package database
+
+import "testing"
+
+func TestSetDrawPileDeckPercents(t *testing.T) {
+	tests := []struct {
+		name             string
+		decks            []DrawPileDeck
+		isWeighted       bool
+		promptPercents   []int
+		responsePercents []int
+	}{
+		{
+			"by card count",
+			[]DrawPileDeck{
+				{Name: "A", Weight: 5, PromptCount: 30, ResponseCount: 10},
+				{Name: "B", Weight: 1, PromptCount: 10, ResponseCount: 30},
+			},
+			false,
+			[]int{75, 25},
+			[]int{25, 75},
+		},
+		{
+			"by weight",
+			[]DrawPileDeck{
+				{Name: "A", Weight: 3, PromptCount: 10, ResponseCount: 10},
+				{Name: "B", Weight: 1, PromptCount: 90, ResponseCount: 90},
+			},
+			true,
+			[]int{75, 25},
+			[]int{75, 25},
+		},
+		{
+			"unset weight counts as one",
+			[]DrawPileDeck{
+				{Name: "A", Weight: 0, PromptCount: 10, ResponseCount: 10},
+				{Name: "B", Weight: 1, PromptCount: 10, ResponseCount: 10},
+			},
+			true,
+			[]int{50, 50},
+			[]int{50, 50},
+		},
+		{
+			"weight needs cards",
+			[]DrawPileDeck{
+				{Name: "A", Weight: 3, PromptCount: 10, ResponseCount: 0},
+				{Name: "B", Weight: 1, PromptCount: 0, ResponseCount: 10},
+			},
+			true,
+			[]int{100, 0},
+			[]int{0, 100},
+		},
+		{
+			"big deck does not drown out a weighted small one",
+			[]DrawPileDeck{
+				{Name: "Big", Weight: 0, PromptCount: 1000, ResponseCount: 1000},
+				{Name: "Small", Weight: 2, PromptCount: 50, ResponseCount: 50},
+			},
+			true,
+			[]int{33, 66},
+			[]int{33, 66},
+		},
+		{
+			"three decks share",
+			[]DrawPileDeck{
+				{Name: "A", Weight: 1, PromptCount: 5, ResponseCount: 5},
+				{Name: "B", Weight: 1, PromptCount: 500, ResponseCount: 5},
+				{Name: "C", Weight: 2, PromptCount: 5, ResponseCount: 0},
+			},
+			true,
+			[]int{25, 25, 50},
+			[]int{50, 50, 0},
+		},
+		{
+			"empty draw pile",
+			[]DrawPileDeck{
+				{Name: "A", Weight: 1},
+			},
+			true,
+			[]int{0},
+			[]int{0},
+		},
+	}
+
+	for _, test := range tests {
+		setDrawPileDeckPercents(test.decks, test.isWeighted)
+		for i, deck := range test.decks {
+			if deck.PromptPercent != test.promptPercents[i] {
+				t.Errorf("%s: deck %s prompt percent got %d, want %d", test.name, deck.Name, deck.PromptPercent, test.promptPercents[i])
+			}
+			if deck.ResponsePercent != test.responsePercents[i] {
+				t.Errorf("%s: deck %s response percent got %d, want %d", test.name, deck.Name, deck.ResponsePercent, test.responsePercents[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// Reshuffle is a discard pile that was shuffled back into a lobby draw pile.
+type Reshuffle struct {
+	Category  string
+	CardCount int
+}
+
+// ClaimLobbyReshuffles returns the reshuffles the lobby has had that have not
+// been announced yet and marks them as announced. Reshuffles happen inside the
+// draw procedures, so call it after an action that draws cards. The claim is a
+// single update, so actions running at the same time never get the same one.
+func ClaimLobbyReshuffles(lobbyId uuid.UUID) ([]Reshuffle, error) {
+	announceId := uuid.New()
+
+	sqlString := `
+		UPDATE LOG_RESHUFFLE
+		SET ANNOUNCE_ID = ?
+		WHERE LOBBY_ID = ?
+			AND ANNOUNCE_ID IS NULL
+	`
+	err := execute(sqlString, announceId, lobbyId)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlString = `
+		SELECT
+			CATEGORY,
+			CARD_COUNT
+		FROM LOG_RESHUFFLE
+		WHERE LOBBY_ID = ?
+			AND ANNOUNCE_ID = ?
+		ORDER BY CREATED_ON_DATE, ID
+	`
+	rows, err := query(sqlString, lobbyId, announceId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]Reshuffle, 0)
+	for rows.Next() {
+		var reshuffle Reshuffle
+		if err := rows.Scan(
+			&reshuffle.Category,
+			&reshuffle.CardCount,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, reshuffle)
+	}
+	return result, nil
+}
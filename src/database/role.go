@@ -0,0 +1,160 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// LobbyRole is what a player is allowed to do in their lobby. There is one
+// owner per lobby, any number of co-hosts, and everyone else is a player.
+type LobbyRole string
+
+const (
+	LobbyRoleOwner  LobbyRole = "OWNER"
+	LobbyRoleCoHost LobbyRole = "CO-HOST"
+	LobbyRolePlayer LobbyRole = "PLAYER"
+)
+
+type LobbyPermission string
+
+const (
+	// LobbyPermissionEditDetails covers the lobby name and message.
+	LobbyPermissionEditDetails LobbyPermission = "EDIT-DETAILS"
+	// LobbyPermissionEditSettings covers game rules, decks and spectators.
+	LobbyPermissionEditSettings LobbyPermission = "EDIT-SETTINGS"
+	// LobbyPermissionRunGame covers starting, pausing, bots and card writing.
+	LobbyPermissionRunGame LobbyPermission = "RUN-GAME"
+	// LobbyPermissionManageRoles covers co-hosts and handing over the lobby.
+	LobbyPermissionManageRoles LobbyPermission = "MANAGE-ROLES"
+	// LobbyPermissionManageBans covers lifting bans handed out by kicks.
+	LobbyPermissionManageBans LobbyPermission = "MANAGE-BANS"
+	// LobbyPermissionManageInvites covers creating and revoking invite links.
+	LobbyPermissionManageInvites LobbyPermission = "MANAGE-INVITES"
+	// LobbyPermissionViewAccessFailures covers seeing wrong lobby passwords.
+	LobbyPermissionViewAccessFailures LobbyPermission = "VIEW-ACCESS-FAILURES"
+)
+
+var lobbyRolePermissions = map[LobbyRole][]LobbyPermission{
+	LobbyRoleOwner: {
+		LobbyPermissionEditDetails,
+		LobbyPermissionEditSettings,
+		LobbyPermissionRunGame,
+		LobbyPermissionManageRoles,
+		LobbyPermissionManageBans,
+		LobbyPermissionManageInvites,
+		LobbyPermissionViewAccessFailures,
+	},
+	LobbyRoleCoHost: {
+		LobbyPermissionEditSettings,
+		LobbyPermissionRunGame,
+		LobbyPermissionManageBans,
+	},
+}
+
+func (role LobbyRole) Can(permission LobbyPermission) bool {
+	for _, p := range lobbyRolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (role LobbyRole) Name() string {
+	switch role {
+	case LobbyRoleOwner:
+		return "Owner"
+	case LobbyRoleCoHost:
+		return "Co-Host"
+	default:
+		return "Player"
+	}
+}
+
+type LobbyMember struct {
+	PlayerId    uuid.UUID
+	Name        string
+	Role        LobbyRole
+	IsSpectator bool
+}
+
+func GetLobbyPlayerRole(playerId uuid.UUID) (LobbyRole, error) {
+	role := LobbyRolePlayer
+
+	sqlString := `
+		SELECT
+			FN_GET_PLAYER_LOBBY_ROLE(?)
+	`
+	rows, err := query(sqlString, playerId)
+	if err != nil {
+		return role, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&role); err != nil {
+			log.Println(err)
+			return role, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return role, nil
+}
+
+// GetLobbyMembers lists the connected people in the lobby with their role.
+// Bots are left out since they can never be given a role.
+func GetLobbyMembers(lobbyId uuid.UUID) ([]LobbyMember, error) {
+	sqlString := `
+		SELECT
+			P.ID,
+			U.NAME,
+			FN_GET_PLAYER_LOBBY_ROLE(P.ID) AS ROLE,
+			CJPS.IS_SPECTATOR
+		FROM PLAYER AS P
+			INNER JOIN USER AS U ON U.ID = P.USER_ID
+			INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+		WHERE P.LOBBY_ID = ?
+			AND P.IS_ACTIVE = 1
+			AND NOT EXISTS(SELECT ID FROM BOT WHERE PLAYER_ID = P.ID)
+		ORDER BY P.JOIN_ORDER
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]LobbyMember, 0)
+	for rows.Next() {
+		var player LobbyMember
+		if err := rows.Scan(
+			&player.PlayerId,
+			&player.Name,
+			&player.Role,
+			&player.IsSpectator,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		result = append(result, player)
+	}
+	return result, nil
+}
+
+func SetPlayerCoHost(playerId uuid.UUID, isCoHost bool) error {
+	sqlString := `
+		UPDATE CJ_PLAYER_STATE
+		SET IS_CO_HOST = ?
+		WHERE PLAYER_ID = ?
+	`
+	return execute(sqlString, isCoHost, playerId)
+}
+
+// TransferLobbyOwner hands the lobby to the player. The old owner is kept on
+// as a co-host.
+func TransferLobbyOwner(playerId uuid.UUID) error {
+	sqlString := "CALL SP_TRANSFER_LOBBY_OWNER (?)"
+	return execute(sqlString, playerId)
+}
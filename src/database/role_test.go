@@ -0,0 +1,75 @@
+package database
+
+import "testing"
+
+func TestLobbyRoleCan(t *testing.T) {
+	tests := []struct {
+		role       LobbyRole
+		permission LobbyPermission
+		want       bool
+	}{
+		{LobbyRoleOwner, LobbyPermissionEditDetails, true},
+		{LobbyRoleOwner, LobbyPermissionManageRoles, true},
+		{LobbyRoleOwner, LobbyPermissionViewAccessFailures, true},
+		{LobbyRoleCoHost, LobbyPermissionEditSettings, true},
+		{LobbyRoleCoHost, LobbyPermissionRunGame, true},
+		{LobbyRoleCoHost, LobbyPermissionManageBans, true},
+		{LobbyRoleCoHost, LobbyPermissionEditDetails, false},
+		{LobbyRoleCoHost, LobbyPermissionManageRoles, false},
+		{LobbyRoleCoHost, LobbyPermissionManageInvites, false},
+		{LobbyRolePlayer, LobbyPermissionRunGame, false},
+		{LobbyRole(""), LobbyPermissionEditSettings, false},
+	}
+
+	for _, test := range tests {
+		got := test.role.Can(test.permission)
+		if got != test.want {
+			t.Errorf("%s can %s: got %t, want %t", test.role, test.permission, got, test.want)
+		}
+	}
+}
+
+func TestLobbyRoleHierarchy(t *testing.T) {
+	permissions := []LobbyPermission{
+		LobbyPermissionEditDetails,
+		LobbyPermissionEditSettings,
+		LobbyPermissionRunGame,
+		LobbyPermissionManageRoles,
+		LobbyPermissionManageBans,
+		LobbyPermissionManageInvites,
+		LobbyPermissionViewAccessFailures,
+	}
+
+	for _, permission := range permissions {
+		if !LobbyRoleOwner.Can(permission) {
+			t.Errorf("owner cannot %s, the owner can do everything", permission)
+		}
+		if LobbyRolePlayer.Can(permission) {
+			t.Errorf("players can %s, only hosts should", permission)
+		}
+	}
+
+	// a co-host who could manage roles could make themselves the owner
+	if LobbyRoleCoHost.Can(LobbyPermissionManageRoles) {
+		t.Errorf("co-hosts can %s, only the owner should", LobbyPermissionManageRoles)
+	}
+}
+
+func TestLobbyRoleName(t *testing.T) {
+	tests := []struct {
+		role LobbyRole
+		want string
+	}{
+		{LobbyRoleOwner, "Owner"},
+		{LobbyRoleCoHost, "Co-Host"},
+		{LobbyRolePlayer, "Player"},
+		{LobbyRole(""), "Player"},
+	}
+
+	for _, test := range tests {
+		got := test.role.Name()
+		if got != test.want {
+			t.Errorf("%q: got %q, want %q", test.role, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// Special is the key of one special action or perk a lobby can turn on or
+// off. The specials themselves are registered in the game package.
+type Special string
+
+const (
+	SpecialAlert         Special = "ALERT"
+	SpecialGamble        Special = "GAMBLE"
+	SpecialBet           Special = "BET"
+	SpecialSkipJudge     Special = "SKIP-JUDGE"
+	SpecialExtraResponse Special = "EXTRA-RESPONSE"
+	SpecialBlockResponse Special = "BLOCK-RESPONSE"
+	SpecialSurprise      Special = "SURPRISE"
+	SpecialSteal         Special = "STEAL"
+	SpecialFind          Special = "FIND"
+	SpecialWild          Special = "WILD"
+	SpecialSwap          Special = "SWAP"
+	SpecialVeto          Special = "VETO"
+	SpecialMulligan      Special = "MULLIGAN"
+	SpecialSideBet       Special = "SIDE-BET"
+	SpecialPerkHandSize  Special = "PERK-HAND-SIZE"
+	SpecialPerkDiscard   Special = "PERK-DISCARD"
+	SpecialPerkHandicap  Special = "PERK-HANDICAP"
+	SpecialPerkSpy       Special = "PERK-SPY"
+)
+
+// specials lists every special so a lobby can store only the ones it has
+// turned off.
+var specials = []Special{
+	SpecialAlert,
+	SpecialGamble,
+	SpecialBet,
+	SpecialSkipJudge,
+	SpecialExtraResponse,
+	SpecialBlockResponse,
+	SpecialSurprise,
+	SpecialSteal,
+	SpecialFind,
+	SpecialWild,
+	SpecialSwap,
+	SpecialVeto,
+	SpecialMulligan,
+	SpecialSideBet,
+	SpecialPerkHandSize,
+	SpecialPerkDiscard,
+	SpecialPerkHandicap,
+	SpecialPerkSpy,
+}
+
+// SpecialSet is the set of specials that are turned on in a lobby.
+type SpecialSet map[Special]bool
+
+func (set SpecialSet) Allows(special Special) bool {
+	return set[special]
+}
+
+// AllSpecials returns every special turned on, the default for a new lobby.
+func AllSpecials() SpecialSet {
+	result := make(SpecialSet, len(specials))
+	for _, special := range specials {
+		result[special] = true
+	}
+	return result
+}
+
+// GetLobbySpecials returns the set of specials turned on in the lobby. Lobbies
+// only store the specials they have turned off, so everything else is on.
+func GetLobbySpecials(lobbyId uuid.UUID) (SpecialSet, error) {
+	result := AllSpecials()
+
+	sqlString := `
+		SELECT
+			SPECIAL
+		FROM CJ_LOBBY_DISABLED_SPECIAL
+		WHERE LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var special Special
+		if err := rows.Scan(&special); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+		delete(result, special)
+	}
+
+	return result, nil
+}
+
+// SetLobbySpecials turns on the given specials and turns off all others.
+func SetLobbySpecials(lobbyId uuid.UUID, enabled SpecialSet) error {
+	sqlString := `
+		DELETE FROM CJ_LOBBY_DISABLED_SPECIAL
+		WHERE LOBBY_ID = ?
+	`
+	if err := execute(sqlString, lobbyId); err != nil {
+		return err
+	}
+
+	sqlString = `
+		INSERT INTO CJ_LOBBY_DISABLED_SPECIAL (LOBBY_ID, SPECIAL)
+		VALUES (?, ?)
+	`
+	for _, special := range specials {
+		if enabled.Allows(special) {
+			continue
+		}
+		if err := execute(sqlString, lobbyId, special); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LobbyAllowsSpecial reports whether the special is turned on in the lobby.
+func LobbyAllowsSpecial(lobbyId uuid.UUID, special Special) (bool, error) {
+	sqlString := `
+		SELECT
+			COUNT(*)
+		FROM CJ_LOBBY_DISABLED_SPECIAL
+		WHERE LOBBY_ID = ?
+			AND SPECIAL = ?
+	`
+	rows, err := query(sqlString, lobbyId, special)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var count int
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			log.Println(err)
+			return false, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return count == 0, nil
+}
@@ -0,0 +1,29 @@
+package database
+
+import "testing"
+
+func TestSpecialSetAllows(t *testing.T) {
+	disabled := AllSpecials()
+	delete(disabled, SpecialSteal)
+
+	tests := []struct {
+		name    string
+		set     SpecialSet
+		special Special
+		want    bool
+	}{
+		{"all specials", AllSpecials(), SpecialSteal, true},
+		{"all specials perk", AllSpecials(), SpecialPerkSpy, true},
+		{"turned off", disabled, SpecialSteal, false},
+		{"others still on", disabled, SpecialSwap, true},
+		{"empty set", SpecialSet{}, SpecialAlert, false},
+		{"unknown special", AllSpecials(), Special("UNKNOWN"), false},
+	}
+
+	for _, test := range tests {
+		got := test.set.Allows(test.special)
+		if got != test.want {
+			t.Errorf("%s: got %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -533,6 +533,43 @@ func GetStatsLeaderboard(userId uuid.UUID, timeframe string, topic string, subje
 		default:
 			return resultHeaders, resultRows, errors.New("invalid subject provided")
 		}
+	case "prompt-card-pick":
+		switch subject {
+		case "player":
+			resultHeaders = append(resultHeaders, "Prompts Picked")
+			resultHeaders = append(resultHeaders, "Player")
+			sqlString = fmt.Sprintf(`
+				SELECT
+					COUNT(DISTINCT LPP.ID) AS COUNT,
+					U.NAME AS NAME
+				FROM LOG_PROMPT_PICK AS LPP
+					INNER JOIN USER AS U ON U.ID = LPP.USER_ID
+				WHERE LPP.CREATED_ON_DATE >= %s
+				GROUP BY U.ID
+				ORDER BY COUNT DESC,
+					NAME ASC
+				LIMIT 10
+			`, timeframeDateString)
+		case "card":
+			resultHeaders = append(resultHeaders, "Times Picked")
+			resultHeaders = append(resultHeaders, "Card")
+			params = append(params, userId)
+			sqlString = fmt.Sprintf(`
+				SELECT
+					COUNT(DISTINCT LPP.ID) AS COUNT,
+					COALESCE(C.TEXT, 'Unknown') AS NAME
+				FROM LOG_PROMPT_PICK AS LPP
+					LEFT JOIN CARD AS C ON C.ID = LPP.CARD_ID
+				WHERE LPP.CREATED_ON_DATE >= %s
+					AND FN_USER_HAS_DECK_ACCESS(?, C.DECK_ID)
+				GROUP BY C.ID
+				ORDER BY COUNT DESC,
+					NAME ASC
+				LIMIT 10
+			`, timeframeDateString)
+		default:
+			return resultHeaders, resultRows, errors.New("invalid subject provided")
+		}
 	case "picked-judge":
 		switch subject {
 		case "player":
@@ -893,6 +930,69 @@ func GetStatsLeaderboard(userId uuid.UUID, timeframe string, topic string, subje
 		default:
 			return resultHeaders, resultRows, errors.New("invalid subject provided")
 		}
+	case "swap":
+		switch subject {
+		case "player":
+			resultHeaders = append(resultHeaders, "Swaps")
+			resultHeaders = append(resultHeaders, "Player")
+			sqlString = fmt.Sprintf(`
+				SELECT
+					COUNT(*) AS COUNT,
+					U.NAME AS NAME
+				FROM LOG_CREDITS_SPENT AS LCS
+					INNER JOIN USER AS U ON U.ID = LCS.USER_ID
+				WHERE LCS.CREATED_ON_DATE >= %s
+					AND LCS.CATEGORY = 'SWAP'
+				GROUP BY U.ID
+				ORDER BY COUNT DESC,
+					NAME ASC
+				LIMIT 10
+			`, timeframeDateString)
+		default:
+			return resultHeaders, resultRows, errors.New("invalid subject provided")
+		}
+	case "veto":
+		switch subject {
+		case "player":
+			resultHeaders = append(resultHeaders, "Vetoes")
+			resultHeaders = append(resultHeaders, "Player")
+			sqlString = fmt.Sprintf(`
+				SELECT
+					COUNT(*) AS COUNT,
+					U.NAME AS NAME
+				FROM LOG_CREDITS_SPENT AS LCS
+					INNER JOIN USER AS U ON U.ID = LCS.USER_ID
+				WHERE LCS.CREATED_ON_DATE >= %s
+					AND LCS.CATEGORY = 'VETO'
+				GROUP BY U.ID
+				ORDER BY COUNT DESC,
+					NAME ASC
+				LIMIT 10
+			`, timeframeDateString)
+		default:
+			return resultHeaders, resultRows, errors.New("invalid subject provided")
+		}
+	case "mulligan":
+		switch subject {
+		case "player":
+			resultHeaders = append(resultHeaders, "Mulligans")
+			resultHeaders = append(resultHeaders, "Player")
+			sqlString = fmt.Sprintf(`
+				SELECT
+					COUNT(*) AS COUNT,
+					U.NAME AS NAME
+				FROM LOG_CREDITS_SPENT AS LCS
+					INNER JOIN USER AS U ON U.ID = LCS.USER_ID
+				WHERE LCS.CREATED_ON_DATE >= %s
+					AND LCS.CATEGORY = 'MULLIGAN'
+				GROUP BY U.ID
+				ORDER BY COUNT DESC,
+					NAME ASC
+				LIMIT 10
+			`, timeframeDateString)
+		default:
+			return resultHeaders, resultRows, errors.New("invalid subject provided")
+		}
 	case "kick":
 		switch subject {
 		case "player":
@@ -1136,6 +1236,18 @@ func GetAchievementsUser(userId uuid.UUID) ([]Achievement, error) {
 			'Perks Purchased',
 			(SELECT COUNT(*) FROM CREDITS_SPENT WHERE CATEGORY = 'PERK')
 		UNION
+		SELECT
+			'Cards Swapped',
+			(SELECT COUNT(*) FROM CREDITS_SPENT WHERE CATEGORY = 'SWAP')
+		UNION
+		SELECT
+			'Responses Vetoed',
+			(SELECT COUNT(*) FROM CREDITS_SPENT WHERE CATEGORY = 'VETO')
+		UNION
+		SELECT
+			'Mulligans Taken',
+			(SELECT COUNT(*) FROM CREDITS_SPENT WHERE CATEGORY = 'MULLIGAN')
+		UNION
 		SELECT
 			'Cards Discarded',
 			(SELECT COUNT(*) FROM LOG_DISCARD WHERE USER_ID = ?)
@@ -1151,8 +1263,12 @@ func GetAchievementsUser(userId uuid.UUID) ([]Achievement, error) {
 		SELECT
 			'Flipped Tables',
 			(SELECT COUNT(*) FROM LOG_FLIP_TABLE WHERE USER_ID = ?)
+		UNION
+		SELECT
+			'Streak Milestones',
+			(SELECT COUNT(*) FROM LOG_STREAK_MILESTONE WHERE USER_ID = ?)
 	`
-	rows, err := query(sqlString, userId, userId, userId, userId, userId, userId, userId)
+	rows, err := query(sqlString, userId, userId, userId, userId, userId, userId, userId, userId)
 	if err != nil {
 		return result, err
 	}
@@ -0,0 +1,225 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// StreakReward is what a streak rule gives a player when their streak reaches
+// the rule's length.
+type StreakReward string
+
+const (
+	StreakRewardCredits       StreakReward = "CREDITS"
+	StreakRewardHandCard      StreakReward = "HAND-CARD"
+	StreakRewardJudgeImmunity StreakReward = "JUDGE-IMMUNITY"
+	StreakRewardPerkHandSize  StreakReward = "PERK-HAND-SIZE"
+	StreakRewardPerkDiscard   StreakReward = "PERK-DISCARD"
+	StreakRewardPerkHandicap  StreakReward = "PERK-HANDICAP"
+	StreakRewardPerkSpy       StreakReward = "PERK-SPY"
+)
+
+// IsPerk is true for the free perks, which can only be given once.
+func (reward StreakReward) IsPerk() bool {
+	option, ok := getStreakRewardOption(reward)
+	return ok && !option.HasAmount
+}
+
+// StreakRewardOption is a reward a streak rule can give.
+type StreakRewardOption struct {
+	Reward StreakReward
+	Name   string
+	// HasAmount is false for the free perks, which are the same every time.
+	HasAmount bool
+}
+
+// streakRewards is the order the rewards are offered in the settings.
+var streakRewards = []StreakRewardOption{
+	{StreakRewardCredits, "Credits", true},
+	{StreakRewardHandCard, "Extra Hand Cards", true},
+	{StreakRewardJudgeImmunity, "Judge Immunity (Turns)", true},
+	{StreakRewardPerkHandSize, "Free Hand Size Perk", false},
+	{StreakRewardPerkDiscard, "Free Discard Perk", false},
+	{StreakRewardPerkHandicap, "Free Handicap Perk", false},
+	{StreakRewardPerkSpy, "Free Spy Perk", false},
+}
+
+// StreakRewards returns every reward a streak rule can give.
+func StreakRewards() []StreakRewardOption {
+	return streakRewards
+}
+
+func getStreakRewardOption(reward StreakReward) (StreakRewardOption, bool) {
+	for _, option := range streakRewards {
+		if option.Reward == reward {
+			return option, true
+		}
+	}
+	return StreakRewardOption{}, false
+}
+
+func IsStreakReward(reward StreakReward) bool {
+	_, ok := getStreakRewardOption(reward)
+	return ok
+}
+
+// StreakRule gives every player whose winning or losing streak reaches
+// Length the reward, on top of the credits from the lobby's streak
+// thresholds.
+type StreakRule struct {
+	Id         uuid.UUID
+	Streak     string
+	Length     int
+	Reward     StreakReward
+	RewardName string
+	Amount     int
+	HasAmount  bool
+}
+
+func GetLobbyStreakRules(lobbyId uuid.UUID) ([]StreakRule, error) {
+	sqlString := `
+		SELECT
+			ID,
+			STREAK,
+			LENGTH,
+			REWARD,
+			AMOUNT
+		FROM CJ_LOBBY_STREAK_RULE
+		WHERE LOBBY_ID = ?
+		ORDER BY STREAK,
+			LENGTH,
+			REWARD
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]StreakRule, 0)
+	for rows.Next() {
+		var rule StreakRule
+		if err := rows.Scan(
+			&rule.Id,
+			&rule.Streak,
+			&rule.Length,
+			&rule.Reward,
+			&rule.Amount,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+
+		option, _ := getStreakRewardOption(rule.Reward)
+		rule.RewardName = option.Name
+		rule.HasAmount = option.HasAmount
+
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// SetLobbyStreakRule adds the rule, or changes its amount if the lobby already
+// has the reward at that streak length.
+func SetLobbyStreakRule(lobbyId uuid.UUID, streak string, length int, reward StreakReward, amount int) error {
+	sqlString := `
+		INSERT INTO CJ_LOBBY_STREAK_RULE (LOBBY_ID, STREAK, LENGTH, REWARD, AMOUNT)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE AMOUNT = VALUES(AMOUNT)
+	`
+	return execute(sqlString, lobbyId, streak, length, reward, amount)
+}
+
+func RemoveLobbyStreakRule(lobbyId uuid.UUID, ruleId uuid.UUID) error {
+	sqlString := `
+		DELETE
+		FROM CJ_LOBBY_STREAK_RULE
+		WHERE ID = ?
+			AND LOBBY_ID = ?
+	`
+	return execute(sqlString, ruleId, lobbyId)
+}
+
+// StreakMilestone is a streak rule a player reached.
+type StreakMilestone struct {
+	UserName   string
+	Streak     string
+	Length     int
+	Reward     StreakReward
+	RewardName string
+	Amount     int
+	HasAmount  bool
+}
+
+func GetLobbyRoundId(lobbyId uuid.UUID) (uuid.UUID, error) {
+	var roundId uuid.UUID
+
+	sqlString := `
+		SELECT
+			ROUND_ID
+		FROM CJ_LOBBY_SETTINGS
+		WHERE LOBBY_ID = ?
+	`
+	rows, err := query(sqlString, lobbyId)
+	if err != nil {
+		return roundId, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&roundId); err != nil {
+			log.Println(err)
+			return roundId, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return roundId, nil
+}
+
+// GetStreakMilestones returns the streak milestones players in the lobby
+// reached in the round.
+func GetStreakMilestones(lobbyId uuid.UUID, roundId uuid.UUID) ([]StreakMilestone, error) {
+	sqlString := `
+		SELECT
+			U.NAME,
+			LSM.STREAK,
+			LSM.LENGTH,
+			LSM.REWARD,
+			LSM.AMOUNT
+		FROM LOG_STREAK_MILESTONE AS LSM
+			INNER JOIN USER AS U ON U.ID = LSM.USER_ID
+		WHERE LSM.LOBBY_ID = ?
+			AND LSM.ROUND_ID = ?
+		ORDER BY U.NAME,
+			LSM.CREATED_ON_DATE
+	`
+	rows, err := query(sqlString, lobbyId, roundId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]StreakMilestone, 0)
+	for rows.Next() {
+		var milestone StreakMilestone
+		if err := rows.Scan(
+			&milestone.UserName,
+			&milestone.Streak,
+			&milestone.Length,
+			&milestone.Reward,
+			&milestone.Amount,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+
+		option, _ := getStreakRewardOption(milestone.Reward)
+		milestone.RewardName = option.Name
+		milestone.HasAmount = option.HasAmount
+
+		result = append(result, milestone)
+	}
+	return result, nil
+}
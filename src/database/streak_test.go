@@ -0,0 +1,34 @@
+package database
+
+import "testing"
+
+func TestStreakRewardIsPerk(t *testing.T) {
+	tests := []struct {
+		reward    StreakReward
+		isReward  bool
+		isPerk    bool
+		hasAmount bool
+	}{
+		{StreakRewardCredits, true, false, true},
+		{StreakRewardHandCard, true, false, true},
+		{StreakRewardJudgeImmunity, true, false, true},
+		{StreakRewardPerkHandSize, true, true, false},
+		{StreakRewardPerkDiscard, true, true, false},
+		{StreakRewardPerkHandicap, true, true, false},
+		{StreakRewardPerkSpy, true, true, false},
+		{StreakReward("PERK-UNKNOWN"), false, false, false},
+	}
+
+	for _, test := range tests {
+		if got := IsStreakReward(test.reward); got != test.isReward {
+			t.Errorf("%s is a reward: got %t, want %t", test.reward, got, test.isReward)
+		}
+		if got := test.reward.IsPerk(); got != test.isPerk {
+			t.Errorf("%s is a perk: got %t, want %t", test.reward, got, test.isPerk)
+		}
+		option, _ := getStreakRewardOption(test.reward)
+		if option.HasAmount != test.hasAmount {
+			t.Errorf("%s has an amount: got %t, want %t", test.reward, option.HasAmount, test.hasAmount)
+		}
+	}
+}
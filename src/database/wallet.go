@@ -0,0 +1,155 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WalletLedgerEntry is one change to a user's wallet. Unlike lobby credits,
+// wallet coins last across games. They are earned from round wins, game wins
+// and achievement milestones, and spent on cosmetics.
+type WalletLedgerEntry struct {
+	CreatedOnDate time.Time
+	Amount        int
+	Category      string
+	Reference     string
+}
+
+type achievementMilestone struct {
+	Progress int
+	Reward   int
+}
+
+// achievementMilestones are the progress every achievement pays coins at.
+var achievementMilestones = []achievementMilestone{
+	{10, 5},
+	{100, 10},
+	{1000, 25},
+}
+
+func GetUserWalletBalance(userId uuid.UUID) (int, error) {
+	sqlString := `
+		SELECT
+			COALESCE(SUM(AMOUNT), 0)
+		FROM WALLET_LEDGER
+		WHERE USER_ID = ?
+	`
+	rows, err := query(sqlString, userId)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var balance int
+	for rows.Next() {
+		if err := rows.Scan(&balance); err != nil {
+			log.Println(err)
+			return 0, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return balance, nil
+}
+
+// GetUserWalletLedger returns the most recent changes to the user's wallet.
+func GetUserWalletLedger(userId uuid.UUID, limit int) ([]WalletLedgerEntry, error) {
+	var result []WalletLedgerEntry
+
+	sqlString := `
+		SELECT
+			CREATED_ON_DATE,
+			AMOUNT,
+			CATEGORY,
+			COALESCE(REFERENCE, '') AS REFERENCE
+		FROM WALLET_LEDGER
+		WHERE USER_ID = ?
+		ORDER BY CREATED_ON_DATE DESC
+		LIMIT ?
+	`
+	rows, err := query(sqlString, userId, limit)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry WalletLedgerEntry
+		if err := rows.Scan(
+			&entry.CreatedOnDate,
+			&entry.Amount,
+			&entry.Category,
+			&entry.Reference,
+		); err != nil {
+			log.Println(err)
+			return result, errors.New("failed to scan row in query results")
+		}
+
+		// round and game wins reference the lobby, which is gone by now
+		if entry.Category != "ACHIEVEMENT" {
+			if cosmetic, ok := GetCosmetic(entry.Reference); ok {
+				entry.Reference = cosmetic.Name
+			} else {
+				entry.Reference = ""
+			}
+		}
+
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// CollectAchievementRewards pays the user for every achievement milestone
+// they have reached and not been paid for yet, returning the coins earned.
+func CollectAchievementRewards(userId uuid.UUID) (int, error) {
+	achievements, err := GetAchievementsUser(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	earned := 0
+	for _, achievement := range achievements {
+		for _, milestone := range achievementMilestones {
+			if achievement.Progress < milestone.Progress {
+				break
+			}
+
+			reference := fmt.Sprintf("%s: %d", achievement.Name, milestone.Progress)
+			isPaid, err := payAchievementReward(userId, reference, milestone.Reward)
+			if err != nil {
+				return earned, err
+			}
+			if isPaid {
+				earned += milestone.Reward
+			}
+		}
+	}
+
+	return earned, nil
+}
+
+// payAchievementReward pays the reward for the milestone unless the user has
+// already been paid for it, returning whether it was paid.
+func payAchievementReward(userId uuid.UUID, reference string, reward int) (bool, error) {
+	var isPaid bool
+
+	sqlString := "CALL SP_PAY_ACHIEVEMENT_REWARD (?, ?, ?)"
+	rows, err := query(sqlString, userId, reference, reward)
+	if err != nil {
+		return isPaid, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&isPaid); err != nil {
+			log.Println(err)
+			return isPaid, errors.New("failed to scan row in query results")
+		}
+	}
+
+	return isPaid, nil
+}
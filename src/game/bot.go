@@ -0,0 +1,376 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	gsDatabase "github.com/gerp93/gameshell-framework/database"
+	"github.com/gerp93/gameshell-framework/websocket"
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+// MaxLobbyBots caps how many bots a host can seat in one lobby.
+const MaxLobbyBots = 8
+
+// botTickInterval is how often bots look for something to do. Bots take at
+// most one action per tick, so it also paces how quickly they fill responses.
+const botTickInterval = 3 * time.Second
+
+// botJudgeDelay is how long a bot judge waits after the board is ready before
+// picking, so players get a moment to read the responses.
+const botJudgeDelay = 6 * time.Second
+
+// BotStrategy is a bot personality. Bots play the highest scoring card in
+// their hand and, as judge, pick the response whose cards score highest.
+type BotStrategy interface {
+	Name() string
+	ScoreCard(card database.BotCard) float64
+}
+
+type BotPersonality struct {
+	Key  string
+	Name string
+}
+
+var botStrategies = map[string]BotStrategy{}
+
+// botDifficultyFocus is the chance a bot follows its strategy on a given
+// choice instead of choosing at random.
+var botDifficultyFocus = map[string]float64{
+	"EASY":   0.25,
+	"NORMAL": 0.6,
+	"HARD":   1,
+}
+
+// RegisterBotStrategy adds a personality hosts can give their bots.
+func RegisterBotStrategy(personality string, strategy BotStrategy) {
+	botStrategies[personality] = strategy
+}
+
+func BotPersonalities() []BotPersonality {
+	personalities := make([]BotPersonality, 0, len(botStrategies))
+	for key, strategy := range botStrategies {
+		personalities = append(personalities, BotPersonality{Key: key, Name: strategy.Name()})
+	}
+	sort.Slice(personalities, func(i, j int) bool {
+		return personalities[i].Name < personalities[j].Name
+	})
+	return personalities
+}
+
+type randomStrategy struct{}
+
+func (randomStrategy) Name() string { return "Random" }
+
+func (randomStrategy) ScoreCard(card database.BotCard) float64 {
+	return rand.Float64()
+}
+
+// leastPlayedStrategy favours cards the table has seen the least.
+type leastPlayedStrategy struct{}
+
+func (leastPlayedStrategy) Name() string { return "Least Played" }
+
+func (leastPlayedStrategy) ScoreCard(card database.BotCard) float64 {
+	return -float64(card.PlayCount) + rand.Float64()
+}
+
+// promptAffinityStrategy favours cards that have won with the current prompt
+// before, then cards that win in general.
+type promptAffinityStrategy struct{}
+
+func (promptAffinityStrategy) Name() string { return "Prompt Affinity" }
+
+func (promptAffinityStrategy) ScoreCard(card database.BotCard) float64 {
+	score := float64(card.PromptWinCount) * 10
+	if card.PlayCount > 0 {
+		score += float64(card.WinCount) / float64(card.PlayCount)
+	}
+	return score + rand.Float64()/10
+}
+
+func init() {
+	RegisterBotStrategy("RANDOM", randomStrategy{})
+	RegisterBotStrategy("LEAST-PLAYED", leastPlayedStrategy{})
+	RegisterBotStrategy("PROMPT-AFFINITY", promptAffinityStrategy{})
+}
+
+// AddBot creates a bot account and seats it in the lobby as a regular player.
+func AddBot(lobbyId uuid.UUID, personality string, difficulty string) (string, error) {
+	strategy, ok := botStrategies[personality]
+	if !ok {
+		return "", errors.New("unknown bot personality")
+	}
+
+	if _, ok := botDifficultyFocus[difficulty]; !ok {
+		return "", errors.New("unknown bot difficulty")
+	}
+
+	bots, err := database.GetLobbyBots(lobbyId)
+	if err != nil {
+		return "", err
+	}
+
+	if len(bots) >= MaxLobbyBots {
+		return "", fmt.Errorf("lobbies can have at most %d bots", MaxLobbyBots)
+	}
+
+	name := fmt.Sprintf("Bot %s %s", strategy.Name(), uuid.NewString()[:4])
+	for gsDatabase.UserNameExists(name) {
+		name = fmt.Sprintf("Bot %s %s", strategy.Name(), uuid.NewString()[:4])
+	}
+
+	// bot accounts are never approved, so nobody can log in as one
+	err = gsDatabase.CreateUser(name, uuid.NewString(), false)
+	if err != nil {
+		return "", err
+	}
+
+	userId, err := gsDatabase.GetUserIdByName(name)
+	if err != nil {
+		return "", err
+	}
+
+	playerId, err := gsDatabase.AddUserToLobby(lobbyId, userId)
+	if err != nil {
+		return "", err
+	}
+
+	err = database.CreateBot(lobbyId, playerId, userId, personality, difficulty)
+	if err != nil {
+		return "", err
+	}
+
+	BroadcastReshuffles(lobbyId)
+
+	return name, nil
+}
+
+// RemoveBot takes the bot out of play and returns its hand to the draw pile.
+// Its account is deleted with the lobby.
+func RemoveBot(lobbyId uuid.UUID, botId uuid.UUID) (string, error) {
+	bot, err := database.GetBot(botId)
+	if err != nil {
+		return "", err
+	}
+
+	if bot.Id == uuid.Nil || bot.LobbyId != lobbyId {
+		return "", errors.New("bot not found in lobby")
+	}
+
+	err = gsDatabase.SetPlayerInactive(lobbyId, bot.UserId)
+	if err != nil {
+		return "", err
+	}
+
+	err = database.ReturnHand(bot.PlayerId)
+	if err != nil {
+		return "", err
+	}
+
+	return bot.UserName, nil
+}
+
+// RunBots drives every seated bot until the process exits. Run it in its own
+// goroutine.
+func RunBots() {
+	boardReadySince := make(map[uuid.UUID]time.Time)
+
+	ticker := time.NewTicker(botTickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		bots, err := database.GetActiveBots()
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		for _, bot := range bots {
+			err = takeBotTurn(bot, boardReadySince)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+func takeBotTurn(bot database.Bot, boardReadySince map[uuid.UUID]time.Time) error {
+	isStarted, err := database.IsLobbyStarted(bot.LobbyId)
+	if err != nil {
+		return err
+	}
+
+	if !isStarted {
+		return nil
+	}
+
+	isPaused, err := database.IsLobbyPaused(bot.LobbyId)
+	if err != nil {
+		return err
+	}
+
+	if isPaused {
+		return nil
+	}
+
+	hand, err := database.GetPlayerHandData(bot.PlayerId)
+	if err != nil {
+		return err
+	}
+
+	if hand.LobbyIsWriting || hand.PlayerIsSpectator {
+		return nil
+	}
+
+	if hand.PlayerIsJudge {
+		return takeBotJudgeTurn(bot, hand.JudgeIsChoosingPrompt, boardReadySince)
+	}
+
+	if hand.PlayerIsReady || hand.JudgeIsChoosingPrompt {
+		return nil
+	}
+
+	cards, err := database.GetBotHandCards(bot.PlayerId)
+	if err != nil {
+		return err
+	}
+
+	if len(cards) == 0 {
+		return nil
+	}
+
+	strategy := botChoiceStrategy(bot)
+	best := cards[0]
+	bestScore := strategy.ScoreCard(best)
+	for _, card := range cards[1:] {
+		score := strategy.ScoreCard(card)
+		if score > bestScore {
+			best = card
+			bestScore = score
+		}
+	}
+
+	err = database.PlayCard(bot.PlayerId, best.Id)
+	if err != nil {
+		return err
+	}
+
+	websocket.LobbyBroadcast(bot.LobbyId, "refresh-player-specials")
+	websocket.LobbyBroadcast(bot.LobbyId, "refresh-lobby-game-board")
+	BroadcastReshuffles(bot.LobbyId)
+	return nil
+}
+
+func takeBotJudgeTurn(bot database.Bot, isChoosingPrompt bool, boardReadySince map[uuid.UUID]time.Time) error {
+	if isChoosingPrompt {
+		candidateIds, err := database.GetPromptCandidateIds(bot.LobbyId)
+		if err != nil {
+			return err
+		}
+
+		if len(candidateIds) == 0 {
+			return nil
+		}
+
+		err = database.PickPromptCandidate(bot.LobbyId, candidateIds[rand.Intn(len(candidateIds))])
+		if err != nil {
+			return err
+		}
+
+		websocket.LobbyBroadcast(bot.LobbyId, "refresh")
+		return nil
+	}
+
+	board, err := database.GetLobbyGameBoardData(bot.PlayerId)
+	if err != nil {
+		return err
+	}
+
+	if !board.BoardIsReady {
+		delete(boardReadySince, bot.LobbyId)
+		return nil
+	}
+
+	readySince, ok := boardReadySince[bot.LobbyId]
+	if !ok {
+		boardReadySince[bot.LobbyId] = time.Now()
+		return nil
+	}
+
+	if time.Since(readySince) < botJudgeDelay {
+		return nil
+	}
+
+	responses, err := database.GetBotBoardResponses(bot.LobbyId)
+	if err != nil {
+		return err
+	}
+
+	if len(responses) == 0 {
+		return nil
+	}
+
+	strategy := botChoiceStrategy(bot)
+	best := responses[0]
+	bestScore := scoreBotResponse(strategy, best)
+	for _, response := range responses[1:] {
+		score := scoreBotResponse(strategy, response)
+		if score > bestScore {
+			best = response
+			bestScore = score
+		}
+	}
+
+	delete(boardReadySince, bot.LobbyId)
+
+	cardTextStart, err := database.GetResponseCardTextStart(best.Id)
+	if err != nil {
+		return err
+	}
+
+	websocket.LobbyBroadcast(bot.LobbyId, "<blue>Winning Card</>: "+cardTextStart)
+
+	roundId, err := database.GetLobbyRoundId(bot.LobbyId)
+	if err != nil {
+		return err
+	}
+
+	winnerName, err := database.PickWinner(best.Id)
+	if err != nil {
+		return err
+	}
+
+	websocket.LobbyBroadcast(bot.LobbyId, "<blue>Winner</>: <green>"+winnerName+"</>")
+	BroadcastStreakMilestones(bot.LobbyId, roundId)
+	BroadcastReshuffles(bot.LobbyId)
+	websocket.LobbyBroadcast(bot.LobbyId, "refresh")
+	return nil
+}
+
+// botChoiceStrategy returns the strategy the bot uses for its next choice,
+// which on lower difficulties is sometimes just a random one.
+func botChoiceStrategy(bot database.Bot) BotStrategy {
+	strategy, ok := botStrategies[bot.Personality]
+	if !ok || rand.Float64() >= botDifficultyFocus[bot.Difficulty] {
+		return randomStrategy{}
+	}
+	return strategy
+}
+
+func scoreBotResponse(strategy BotStrategy, response database.BotResponse) float64 {
+	if len(response.Cards) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, card := range response.Cards {
+		total += strategy.ScoreCard(card)
+	}
+	return total / float64(len(response.Cards))
+}
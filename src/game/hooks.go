@@ -10,8 +10,10 @@ import (
 // layer's CALL SP_... convention.
 type CardJudge struct{}
 
+// OnRoomCreated only opens the waiting room. The game is set up when the host
+// starts it, see database.InitLobbyGame.
 func (CardJudge) OnRoomCreated(lobbyId uuid.UUID) error {
-	return database.InitLobbyGame(lobbyId)
+	return database.OpenLobbyGame(lobbyId)
 }
 
 func (CardJudge) OnPlayerJoined(playerId uuid.UUID) error {
@@ -0,0 +1,204 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	gsDatabase "github.com/gerp93/gameshell-framework/database"
+	"github.com/gerp93/gameshell-framework/websocket"
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+// idleTickInterval is how often idle players are looked for.
+const idleTickInterval = 5 * time.Second
+
+// idleWarningSeconds is how long the idle warning stays on screen.
+const idleWarningSeconds = 10
+
+// RunIdleChecks handles players who stop taking part, until the process
+// exits. Run it in its own goroutine.
+//
+// A player misses a round when it ends without them doing anything in it.
+// Once they have missed the lobby's idle rounds their cards are played for
+// them, one round later they are skipped as judge, and one round after that
+// they are removed from the lobby. They are warned the round before each step.
+//
+// Writing phases that ran out are also ended here.
+func RunIdleChecks() {
+	boardReadySince := make(map[uuid.UUID]time.Time)
+
+	ticker := time.NewTicker(idleTickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		endExpiredWritingPhases()
+
+		players, err := database.GetIdlePlayers()
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		for _, player := range players {
+			err = handleIdlePlayer(player, boardReadySince)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+func handleIdlePlayer(player database.IdlePlayer, boardReadySince map[uuid.UUID]time.Time) error {
+	if player.MissedRounds >= player.IdleRounds+2 {
+		err := gsDatabase.SetPlayerInactive(player.LobbyId, player.UserId)
+		if err != nil {
+			return err
+		}
+
+		websocket.LobbyBroadcast(player.LobbyId, "<red>Player Idle</>: <green>"+player.Name+"</> was removed from the lobby")
+		websocket.LobbyBroadcast(player.LobbyId, "refresh")
+		websocket.PlayerBroadcast(player.PlayerId, "exit")
+		return nil
+	}
+
+	if !player.IsWarned {
+		err := warnIdlePlayer(player)
+		if err != nil {
+			return err
+		}
+	}
+
+	if player.MissedRounds < player.IdleRounds {
+		return nil
+	}
+
+	hand, err := database.GetPlayerHandData(player.PlayerId)
+	if err != nil {
+		return err
+	}
+
+	if hand.LobbyIsWriting {
+		return nil
+	}
+
+	if hand.PlayerIsJudge {
+		if player.MissedRounds >= player.IdleRounds+1 {
+			err = database.SkipIdleJudge(player.LobbyId)
+			if err != nil {
+				return err
+			}
+
+			websocket.LobbyBroadcast(player.LobbyId, "<red>Player Idle</>: <green>"+player.Name+"</> was skipped as judge")
+			websocket.LobbyBroadcast(player.LobbyId, "refresh")
+			return nil
+		}
+
+		return takeIdleJudgeTurn(player, hand.JudgeIsChoosingPrompt, boardReadySince)
+	}
+
+	if hand.PlayerIsReady || hand.JudgeIsChoosingPrompt {
+		return nil
+	}
+
+	cardWasPlayed, err := database.PlayForceCard(player.PlayerId)
+	if err != nil {
+		return err
+	}
+
+	if cardWasPlayed {
+		websocket.PlayerBroadcast(player.PlayerId, "refresh-player-hand")
+		websocket.LobbyBroadcast(player.LobbyId, "refresh-player-specials")
+		websocket.LobbyBroadcast(player.LobbyId, "refresh-lobby-game-board")
+		BroadcastReshuffles(player.LobbyId)
+	}
+	return nil
+}
+
+// takeIdleJudgeTurn judges for an idle player the way a bot would, but picks
+// at random.
+func takeIdleJudgeTurn(player database.IdlePlayer, isChoosingPrompt bool, boardReadySince map[uuid.UUID]time.Time) error {
+	if isChoosingPrompt {
+		candidateIds, err := database.GetPromptCandidateIds(player.LobbyId)
+		if err != nil {
+			return err
+		}
+
+		if len(candidateIds) == 0 {
+			return nil
+		}
+
+		err = database.PickPromptCandidate(player.LobbyId, candidateIds[rand.Intn(len(candidateIds))])
+		if err != nil {
+			return err
+		}
+
+		websocket.LobbyBroadcast(player.LobbyId, "refresh")
+		return nil
+	}
+
+	board, err := database.GetLobbyGameBoardData(player.PlayerId)
+	if err != nil {
+		return err
+	}
+
+	if !board.BoardIsReady {
+		delete(boardReadySince, player.LobbyId)
+		return nil
+	}
+
+	readySince, ok := boardReadySince[player.LobbyId]
+	if !ok {
+		boardReadySince[player.LobbyId] = time.Now()
+		return nil
+	}
+
+	if time.Since(readySince) < botJudgeDelay {
+		return nil
+	}
+
+	delete(boardReadySince, player.LobbyId)
+
+	roundId, err := database.GetLobbyRoundId(player.LobbyId)
+	if err != nil {
+		return err
+	}
+
+	winnerName, err := database.PickRandomWinner(player.LobbyId)
+	if err != nil {
+		return err
+	}
+
+	if winnerName == "" {
+		return nil
+	}
+
+	websocket.LobbyBroadcast(player.LobbyId, "<green>"+player.Name+"</>: Random Winner!")
+	websocket.LobbyBroadcast(player.LobbyId, "<blue>Winner</>: <green>"+winnerName+"</>")
+	BroadcastStreakMilestones(player.LobbyId, roundId)
+	BroadcastReshuffles(player.LobbyId)
+	websocket.LobbyBroadcast(player.LobbyId, "refresh")
+	return nil
+}
+
+// warnIdlePlayer tells the player what happens if they miss this round too.
+func warnIdlePlayer(player database.IdlePlayer) error {
+	var text string
+	if player.MissedRounds >= player.IdleRounds+1 {
+		text = "Miss this round too and you will be removed from the lobby."
+	} else if player.MissedRounds >= player.IdleRounds {
+		text = "Your cards are being played for you. Miss this round too and you will be skipped as judge."
+	} else {
+		text = "Miss this round too and your cards will be played for you."
+	}
+
+	err := database.SetPlayerIdleWarned(player.PlayerId)
+	if err != nil {
+		return err
+	}
+
+	websocket.PlayerBroadcast(player.PlayerId, fmt.Sprintf("alert;;%d;;Are you still there?;;%s", idleWarningSeconds, text))
+	return nil
+}
@@ -0,0 +1,177 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+// perk holds what every perk shares: they all cost the lobby's perk price,
+// anyone in the game can buy them, and they last the rest of the game.
+type perk struct{}
+
+func (perk) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategoryPerk, false))
+}
+
+func (perk) Available(data database.PlayerSpecialsData) bool { return true }
+
+func (perk) canAfford(data database.PlayerSpecialsData) bool {
+	return data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategoryPerk, false)
+}
+
+type handSizePerk struct{ perk }
+
+func (handSizePerk) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialPerkHandSize,
+		Name:    "Hand Size Perk",
+		Path:    "perk/hand-size-advantage",
+		Confirm: "Are you sure you want to increase your hand size?",
+		Perk:    true,
+	}
+}
+
+func (handSizePerk) Description(data database.PlayerSpecialsData) string {
+	return "Hand size increases by 2"
+}
+
+func (p handSizePerk) Usable(data database.PlayerSpecialsData) bool {
+	return p.canAfford(data)
+}
+
+func (handSizePerk) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.PerkHandSizeAdvantage(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	playerState, err := database.GetPlayerGameState(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			playerEvent(use.Player.Id, "refresh-player-hand"),
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+			playerEvent(use.Player.Id, fmt.Sprintf("Perk: Your hand size is now increased by <green>%d</> more than the lobby default.", playerState.HandSizeAdvantage)),
+		},
+		Response: "success",
+	}, nil
+}
+
+type discardPerk struct{ perk }
+
+func (discardPerk) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialPerkDiscard,
+		Name:    "Discard Perk",
+		Path:    "perk/discard-advantage",
+		Confirm: "Are you sure you want to always allow discards?",
+		Perk:    true,
+		Note:    "when cannot play",
+	}
+}
+
+func (discardPerk) Description(data database.PlayerSpecialsData) string {
+	return "Discard more often"
+}
+
+func (p discardPerk) Usable(data database.PlayerSpecialsData) bool {
+	return p.canAfford(data) && !data.PlayerDiscardAdvantage
+}
+
+func (discardPerk) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.PerkDiscardAdvantage(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			playerEvent(use.Player.Id, "refresh-player-hand"),
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+			playerEvent(use.Player.Id, "Perk: You can now discard more often (whenever you cannot play a card)."),
+		},
+		Response: "success",
+	}, nil
+}
+
+type handicapPerk struct{ perk }
+
+func (handicapPerk) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialPerkHandicap,
+		Name:    "Handicap Perk",
+		Path:    "perk/handicap-advantage",
+		Confirm: "Are you sure you want to decrease your handicap?",
+		Perk:    true,
+		Note:    "cannot go negative",
+	}
+}
+
+func (handicapPerk) Description(data database.PlayerSpecialsData) string {
+	return "Handicap decreases by 1"
+}
+
+// Available hides the perk when the lobby has no handicap to decrease.
+func (handicapPerk) Available(data database.PlayerSpecialsData) bool {
+	return data.LobbyHandicapMode != "OFF"
+}
+
+func (p handicapPerk) Usable(data database.PlayerSpecialsData) bool {
+	return p.canAfford(data) && !data.PlayerHandicapAdvantage
+}
+
+func (handicapPerk) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.PerkHandicapAdvantage(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+			playerEvent(use.Player.Id, "Perk: Your handicap is now decreased by <green>1</> (cannot go negative)."),
+		},
+		Response: "success",
+	}, nil
+}
+
+type spyPerk struct{ perk }
+
+func (spyPerk) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialPerkSpy,
+		Name:    "Spy Perk",
+		Path:    "perk/spy-advantage",
+		Confirm: "Are you sure you want to spy on other player's credits?",
+		Perk:    true,
+	}
+}
+
+func (spyPerk) Description(data database.PlayerSpecialsData) string {
+	return "Spy on other player's credits"
+}
+
+func (p spyPerk) Usable(data database.PlayerSpecialsData) bool {
+	return p.canAfford(data) && !data.PlayerSpyAdvantage
+}
+
+func (spyPerk) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.PerkSpyAdvantage(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+			playerEvent(use.Player.Id, "refresh-lobby-game-stats"),
+			playerEvent(use.Player.Id, "Perk: You can now spy on other player's credits."),
+		},
+		Response: "success",
+	}, nil
+}
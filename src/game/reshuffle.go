@@ -0,0 +1,34 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gerp93/gameshell-framework/websocket"
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+// BroadcastReshuffles tells the lobby about every discard pile shuffled back
+// into its draw pile that it has not been told about yet. Call it after an
+// action that drew cards.
+func BroadcastReshuffles(lobbyId uuid.UUID) {
+	reshuffles, err := database.ClaimLobbyReshuffles(lobbyId)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, reshuffle := range reshuffles {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf(
+			"<blue>Reshuffle</>: The draw pile ran out of %s cards, <green>%d</> discarded cards were shuffled back in",
+			strings.ToLower(reshuffle.Category),
+			reshuffle.CardCount,
+		))
+	}
+
+	if len(reshuffles) > 0 {
+		websocket.LobbyBroadcast(lobbyId, "refresh-lobby-game-info")
+	}
+}
@@ -0,0 +1,216 @@
+package game
+
+import (
+	"net/url"
+
+	gsDatabase "github.com/gerp93/gameshell-framework/database"
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+// Special is an action players buy with credits during a round. Each
+// registered special gets its own API route, lobby allow-list entry and row
+// in the player specials panel, so adding a special means writing one type.
+type Special interface {
+	Info() SpecialInfo
+	// Cost is shown next to the special, e.g. "2" or "1+".
+	Cost(data database.PlayerSpecialsData) string
+	Description(data database.PlayerSpecialsData) string
+	// Available reports whether the special is shown to the player at all,
+	// e.g. only the judge can skip judging.
+	Available(data database.PlayerSpecialsData) bool
+	// Usable reports whether the player can use the special right now.
+	Usable(data database.PlayerSpecialsData) bool
+	Use(use SpecialUse) (SpecialResult, error)
+}
+
+// UndoableSpecial is a special that can be taken back before the round is
+// decided. Its undo route is the special's path with "/undo" on the end.
+type UndoableSpecial interface {
+	Special
+	CanUndo(data database.PlayerSpecialsData) bool
+	Undo(use SpecialUse) (SpecialResult, error)
+}
+
+// StatusSpecial is a special that shows a status in place of its button once
+// it has been used, e.g. the bet that was placed.
+type StatusSpecial interface {
+	Special
+	Status(data database.PlayerSpecialsData) string
+}
+
+type SpecialInfo struct {
+	Key  database.Special
+	Name string
+	// Path is the route under /api/lobby/{lobbyId}/ the special is used at.
+	Path string
+	// Dialog is the id of the dialog that collects the special's form. Leave
+	// it empty to use the special straight from its button.
+	Dialog string
+	// Confirm is asked before the special is used from its button.
+	Confirm string
+	// Perk specials last the rest of the game and are listed in the perks
+	// dialog instead of the specials table.
+	Perk bool
+	// Note is shown under a perk in the perks dialog.
+	Note string
+}
+
+// SpecialUse is one player using a special.
+type SpecialUse struct {
+	LobbyId uuid.UUID
+	Player  gsDatabase.Player
+	Form    url.Values
+}
+
+// SpecialEvent is a websocket message sent after a special is used.
+type SpecialEvent struct {
+	// PlayerId is who the message is sent to, or uuid.Nil for the whole lobby.
+	PlayerId uuid.UUID
+	Message  string
+}
+
+type SpecialResult struct {
+	Events []SpecialEvent
+	// Response is written back to the player who used the special.
+	Response string
+}
+
+// RejectedError is returned when a special cannot be used right now. Its
+// message is shown to the player.
+type RejectedError string
+
+func (err RejectedError) Error() string {
+	return string(err)
+}
+
+// SpecialView is a special as shown in the player specials panel.
+type SpecialView struct {
+	Name        string
+	Description string
+	Note        string
+	Cost        string
+	Path        string
+	Dialog      string
+	Confirm     string
+	Status      string
+	Usable      bool
+	CanUndo     bool
+}
+
+// SpecialsPanel is everything the player specials panel shows about the
+// registered specials.
+type SpecialsPanel struct {
+	Specials      []SpecialView
+	Perks         []SpecialView
+	PerkCost      int
+	CanAffordPerk bool
+}
+
+// SpecialSetting is a special as shown in the lobby settings.
+type SpecialSetting struct {
+	Special database.Special
+	Name    string
+	Enabled bool
+}
+
+var specials []Special
+
+// RegisterSpecial adds a special to the game. Specials are shown in the
+// order they are registered.
+func RegisterSpecial(special Special) {
+	specials = append(specials, special)
+}
+
+func Specials() []Special {
+	return specials
+}
+
+// GetSpecial returns the registered special with the key.
+func GetSpecial(key string) (Special, bool) {
+	for _, special := range specials {
+		if string(special.Info().Key) == key {
+			return special, true
+		}
+	}
+	return nil, false
+}
+
+// GetSpecialsPanel returns the specials the player can see, leaving out the
+// ones the lobby has turned off.
+func GetSpecialsPanel(data database.PlayerSpecialsData) SpecialsPanel {
+	panel := SpecialsPanel{
+		PerkCost: fixedCost(data, database.EconomyCategoryPerk, false),
+	}
+	panel.CanAffordPerk = data.PlayerCreditsRemaining >= panel.PerkCost
+
+	for _, special := range specials {
+		info := special.Info()
+		if !special.Available(data) {
+			continue
+		}
+
+		allowed := data.LobbySpecials.Allows(info.Key)
+		view := SpecialView{
+			Name:        info.Name,
+			Description: special.Description(data),
+			Note:        info.Note,
+			Cost:        special.Cost(data),
+			Path:        info.Path,
+			Dialog:      info.Dialog,
+			Confirm:     info.Confirm,
+			Usable:      allowed && special.Usable(data),
+		}
+		if undoable, ok := special.(UndoableSpecial); ok {
+			view.CanUndo = undoable.CanUndo(data)
+		}
+		if status, ok := special.(StatusSpecial); ok {
+			view.Status = status.Status(data)
+		}
+
+		// a special turned off mid-round stays visible while it can be undone
+		if !allowed && view.Status == "" && !view.CanUndo {
+			continue
+		}
+
+		if info.Perk {
+			panel.Perks = append(panel.Perks, view)
+		} else {
+			panel.Specials = append(panel.Specials, view)
+		}
+	}
+
+	return panel
+}
+
+// GetSpecialSettings returns every special with whether the lobby has it
+// turned on, in the order they are registered.
+func GetSpecialSettings(enabled database.SpecialSet) []SpecialSetting {
+	result := make([]SpecialSetting, 0, len(specials))
+	for _, special := range specials {
+		info := special.Info()
+		result = append(result, SpecialSetting{
+			Special: info.Key,
+			Name:    info.Name,
+			Enabled: enabled.Allows(info.Key),
+		})
+	}
+	return result
+}
+
+// fixedCost is the price of a special with the player's handicap added.
+// Special cards are free when the lobby gives them away.
+func fixedCost(data database.PlayerSpecialsData, category database.EconomyCategory, isCard bool) int {
+	if isCard && data.LobbyFreeSpecialCards {
+		return 0
+	}
+	return data.Economy[category] + data.PlayerHandicap
+}
+
+func lobbyEvent(message string) SpecialEvent {
+	return SpecialEvent{PlayerId: uuid.Nil, Message: message}
+}
+
+func playerEvent(playerId uuid.UUID, message string) SpecialEvent {
+	return SpecialEvent{PlayerId: playerId, Message: message}
+}
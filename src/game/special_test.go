@@ -0,0 +1,77 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+func TestSpecialsAreKnown(t *testing.T) {
+	known := database.AllSpecials()
+	for _, special := range Specials() {
+		if !known.Allows(special.Info().Key) {
+			t.Errorf("%s: key %q is not in database.AllSpecials", special.Info().Name, special.Info().Key)
+		}
+	}
+	if len(Specials()) != len(known) {
+		t.Errorf("got %d registered specials, want %d", len(Specials()), len(known))
+	}
+}
+
+func TestGetSpecialsPanel(t *testing.T) {
+	findView := func(views []SpecialView, name string) (SpecialView, bool) {
+		for _, view := range views {
+			if view.Name == name {
+				return view, true
+			}
+		}
+		return SpecialView{}, false
+	}
+
+	allowed := database.PlayerSpecialsData{
+		LobbySpecials:          database.AllSpecials(),
+		PlayerCreditsRemaining: 5,
+	}
+	panel := GetSpecialsPanel(allowed)
+	if view, ok := findView(panel.Specials, "Extra Response"); !ok || !view.Usable {
+		t.Errorf("allowed: got extra response shown %t usable %t, want shown and usable", ok, view.Usable)
+	}
+	if _, ok := findView(panel.Specials, "Bet On Win"); !ok {
+		t.Errorf("allowed: got bet hidden, want it shown")
+	}
+	if _, ok := findView(panel.Specials, "Skip Being Judge"); ok {
+		t.Errorf("allowed: got skip judge shown to a player, want it only for the judge")
+	}
+	if _, ok := findView(panel.Perks, "Spy Perk"); !ok {
+		t.Errorf("allowed: got spy perk missing from the perks, want it there")
+	}
+	if _, ok := findView(panel.Specials, "Spy Perk"); ok {
+		t.Errorf("allowed: got spy perk in the specials, want it only in the perks")
+	}
+
+	turnedOff := allowed
+	turnedOff.LobbySpecials = database.AllSpecials()
+	delete(turnedOff.LobbySpecials, database.SpecialBet)
+	delete(turnedOff.LobbySpecials, database.SpecialPerkSpy)
+	panel = GetSpecialsPanel(turnedOff)
+	if _, ok := findView(panel.Specials, "Bet On Win"); ok {
+		t.Errorf("turned off: got bet shown, want it hidden")
+	}
+	if _, ok := findView(panel.Perks, "Spy Perk"); ok {
+		t.Errorf("turned off: got spy perk shown, want it hidden")
+	}
+	if _, ok := findView(panel.Specials, "Extra Response"); !ok {
+		t.Errorf("turned off: got extra response hidden, want the other specials shown")
+	}
+
+	betPlaced := turnedOff
+	betPlaced.PlayerBetOnWin = 2
+	panel = GetSpecialsPanel(betPlaced)
+	view, ok := findView(panel.Specials, "Bet On Win")
+	if !ok {
+		t.Fatalf("turned off mid-round: got bet hidden, want it shown while it can be undone")
+	}
+	if view.Usable || !view.CanUndo {
+		t.Errorf("turned off mid-round: got usable %t can undo %t, want only undo", view.Usable, view.CanUndo)
+	}
+}
@@ -0,0 +1,901 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+
+	gsDatabase "github.com/gerp93/gameshell-framework/database"
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+func init() {
+	RegisterSpecial(alertSpecial{})
+	RegisterSpecial(gambleSpecial{})
+	RegisterSpecial(betSpecial{})
+	RegisterSpecial(sideBetSpecial{})
+	RegisterSpecial(skipJudgeSpecial{})
+	RegisterSpecial(extraResponseSpecial{})
+	RegisterSpecial(blockResponseSpecial{})
+	RegisterSpecial(surpriseCardSpecial{})
+	RegisterSpecial(stealCardSpecial{})
+	RegisterSpecial(findCardSpecial{})
+	RegisterSpecial(wildCardSpecial{})
+	RegisterSpecial(swapCardSpecial{})
+	RegisterSpecial(vetoSpecial{})
+	RegisterSpecial(mulliganSpecial{})
+	RegisterSpecial(handSizePerk{})
+	RegisterSpecial(discardPerk{})
+	RegisterSpecial(handicapPerk{})
+	RegisterSpecial(spyPerk{})
+}
+
+type alertSpecial struct{}
+
+func (alertSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialAlert,
+		Name:   "Alert Lobby",
+		Path:   "alert",
+		Dialog: "alert-lobby-dialog",
+	}
+}
+
+func (alertSpecial) Cost(data database.PlayerSpecialsData) string { return "1+" }
+
+func (alertSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Alert the lobby with any text you want."
+}
+
+func (alertSpecial) Available(data database.PlayerSpecialsData) bool { return true }
+
+func (alertSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return data.PlayerCreditsRemaining >= 1
+}
+
+func (alertSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	credits, err := creditsToSpend(use, "spend")
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	err = database.AlertLobby(use.Player.Id, credits)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+			lobbyEvent(fmt.Sprintf("alert;;%d;;%s;;%s", credits, use.Player.Name, use.Form.Get("text"))),
+		},
+	}, nil
+}
+
+type gambleSpecial struct{}
+
+func (gambleSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialGamble,
+		Name:   "Gamble Credits",
+		Path:   "gamble-credits",
+		Dialog: "gamble-credits-dialog",
+	}
+}
+
+func (gambleSpecial) Cost(data database.PlayerSpecialsData) string { return "1+" }
+
+func (gambleSpecial) Description(data database.PlayerSpecialsData) string {
+	return fmt.Sprintf(
+		"Gamble credits with a %d%% chance to get %d times them back.",
+		data.Economy[database.EconomyCategoryGambleChance],
+		data.Economy[database.EconomyCategoryGambleWin],
+	)
+}
+
+func (gambleSpecial) Available(data database.PlayerSpecialsData) bool { return true }
+
+func (gambleSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return data.PlayerCreditsRemaining >= 1
+}
+
+func (gambleSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	credits, err := creditsToSpend(use, "gamble")
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	gambleWon, err := database.GambleCredits(use.Player.Id, credits)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	message := "Sorry, you <red>lost</> your gamble..."
+	if gambleWon {
+		message = "Congratulations, you <green>won</> your gamble!"
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			playerEvent(use.Player.Id, message),
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+		},
+		Response: "success",
+	}, nil
+}
+
+type betSpecial struct{}
+
+func (betSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialBet,
+		Name:   "Bet On Win",
+		Path:   "bet-on-win",
+		Dialog: "bet-on-win-dialog",
+	}
+}
+
+func (betSpecial) Cost(data database.PlayerSpecialsData) string { return "1+" }
+
+func (betSpecial) Description(data database.PlayerSpecialsData) string {
+	return fmt.Sprintf(
+		"Bet credits you will win this round. Get %d times them back if correct.",
+		data.Economy[database.EconomyCategoryBetWin],
+	)
+}
+
+func (betSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (betSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.BoardHasAnyRevealed && len(data.Opponents) > 0 && data.PlayerCreditsRemaining >= 1
+}
+
+func (betSpecial) Status(data database.PlayerSpecialsData) string {
+	if data.PlayerBetOnWin == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Bet Placed: %d", data.PlayerBetOnWin)
+}
+
+func (betSpecial) CanUndo(data database.PlayerSpecialsData) bool {
+	return data.PlayerBetOnWin > 0 && !data.BoardHasAnyRevealed
+}
+
+func (betSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	playerState, err := database.GetPlayerGameState(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	if playerState.BetOnWin > 0 {
+		return SpecialResult{}, RejectedError(fmt.Sprintf("A bet of %d has already been placed.", playerState.BetOnWin))
+	}
+
+	credits, err := creditsToSpend(use, "bet")
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	err = database.BetOnWin(use.Player.Id, credits)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+		Response: "success",
+	}, nil
+}
+
+func (betSpecial) Undo(use SpecialUse) (SpecialResult, error) {
+	playerState, err := database.GetPlayerGameState(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	if playerState.BetOnWin == 0 {
+		return SpecialResult{}, RejectedError("No bet has been placed.")
+	}
+
+	err = database.BetOnWinUndo(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+		Response: "success",
+	}, nil
+}
+
+type sideBetSpecial struct{}
+
+func (sideBetSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialSideBet,
+		Name:   "Side Bet",
+		Path:   "side-bet",
+		Dialog: "side-bet-dialog",
+	}
+}
+
+func (sideBetSpecial) Cost(data database.PlayerSpecialsData) string { return "1+" }
+
+func (sideBetSpecial) Description(data database.PlayerSpecialsData) string {
+	return fmt.Sprintf(
+		"Once every response is revealed, bet credits on the one the judge will pick. Correct bets split the pool (%d credits so far) by stake.",
+		data.SideBetPool,
+	)
+}
+
+func (sideBetSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (sideBetSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return data.BoardIsAllRevealed && len(data.RevealedResponses) > 0 &&
+		data.PlayerSideBet == 0 && data.PlayerCreditsRemaining >= 1
+}
+
+func (sideBetSpecial) Status(data database.PlayerSpecialsData) string {
+	if data.PlayerSideBet == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Side Bet Placed: %d", data.PlayerSideBet)
+}
+
+func (sideBetSpecial) CanUndo(data database.PlayerSpecialsData) bool {
+	return data.PlayerSideBet > 0
+}
+
+func (sideBetSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	data, err := database.GetPlayerSpecialsData(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	if data.PlayerIsJudge {
+		return SpecialResult{}, RejectedError("The judge cannot place side bets.")
+	}
+
+	if data.PlayerSideBet > 0 {
+		return SpecialResult{}, RejectedError(fmt.Sprintf("A side bet of %d has already been placed.", data.PlayerSideBet))
+	}
+
+	if !data.BoardIsAllRevealed {
+		return SpecialResult{}, RejectedError("Side bets open once every response is revealed.")
+	}
+
+	responseId, err := uuid.Parse(use.Form.Get("responseId"))
+	if err != nil {
+		return SpecialResult{}, RejectedError("Failed to parse response id.")
+	}
+
+	canBet := false
+	for _, response := range data.RevealedResponses {
+		if response.ResponseId == responseId {
+			canBet = true
+			break
+		}
+	}
+	if !canBet {
+		return SpecialResult{}, RejectedError("You cannot bet on that response.")
+	}
+
+	credits, err := creditsToSpend(use, "bet")
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	err = database.SideBet(use.Player.Id, responseId, credits)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent(fmt.Sprintf("<green>%s</>: Placed a side bet of <green>%d</>.", use.Player.Name, credits)),
+			lobbyEvent("refresh-player-specials"),
+		},
+		Response: "success",
+	}, nil
+}
+
+func (sideBetSpecial) Undo(use SpecialUse) (SpecialResult, error) {
+	data, err := database.GetPlayerSpecialsData(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	if data.PlayerSideBet == 0 {
+		return SpecialResult{}, RejectedError("No side bet has been placed.")
+	}
+
+	err = database.SideBetUndo(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("refresh-player-specials"),
+		},
+		Response: "success",
+	}, nil
+}
+
+type skipJudgeSpecial struct{}
+
+func (skipJudgeSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialSkipJudge,
+		Name:    "Skip Being Judge",
+		Path:    "skip-judge",
+		Confirm: "Are you sure you want to skip being judge?",
+	}
+}
+
+func (skipJudgeSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategorySkipJudge, false))
+}
+
+func (skipJudgeSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Skip your turn as judge."
+}
+
+func (skipJudgeSpecial) Available(data database.PlayerSpecialsData) bool {
+	return data.PlayerIsJudge
+}
+
+func (skipJudgeSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.BoardHasAnySpecial && !data.BoardHasAnyRevealed &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategorySkipJudge, false)
+}
+
+func (skipJudgeSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.SkipJudge(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("<green>" + use.Player.Name + "</>: Skipped their turn as judge."),
+			lobbyEvent("refresh"),
+		},
+	}, nil
+}
+
+type extraResponseSpecial struct{}
+
+func (extraResponseSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialExtraResponse,
+		Name:    "Extra Response",
+		Path:    "add-extra-response",
+		Confirm: "Are you sure you want to use an extra response?",
+	}
+}
+
+func (extraResponseSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategoryExtraResponse, false))
+}
+
+func (extraResponseSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Get an extra response for the round."
+}
+
+func (extraResponseSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (extraResponseSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.BoardHasAnyRevealed &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategoryExtraResponse, false)
+}
+
+func (extraResponseSpecial) CanUndo(data database.PlayerSpecialsData) bool {
+	return data.PlayerExtraResponses > 0 && !data.BoardHasAnyRevealed
+}
+
+func (extraResponseSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.AddExtraResponse(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("<green>" + use.Player.Name + "</>: Purchased an extra response."),
+			playerEvent(use.Player.Id, "refresh-player-hand"),
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+func (extraResponseSpecial) Undo(use SpecialUse) (SpecialResult, error) {
+	err := database.AddExtraResponseUndo(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("<green>" + use.Player.Name + "</>: Undid purchase of an extra response."),
+			playerEvent(use.Player.Id, "refresh-player-hand"),
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+type blockResponseSpecial struct{}
+
+func (blockResponseSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialBlockResponse,
+		Name:   "Block Response",
+		Path:   "block-response",
+		Dialog: "block-response-dialog",
+	}
+}
+
+func (blockResponseSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategoryBlockResponse, false))
+}
+
+func (blockResponseSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Block a response of another player for the round."
+}
+
+func (blockResponseSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (blockResponseSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.BoardHasAnyRevealed && len(data.Opponents) > 0 &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategoryBlockResponse, false)
+}
+
+func (blockResponseSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	targetPlayer, err := getTargetPlayer(use)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	err = database.BlockResponse(use.Player.Id, targetPlayer.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("<green>" + use.Player.Name + "</>: Blocked <green>" + targetPlayer.Name + "</> from responding."),
+			playerEvent(targetPlayer.Id, "refresh-player-hand"),
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+type surpriseCardSpecial struct{}
+
+func (surpriseCardSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialSurprise,
+		Name:    "Surprise Card",
+		Path:    "card/surprise/play",
+		Confirm: "Are you sure you want to use a Surprise Card?",
+	}
+}
+
+func (surpriseCardSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategorySurprise, true))
+}
+
+func (surpriseCardSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Draw a random card from the draw pile.\nYou will not know which card you played."
+}
+
+func (surpriseCardSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (surpriseCardSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsReady && !data.JudgeIsChoosingPrompt &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategorySurprise, true)
+}
+
+func (surpriseCardSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.PlaySurpriseCard(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+type stealCardSpecial struct{}
+
+func (stealCardSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialSteal,
+		Name:    "Steal Card",
+		Path:    "card/steal/play",
+		Confirm: "Are you sure you want to use a Steal Card?",
+	}
+}
+
+func (stealCardSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategorySteal, true))
+}
+
+func (stealCardSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Steal a random card from another player in the lobby.\nThey will receive an extra credit (+inverse handicap) to spend as a result."
+}
+
+func (stealCardSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (stealCardSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsReady && !data.JudgeIsChoosingPrompt &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategorySteal, true)
+}
+
+func (stealCardSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.PlayStealCard(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("refresh-player-hand"),
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+type findCardSpecial struct{}
+
+func (findCardSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialFind,
+		Name:   "Find Card",
+		Path:   "card/find/play",
+		Dialog: "find-card-dialog",
+	}
+}
+
+func (findCardSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategoryFind, true))
+}
+
+func (findCardSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Find a card from the draw pile."
+}
+
+func (findCardSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (findCardSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsReady && !data.JudgeIsChoosingPrompt &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategoryFind, true)
+}
+
+func (findCardSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	cardId, err := uuid.Parse(use.Form.Get("cardId"))
+	if err != nil {
+		return SpecialResult{}, RejectedError("Failed to parse card id.")
+	}
+
+	err = database.PlayFindCard(use.Player.Id, cardId)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+type wildCardSpecial struct{}
+
+func (wildCardSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialWild,
+		Name:   "Wild Card",
+		Path:   "card/wild/play",
+		Dialog: "wild-card-dialog",
+	}
+}
+
+func (wildCardSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategoryWild, true))
+}
+
+func (wildCardSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Provide any text you want to play as a card."
+}
+
+func (wildCardSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (wildCardSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsReady && !data.JudgeIsChoosingPrompt &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategoryWild, true)
+}
+
+func (wildCardSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	text := use.Form.Get("text")
+
+	existingCardId, err := database.GetLobbyCardId(use.LobbyId, text)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	if existingCardId != uuid.Nil {
+		return SpecialResult{}, RejectedError("Wild card text has already been played.")
+	}
+
+	err = database.PlayWildCard(use.Player.Id, text)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+// creditsToSpend reads how many credits the player chose to put into a
+// special and checks they have that many left.
+func creditsToSpend(use SpecialUse, verb string) (int, error) {
+	var credits int
+	if value := use.Form.Get("credits"); value != "" {
+		var err error
+		credits, err = strconv.Atoi(value)
+		if err != nil {
+			return 0, RejectedError("Failed to parse credits.")
+		}
+	}
+
+	if credits < 1 {
+		return 0, RejectedError("No credits provided.")
+	}
+
+	lobby, err := database.GetLobby(use.LobbyId)
+	if err != nil {
+		return 0, err
+	}
+
+	playerState, err := database.GetPlayerGameState(use.Player.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	if lobby.FreeCredits-playerState.CreditsSpent < credits {
+		return 0, RejectedError(fmt.Sprintf("You do not have that many credits to %s.", verb))
+	}
+
+	return credits, nil
+}
+
+type swapCardSpecial struct{}
+
+func (swapCardSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialSwap,
+		Name:   "Swap Card",
+		Path:   "card/swap",
+		Dialog: "swap-card-dialog",
+	}
+}
+
+func (swapCardSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategorySwap, false))
+}
+
+func (swapCardSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Trade a random card from your hand with a random card from another player's hand."
+}
+
+func (swapCardSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (swapCardSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return len(data.Opponents) > 0 &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategorySwap, false)
+}
+
+func (swapCardSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	targetPlayer, err := getTargetPlayer(use)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	err = database.SwapCard(use.Player.Id, targetPlayer.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("<green>" + use.Player.Name + "</>: Swapped a card with <green>" + targetPlayer.Name + "</>."),
+			playerEvent(use.Player.Id, "refresh-player-hand"),
+			playerEvent(targetPlayer.Id, "refresh-player-hand"),
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+		},
+	}, nil
+}
+
+type vetoSpecial struct{}
+
+func (vetoSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:    database.SpecialVeto,
+		Name:   "Veto Response",
+		Path:   "veto-response",
+		Dialog: "veto-response-dialog",
+	}
+}
+
+func (vetoSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategoryVeto, false))
+}
+
+func (vetoSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Force the judge to rule out a revealed response of another player."
+}
+
+func (vetoSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (vetoSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return len(data.RevealedResponses) > 0 &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategoryVeto, false)
+}
+
+func (vetoSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	data, err := database.GetPlayerSpecialsData(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	responseId, err := uuid.Parse(use.Form.Get("responseId"))
+	if err != nil {
+		return SpecialResult{}, RejectedError("Failed to parse response id.")
+	}
+
+	var responseText string
+	canVeto := false
+	for _, response := range data.RevealedResponses {
+		if response.ResponseId == responseId {
+			responseText = response.Text
+			canVeto = true
+			break
+		}
+	}
+	if !canVeto {
+		return SpecialResult{}, RejectedError("You cannot veto that response.")
+	}
+
+	isVetoed, err := database.VetoResponse(use.Player.Id, responseId)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	if !isVetoed {
+		return SpecialResult{}, RejectedError("You cannot veto that response.")
+	}
+
+	// responses are anonymous, so the response is named by its text
+	return SpecialResult{
+		Events: []SpecialEvent{
+			lobbyEvent("<green>" + use.Player.Name + "</>: Vetoed the response <green>" + responseText + "</>."),
+			lobbyEvent("refresh-player-specials"),
+			lobbyEvent("refresh-lobby-game-board"),
+		},
+	}, nil
+}
+
+type mulliganSpecial struct{}
+
+func (mulliganSpecial) Info() SpecialInfo {
+	return SpecialInfo{
+		Key:     database.SpecialMulligan,
+		Name:    "Mulligan",
+		Path:    "mulligan",
+		Confirm: "Are you sure you want to discard your whole hand and draw a new one?",
+	}
+}
+
+func (mulliganSpecial) Cost(data database.PlayerSpecialsData) string {
+	return strconv.Itoa(fixedCost(data, database.EconomyCategoryMulligan, false))
+}
+
+func (mulliganSpecial) Description(data database.PlayerSpecialsData) string {
+	return "Discard your entire hand and draw a new one."
+}
+
+func (mulliganSpecial) Available(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsJudge
+}
+
+func (mulliganSpecial) Usable(data database.PlayerSpecialsData) bool {
+	return !data.PlayerIsReady &&
+		data.PlayerCreditsRemaining >= fixedCost(data, database.EconomyCategoryMulligan, false)
+}
+
+func (mulliganSpecial) Use(use SpecialUse) (SpecialResult, error) {
+	err := database.Mulligan(use.Player.Id)
+	if err != nil {
+		return SpecialResult{}, err
+	}
+
+	return SpecialResult{
+		Events: []SpecialEvent{
+			playerEvent(use.Player.Id, "refresh-player-hand"),
+			playerEvent(use.Player.Id, "refresh-player-specials"),
+		},
+		Response: "success",
+	}, nil
+}
+
+// getTargetPlayer loads the player picked in the form of a special aimed at
+// someone else, who has to be another seated player in the same lobby.
+func getTargetPlayer(use SpecialUse) (gsDatabase.Player, error) {
+	targetPlayerId, err := uuid.Parse(use.Form.Get("targetPlayerId"))
+	if err != nil {
+		return gsDatabase.Player{}, RejectedError("Failed to parse target player id.")
+	}
+
+	targetPlayer, err := gsDatabase.GetPlayer(targetPlayerId)
+	if err != nil {
+		return targetPlayer, err
+	}
+
+	if targetPlayer.Id == uuid.Nil ||
+		targetPlayer.LobbyId != use.LobbyId ||
+		targetPlayer.Id == use.Player.Id ||
+		!targetPlayer.IsActive {
+		return targetPlayer, RejectedError("You cannot target that player.")
+	}
+
+	isSpectator, err := database.IsPlayerSpectator(targetPlayer.Id)
+	if err != nil {
+		return targetPlayer, err
+	}
+
+	if isSpectator {
+		return targetPlayer, RejectedError("You cannot target a spectator.")
+	}
+
+	return targetPlayer, nil
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+func TestCardSpecialsWaitForPrompt(t *testing.T) {
+	cardSpecials := []Special{
+		surpriseCardSpecial{},
+		stealCardSpecial{},
+		findCardSpecial{},
+		wildCardSpecial{},
+	}
+
+	for _, special := range cardSpecials {
+		data := database.PlayerSpecialsData{PlayerCreditsRemaining: 5}
+		if !special.Usable(data) {
+			t.Errorf("%s: got not usable once the prompt is chosen, want usable", special.Info().Name)
+		}
+
+		data.JudgeIsChoosingPrompt = true
+		if special.Usable(data) {
+			t.Errorf("%s: got usable while the judge is choosing the prompt, want not usable", special.Info().Name)
+		}
+	}
+}
+
+func TestUndoableSpecialsCanUndo(t *testing.T) {
+	tests := []struct {
+		name    string
+		special UndoableSpecial
+		data    database.PlayerSpecialsData
+		want    bool
+	}{
+		{"bet placed", betSpecial{}, database.PlayerSpecialsData{PlayerBetOnWin: 2}, true},
+		{"bet revealed", betSpecial{}, database.PlayerSpecialsData{PlayerBetOnWin: 2, BoardHasAnyRevealed: true}, false},
+		{"no bet", betSpecial{}, database.PlayerSpecialsData{}, false},
+		{"side bet placed", sideBetSpecial{}, database.PlayerSpecialsData{PlayerSideBet: 1, BoardIsAllRevealed: true}, true},
+		{"no side bet", sideBetSpecial{}, database.PlayerSpecialsData{BoardIsAllRevealed: true}, false},
+		{"extra response bought", extraResponseSpecial{}, database.PlayerSpecialsData{PlayerExtraResponses: 1}, true},
+		{"extra response revealed", extraResponseSpecial{}, database.PlayerSpecialsData{PlayerExtraResponses: 1, BoardHasAnyRevealed: true}, false},
+		{"no extra response", extraResponseSpecial{}, database.PlayerSpecialsData{}, false},
+	}
+
+	for _, test := range tests {
+		got := test.special.CanUndo(test.data)
+		if got != test.want {
+			t.Errorf("%s: got %t, want %t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestInteractiveSpecialsUsable(t *testing.T) {
+	revealed := database.PlayerSpecialsData{
+		PlayerCreditsRemaining: 2,
+		Economy:                map[database.EconomyCategory]int{database.EconomyCategoryVeto: 2},
+	}
+	revealed.RevealedResponses = append(revealed.RevealedResponses, struct {
+		ResponseId uuid.UUID
+		Text       string
+	}{uuid.New(), "a response"})
+
+	broke := revealed
+	broke.PlayerCreditsRemaining = 1
+
+	ready := database.PlayerSpecialsData{PlayerIsReady: true}
+
+	tests := []struct {
+		name    string
+		special Special
+		data    database.PlayerSpecialsData
+		want    bool
+	}{
+		{"veto a revealed response", vetoSpecial{}, revealed, true},
+		{"veto nothing revealed", vetoSpecial{}, database.PlayerSpecialsData{}, false},
+		{"veto without credits", vetoSpecial{}, broke, false},
+		{"mulligan before playing", mulliganSpecial{}, database.PlayerSpecialsData{}, true},
+		{"mulligan after playing", mulliganSpecial{}, ready, false},
+		{"swap without opponents", swapCardSpecial{}, database.PlayerSpecialsData{}, false},
+	}
+
+	for _, test := range tests {
+		got := test.special.Usable(test.data)
+		if got != test.want {
+			t.Errorf("%s: got %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gerp93/gameshell-framework/websocket"
+	"github.com/google/uuid"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+// BroadcastStreakMilestones tells the lobby about every streak rule players
+// reached in the round. Call it with the round id from before the winner was
+// picked, as picking a winner starts a new round.
+func BroadcastStreakMilestones(lobbyId uuid.UUID, roundId uuid.UUID) {
+	milestones, err := database.GetStreakMilestones(lobbyId, roundId)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, milestone := range milestones {
+		websocket.LobbyBroadcast(lobbyId, fmt.Sprintf(
+			"<blue>Streak</>: <green>%s</> reached a %d round %s streak and %s",
+			milestone.UserName,
+			milestone.Length,
+			strings.ToLower(milestone.Streak),
+			streakRewardText(milestone),
+		))
+	}
+}
+
+// streakRewardText describes what the milestone gave, e.g. "earned 2 credits".
+func streakRewardText(milestone database.StreakMilestone) string {
+	if !milestone.HasAmount {
+		return "earned a " + milestone.RewardName
+	}
+
+	switch milestone.Reward {
+	case database.StreakRewardCredits:
+		if milestone.Amount < 0 {
+			return fmt.Sprintf("lost <red>%d</> credits", -milestone.Amount)
+		}
+		return fmt.Sprintf("earned <green>%d</> credits", milestone.Amount)
+	case database.StreakRewardHandCard:
+		return fmt.Sprintf("earned <green>%d</> extra hand cards", milestone.Amount)
+	case database.StreakRewardJudgeImmunity:
+		return fmt.Sprintf("earned immunity from judging for <green>%d</> turns", milestone.Amount)
+	}
+	return "earned " + milestone.RewardName
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+func TestStreakRewardText(t *testing.T) {
+	tests := []struct {
+		name      string
+		milestone database.StreakMilestone
+		want      string
+	}{
+		{
+			"credits",
+			database.StreakMilestone{Reward: database.StreakRewardCredits, Amount: 2, HasAmount: true},
+			"earned <green>2</> credits",
+		},
+		{
+			"lost credits",
+			database.StreakMilestone{Reward: database.StreakRewardCredits, Amount: -2, HasAmount: true},
+			"lost <red>2</> credits",
+		},
+		{
+			"hand cards",
+			database.StreakMilestone{Reward: database.StreakRewardHandCard, Amount: 3, HasAmount: true},
+			"earned <green>3</> extra hand cards",
+		},
+		{
+			"judge immunity",
+			database.StreakMilestone{Reward: database.StreakRewardJudgeImmunity, Amount: 1, HasAmount: true},
+			"earned immunity from judging for <green>1</> turns",
+		},
+		{
+			"perk",
+			database.StreakMilestone{Reward: database.StreakRewardPerkSpy, RewardName: "Free Spy Perk"},
+			"earned a Free Spy Perk",
+		},
+	}
+
+	for _, test := range tests {
+		got := streakRewardText(test.milestone)
+		if got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package game
+
+import (
+	"log"
+
+	"github.com/gerp93/gameshell-framework/websocket"
+	"github.com/grantfbarnes/card-judge/database"
+)
+
+// endExpiredWritingPhases starts the game in lobbies whose writing phase ran
+// out, so the deadline holds even when nobody has the lobby open.
+func endExpiredWritingPhases() {
+	lobbyIds, err := database.GetExpiredWritingPhaseLobbyIds()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, lobbyId := range lobbyIds {
+		err = database.EndWritingPhase(lobbyId)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		websocket.LobbyBroadcast(lobbyId, "<blue>Writing Phase</>: Time is up, let the game begin!")
+		BroadcastReshuffles(lobbyId)
+		websocket.LobbyBroadcast(lobbyId, "refresh")
+	}
+}
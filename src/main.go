@@ -8,7 +8,6 @@ import (
 
 	gameshell "github.com/gerp93/gameshell-framework"
 	"github.com/gerp93/gameshell-framework/api"
-	gsApiDeck "github.com/gerp93/gameshell-framework/api/deck"
 	gsApiUser "github.com/gerp93/gameshell-framework/api/user"
 	"github.com/gerp93/gameshell-framework/auth"
 	gsDatabase "github.com/gerp93/gameshell-framework/database"
@@ -20,6 +19,7 @@ import (
 	apiLobby "github.com/grantfbarnes/card-judge/api/lobby"
 	apiPages "github.com/grantfbarnes/card-judge/api/pages"
 	apiStats "github.com/grantfbarnes/card-judge/api/stats"
+	apiWallet "github.com/grantfbarnes/card-judge/api/wallet"
 	"github.com/grantfbarnes/card-judge/game"
 	"github.com/grantfbarnes/card-judge/static"
 )
@@ -37,7 +37,7 @@ func main() {
 	auth.SetCookiePrefix("CARD-JUDGE")
 	api.SetPagePolicy(api.PagePolicy{
 		LoginPaths:        []string{"/account", "/users", "/review", "/lobbies", "/decks"},
-		LoginPathPrefixes: []string{"/stats", "/lobby", "/deck"},
+		LoginPathPrefixes: []string{"/stats", "/lobby", "/deck", "/invite"},
 		AdminPaths:        []string{"/users", "/review"},
 	})
 
@@ -95,12 +95,14 @@ func main() {
 	http.Handle("GET /stats/card/{cardId}", api.MiddlewareForPages(http.HandlerFunc(apiPages.StatsCard)))
 	http.Handle("GET /users", api.MiddlewareForPages(http.HandlerFunc(apiPages.Users)))
 	http.Handle("GET /review", api.MiddlewareForPages(http.HandlerFunc(apiPages.Review)))
+	http.Handle("GET /gallery", api.MiddlewareForPages(http.HandlerFunc(apiPages.Gallery)))
 	http.Handle("GET /lobbies", api.MiddlewareForPages(http.HandlerFunc(apiPages.Lobbies)))
 	http.Handle("GET /lobby/{lobbyId}", api.MiddlewareForPages(http.HandlerFunc(apiPages.Lobby)))
 	http.Handle("GET /lobby/{lobbyId}/access", api.MiddlewareForPages(http.HandlerFunc(apiPages.LobbyAccess)))
 	http.Handle("GET /decks", api.MiddlewareForPages(http.HandlerFunc(apiPages.Decks)))
 	http.Handle("GET /deck/{deckId}", api.MiddlewareForPages(http.HandlerFunc(apiPages.Deck)))
 	http.Handle("GET /deck/{deckId}/access", api.MiddlewareForPages(http.HandlerFunc(apiPages.DeckAccess)))
+	http.Handle("GET /invite/{token}", api.MiddlewareForPages(http.HandlerFunc(apiPages.Invite)))
 
 	// user
 	http.Handle("POST /api/user/create", api.MiddlewareForAPIs(http.HandlerFunc(gsApiUser.Create)))
@@ -115,13 +117,27 @@ func main() {
 	http.Handle("PUT /api/user/{userId}/is-admin", api.MiddlewareForAPIs(http.HandlerFunc(gsApiUser.SetIsAdmin)))
 	http.Handle("DELETE /api/user/{userId}", api.MiddlewareForAPIs(http.HandlerFunc(gsApiUser.Delete)))
 
+	// wallet
+	http.Handle("POST /api/wallet/collect", api.MiddlewareForAPIs(http.HandlerFunc(apiWallet.CollectAchievementRewards)))
+	http.Handle("POST /api/wallet/cosmetic/{cosmetic}/unlock", api.MiddlewareForAPIs(http.HandlerFunc(apiWallet.UnlockCosmetic)))
+	http.Handle("POST /api/wallet/cosmetic/{cosmetic}/equip", api.MiddlewareForAPIs(http.HandlerFunc(apiWallet.EquipCosmetic)))
+	http.Handle("POST /api/wallet/cosmetic/{cosmetic}/unequip", api.MiddlewareForAPIs(http.HandlerFunc(apiWallet.UnequipCosmetic)))
+
 	// deck
 	http.Handle("GET /api/deck/{deckId}/card-export", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.GetCardExport)))
-	http.Handle("POST /api/deck/create", api.MiddlewareForAPIs(http.HandlerFunc(gsApiDeck.Create)))
-	http.Handle("PUT /api/deck/{deckId}/name", api.MiddlewareForAPIs(http.HandlerFunc(gsApiDeck.SetName)))
-	http.Handle("PUT /api/deck/{deckId}/password", api.MiddlewareForAPIs(http.HandlerFunc(gsApiDeck.SetPassword)))
-	http.Handle("PUT /api/deck/{deckId}/is-public-read-only", api.MiddlewareForAPIs(http.HandlerFunc(gsApiDeck.SetIsPublicReadOnly)))
-	http.Handle("DELETE /api/deck/{deckId}", api.MiddlewareForAPIs(http.HandlerFunc(gsApiDeck.Delete)))
+	http.Handle("POST /api/deck/create", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.Create)))
+	http.Handle("PUT /api/deck/{deckId}/name", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.SetName)))
+	http.Handle("PUT /api/deck/{deckId}/password", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.SetPassword)))
+	http.Handle("PUT /api/deck/{deckId}/is-public-read-only", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.SetIsPublicReadOnly)))
+	http.Handle("DELETE /api/deck/{deckId}", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.Delete)))
+	http.Handle("POST /api/deck/{deckId}/invite", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.CreateInvite)))
+	http.Handle("POST /api/deck/{deckId}/invite/{inviteId}/revoke", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.RevokeInvite)))
+	http.Handle("POST /api/deck/{deckId}/collaborator", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.AddCollaborator)))
+	http.Handle("PUT /api/deck/{deckId}/collaborator/{userId}/role", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.SetCollaboratorRole)))
+	http.Handle("POST /api/deck/{deckId}/collaborator/{userId}/owner", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.TransferOwnership)))
+	http.Handle("DELETE /api/deck/{deckId}/collaborator/{userId}", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.RemoveCollaborator)))
+	http.Handle("POST /api/deck/{deckId}/suggestion/{suggestionId}/approve", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.ApproveSuggestion)))
+	http.Handle("DELETE /api/deck/{deckId}/suggestion/{suggestionId}", api.MiddlewareForAPIs(http.HandlerFunc(apiDeck.RejectSuggestion)))
 
 	// card
 	http.Handle("POST /api/card/find", api.MiddlewareForAPIs(http.HandlerFunc(apiCard.Find)))
@@ -136,6 +152,7 @@ func main() {
 
 	// lobby
 	http.Handle("GET /api/lobby/{lobbyId}/html/game-interface", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.GetGameInterfaceHTML)))
+	http.Handle("GET /api/lobby/{lobbyId}/html/lobby-waiting-room", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.GetLobbyWaitingRoomHTML)))
 	http.Handle("GET /api/lobby/{lobbyId}/html/lobby-game-info", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.GetLobbyGameInfoHTML)))
 	http.Handle("GET /api/lobby/{lobbyId}/html/player-hand", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.GetPlayerHandHTML)))
 	http.Handle("GET /api/lobby/{lobbyId}/html/player-specials", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.GetPlayerSpecialsHTML)))
@@ -145,33 +162,45 @@ func main() {
 	http.Handle("POST /api/lobby/{lobbyId}/card/{cardId}/play", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PlayCard)))
 	http.Handle("POST /api/lobby/{lobbyId}/card/force/play", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PlayForceCard)))
 	http.Handle("POST /api/lobby/{lobbyId}/purchase-credits", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PurchaseCredits)))
-	http.Handle("POST /api/lobby/{lobbyId}/skip-judge", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SkipJudge)))
 	http.Handle("POST /api/lobby/{lobbyId}/reset-responses", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.ResetResponses)))
-	http.Handle("POST /api/lobby/{lobbyId}/alert", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.AlertLobby)))
-	http.Handle("POST /api/lobby/{lobbyId}/gamble-credits", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.GambleCredits)))
-	http.Handle("POST /api/lobby/{lobbyId}/bet-on-win", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.BetOnWin)))
-	http.Handle("POST /api/lobby/{lobbyId}/bet-on-win/undo", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.BetOnWinUndo)))
-	http.Handle("POST /api/lobby/{lobbyId}/add-extra-response", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.AddExtraResponse)))
-	http.Handle("POST /api/lobby/{lobbyId}/add-extra-response/undo", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.AddExtraResponseUndo)))
-	http.Handle("POST /api/lobby/{lobbyId}/block-response", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.BlockResponse)))
-	http.Handle("POST /api/lobby/{lobbyId}/card/surprise/play", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PlaySurpriseCard)))
-	http.Handle("POST /api/lobby/{lobbyId}/card/steal/play", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PlayStealCard)))
-	http.Handle("POST /api/lobby/{lobbyId}/card/find/play", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PlayFindCard)))
-	http.Handle("POST /api/lobby/{lobbyId}/card/wild/play", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PlayWildCard)))
-	http.Handle("POST /api/lobby/{lobbyId}/perk/hand-size-advantage", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PerkHandSizeAdvantage)))
-	http.Handle("POST /api/lobby/{lobbyId}/perk/discard-advantage", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PerkDiscardAdvantage)))
-	http.Handle("POST /api/lobby/{lobbyId}/perk/handicap-advantage", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PerkHandicapAdvantage)))
-	http.Handle("POST /api/lobby/{lobbyId}/perk/spy-advantage", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PerkSpyAdvantage)))
+	http.Handle("POST /api/lobby/{lobbyId}/card/free-text/play", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PlayFreeText)))
+	for _, special := range game.Specials() {
+		specialPath := "POST /api/lobby/{lobbyId}/" + special.Info().Path
+		http.Handle(specialPath, api.MiddlewareForAPIs(apiLobby.UseSpecial(special)))
+		if undoable, ok := special.(game.UndoableSpecial); ok {
+			http.Handle(specialPath+"/undo", api.MiddlewareForAPIs(apiLobby.UndoSpecial(undoable)))
+		}
+	}
 	http.Handle("POST /api/lobby/{lobbyId}/response-card/{responseCardId}/withdraw", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.WithdrawCard)))
 	http.Handle("POST /api/lobby/{lobbyId}/card/{cardId}/discard", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.DiscardCard)))
 	http.Handle("POST /api/lobby/{lobbyId}/player/{playerId}/kick", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.VoteToKick)))
 	http.Handle("POST /api/lobby/{lobbyId}/player/{playerId}/kick/undo", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.VoteToKickUndo)))
+	http.Handle("POST /api/lobby/{lobbyId}/ban/{banId}/remove", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.RemoveBan)))
+	http.Handle("POST /api/lobby/{lobbyId}/invite", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.CreateInvite)))
+	http.Handle("POST /api/lobby/{lobbyId}/invite/{inviteId}/revoke", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.RevokeInvite)))
 	http.Handle("POST /api/lobby/{lobbyId}/response/{responseId}/reveal", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.RevealResponse)))
 	http.Handle("POST /api/lobby/{lobbyId}/response/{responseId}/toggle-rule-out", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.ToggleRuleOutResponse)))
 	http.Handle("POST /api/lobby/{lobbyId}/response/{responseId}/pick-winner", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PickWinner)))
 	http.Handle("POST /api/lobby/{lobbyId}/pick-random-winner", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PickRandomWinner)))
 	http.Handle("POST /api/lobby/{lobbyId}/flip", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.FlipTable)))
 	http.Handle("POST /api/lobby/{lobbyId}/skip-prompt", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SkipPrompt)))
+	http.Handle("POST /api/lobby/{lobbyId}/prompt-candidate/{cardId}/pick", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PickPromptCandidate)))
+	http.Handle("POST /api/lobby/{lobbyId}/custom-prompt", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetCustomPrompt)))
+	http.Handle("POST /api/lobby/{lobbyId}/card/write", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.WriteCard)))
+	http.Handle("POST /api/lobby/{lobbyId}/writing-phase/end", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.EndWritingPhase)))
+	http.Handle("POST /api/lobby/{lobbyId}/written-cards/save", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SaveWrittenCards)))
+	http.Handle("POST /api/lobby/{lobbyId}/ready", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.ReadyToStart)))
+	http.Handle("POST /api/lobby/{lobbyId}/ready/undo", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.ReadyToStartUndo)))
+	http.Handle("POST /api/lobby/{lobbyId}/start", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.StartGame)))
+	http.Handle("POST /api/lobby/{lobbyId}/pause", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.PauseGame)))
+	http.Handle("POST /api/lobby/{lobbyId}/resume", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.ResumeGame)))
+	http.Handle("POST /api/lobby/{lobbyId}/spectate", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.Spectate)))
+	http.Handle("POST /api/lobby/{lobbyId}/join-game", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.JoinGame)))
+	http.Handle("POST /api/lobby/{lobbyId}/bot/add", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.AddBot)))
+	http.Handle("POST /api/lobby/{lobbyId}/bot/{botId}/remove", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.RemoveBot)))
+	http.Handle("POST /api/lobby/{lobbyId}/player/{playerId}/owner", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.TransferOwnership)))
+	http.Handle("POST /api/lobby/{lobbyId}/player/{playerId}/co-host", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetCoHost)))
+	http.Handle("POST /api/lobby/{lobbyId}/player/{playerId}/co-host/undo", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetCoHostUndo)))
 	http.Handle("PUT /api/lobby/{lobbyId}/name", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetName)))
 	http.Handle("PUT /api/lobby/{lobbyId}/message", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetMessage)))
 	http.Handle("PUT /api/lobby/{lobbyId}/draw-priority", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetDrawPriority)))
@@ -182,12 +211,26 @@ func main() {
 	http.Handle("PUT /api/lobby/{lobbyId}/free-special-cards", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetFreeSpecialCards)))
 	http.Handle("PUT /api/lobby/{lobbyId}/win-streak-threshold", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetWinStreakThreshold)))
 	http.Handle("PUT /api/lobby/{lobbyId}/lose-streak-threshold", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetLoseStreakThreshold)))
+	http.Handle("POST /api/lobby/{lobbyId}/streak-rule", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.AddStreakRule)))
+	http.Handle("POST /api/lobby/{lobbyId}/streak-rule/{ruleId}/remove", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.RemoveStreakRule)))
+	http.Handle("PUT /api/lobby/{lobbyId}/economy", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetEconomy)))
+	http.Handle("PUT /api/lobby/{lobbyId}/specials", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetSpecials)))
+	http.Handle("PUT /api/lobby/{lobbyId}/free-text", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetFreeText)))
+	http.Handle("PUT /api/lobby/{lobbyId}/prompt-choices", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetPromptChoices)))
+	http.Handle("PUT /api/lobby/{lobbyId}/custom-prompts", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetCustomPrompts)))
+	http.Handle("PUT /api/lobby/{lobbyId}/spectators", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetSpectators)))
+	http.Handle("PUT /api/lobby/{lobbyId}/kick-rules", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetKickRules)))
+	http.Handle("PUT /api/lobby/{lobbyId}/idle-rounds", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetIdleRounds)))
+	http.Handle("PUT /api/lobby/{lobbyId}/reshuffle-exclude-rounds", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetReshuffleExcludeRounds)))
+	http.Handle("PUT /api/lobby/{lobbyId}/handicap-rules", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetHandicapRules)))
+	http.Handle("PUT /api/lobby/{lobbyId}/min-players", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetMinPlayers)))
 	http.Handle("PUT /api/lobby/{lobbyId}/response-count", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetResponseCount)))
 	http.Handle("PUT /api/lobby/{lobbyId}/set-decks", api.MiddlewareForAPIs(http.HandlerFunc(apiLobby.SetDecks)))
 
 	// access
 	http.Handle("POST /api/access/lobby/{lobbyId}", api.MiddlewareForAPIs(http.HandlerFunc(apiAccess.Lobby)))
 	http.Handle("POST /api/access/deck/{deckId}", api.MiddlewareForAPIs(http.HandlerFunc(apiAccess.Deck)))
+	http.Handle("POST /api/access/invite/{token}", api.MiddlewareForAPIs(http.HandlerFunc(apiAccess.Invite)))
 
 	// stats
 	http.Handle("POST /api/stats/leaderboard", api.MiddlewareForAPIs(http.HandlerFunc(apiStats.GetLeaderboard)))
@@ -209,6 +252,12 @@ func main() {
 		port = ":" + os.Getenv("CARD_JUDGE_PORT")
 	}
 
+	// bots play on their own, outside of any request
+	go game.RunBots()
+
+	// idle players are handled between requests too
+	go game.RunIdleChecks()
+
 	log.Println("server is running...")
 	if os.Getenv("CARD_JUDGE_CERT_FILE") != "" && os.Getenv("CARD_JUDGE_KEY_FILE") != "" {
 		err = http.ListenAndServeTLS(port, os.Getenv("CARD_JUDGE_CERT_FILE"), os.Getenv("CARD_JUDGE_KEY_FILE"), nil)
@@ -97,14 +97,16 @@ function websocketConnect() {
                 });
                 return;
 
-            case "table-flipped":
-            case "player-kicked":
-                confirmationDialogDelete();
-                const gifDialog = document.getElementById(`${messageText}-dialog`);
-                if (gifDialog) {
-                    gifDialog.showModal();
-                    setTimeout(() => gifDialog.close(), 2000);
-                }
+            case "lobby-paused":
+                pauseRoundTimerInterval();
+                return;
+
+            case "lobby-resumed":
+                resumeRoundTimerInterval();
+                return;
+
+            case "reload":
+                document.location.reload();
                 return;
 
             case "exit":
@@ -112,6 +114,18 @@ function websocketConnect() {
                 return;
         }
 
+        // "table-flipped" and "player-kicked" may end with a cosmetic animation,
+        // e.g. "table-flipped-spin"
+        if (messageText.startsWith("table-flipped") || messageText.startsWith("player-kicked")) {
+            confirmationDialogDelete();
+            const gifDialog = document.getElementById(`${messageText}-dialog`);
+            if (gifDialog) {
+                gifDialog.showModal();
+                setTimeout(() => gifDialog.close(), 2000);
+            }
+            return;
+        }
+
         if (messageText.startsWith("timer")) {
             const timerData = messageText.split(";;");
             if (timerData.length === 2) {
@@ -148,18 +162,69 @@ function displayLobbyAlert(header, body, seconds) {
     setTimeout(() => alertDialog.close(), seconds * 1000);
 }
 
+function updateDeckWeightPercents() {
+    const percentElements = document.querySelectorAll("[id^=deckWeightPercent]");
+
+    const chosenDecks = [];
+    let isWeighted = false;
+    percentElements.forEach((percentElement) => {
+        percentElement.innerText = "";
+
+        const deckId = percentElement.id.replace("deckWeightPercent", "");
+        const promptCheckbox = document.getElementById(`deckSelectPrompt${deckId}`);
+        if (!promptCheckbox) return;
+        const responseCheckbox = document.getElementById(`deckSelectResponse${deckId}`);
+        if (!responseCheckbox) return;
+        const weightInput = document.getElementById(`deckWeight${deckId}`);
+        if (!weightInput) return;
+        if (!promptCheckbox.checked && !responseCheckbox.checked) return;
+
+        const weight = parseInt(weightInput.value) || 0;
+        if (weight > 0) isWeighted = true;
+        chosenDecks.push({ percentElement, weight: weight || 1 });
+    });
+
+    // without weights every card has the same odds, so decks have no share
+    if (!isWeighted) return;
+
+    const totalWeight = chosenDecks.reduce((total, deck) => total + deck.weight, 0);
+    chosenDecks.forEach((deck) => {
+        deck.percentElement.innerText = `${Math.round((deck.weight * 100) / totalWeight)}%`;
+    });
+}
+
 let roundTimerInterval = null;
+let roundTimerPaused = false;
 
 resetRoundTimerInterval();
 
 function resetRoundTimerInterval(seconds = 0) {
     if (roundTimerInterval) clearInterval(roundTimerInterval);
+    roundTimerPaused = false;
 
     const roundTimerSecondsElement = document.getElementById("round-timer-seconds");
     if (!roundTimerSecondsElement) return;
     roundTimerSecondsElement.innerText = seconds;
 }
 
+function pauseRoundTimerInterval() {
+    if (!roundTimerInterval) return;
+    clearInterval(roundTimerInterval);
+    roundTimerInterval = null;
+    roundTimerPaused = true;
+}
+
+function resumeRoundTimerInterval() {
+    if (!roundTimerPaused) return;
+    roundTimerPaused = false;
+
+    const roundTimerSecondsElement = document.getElementById("round-timer-seconds");
+    if (!roundTimerSecondsElement) return;
+
+    const secondsRemaining = parseInt(roundTimerSecondsElement.innerText) || 0;
+    if (secondsRemaining > 0) startRoundTimerInterval(secondsRemaining);
+}
+
 function startRoundTimerInterval(seconds) {
     resetRoundTimerInterval(seconds);
     roundTimerInterval = setInterval(() => {
@@ -0,0 +1,8 @@
+CREATE
+OR REPLACE EVENT EVT_CLEAN_ACCESS_FAILURES ON SCHEDULE EVERY 1 DAY
+DO
+    BEGIN
+        DELETE
+        FROM ACCESS_FAILURE
+        WHERE CREATED_ON_DATE < DATE_SUB(CURRENT_TIMESTAMP(), INTERVAL 2 WEEK);
+    END;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE
+OR REPLACE EVENT EVT_EXPIRE_KICK_VOTES ON SCHEDULE EVERY 1 MINUTE
+DO
+    BEGIN
+        DELETE K
+        FROM KICK AS K
+            INNER JOIN PLAYER AS P ON P.ID = K.SUBJECT_PLAYER_ID
+            INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = P.LOBBY_ID
+        WHERE CJLS.KICK_VOTE_MINUTES > 0
+            AND K.CREATED_ON_DATE < DATE_SUB(NOW(), INTERVAL CJLS.KICK_VOTE_MINUTES MINUTE);
+
+        DELETE
+        FROM LOBBY_BAN
+        WHERE EXPIRES_ON_DATE < NOW();
+    END;
\ No newline at end of file
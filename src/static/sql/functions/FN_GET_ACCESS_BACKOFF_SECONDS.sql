@@ -0,0 +1,20 @@
+CREATE
+OR REPLACE FUNCTION FN_GET_ACCESS_BACKOFF_SECONDS(
+    IN VAR_FAILURE_COUNT INT,
+    IN VAR_LAST_FAILURE_DATE DATETIME(6)
+)
+RETURNS INT
+BEGIN
+    DECLARE VAR_BACKOFF_SECONDS INT DEFAULT 0;
+
+    -- TEN FAILURES IN AN HOUR LOCKS OUT FOR AN HOUR, BEFORE THAT THE WAIT
+    -- DOUBLES WITH EACH FAILURE AFTER THE FIRST THREE
+    IF VAR_FAILURE_COUNT >= 10 THEN
+        SET VAR_BACKOFF_SECONDS = 3600;
+        ELSEIF VAR_FAILURE_COUNT >= 3 THEN
+        SET VAR_BACKOFF_SECONDS = POW(2, VAR_FAILURE_COUNT - 3);
+    END
+    IF;
+
+    RETURN GREATEST(VAR_BACKOFF_SECONDS - COALESCE(TIMESTAMPDIFF(SECOND, VAR_LAST_FAILURE_DATE, NOW()), 0), 0);
+END;
\ No newline at end of file
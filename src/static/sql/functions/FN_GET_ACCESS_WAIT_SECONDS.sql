@@ -0,0 +1,39 @@
+CREATE
+OR REPLACE FUNCTION FN_GET_ACCESS_WAIT_SECONDS(
+    IN VAR_IP_ADDRESS VARCHAR(255),
+    IN VAR_USER_ID UUID
+)
+RETURNS INT
+BEGIN
+    -- USERS AND ADDRESSES ARE COUNTED ON THEIR OWN, SO SWITCHING ONE DOES NOT
+    -- RESET THE OTHER
+    DECLARE VAR_IP_FAILURE_COUNT INT DEFAULT 0;
+    DECLARE VAR_IP_LAST_FAILURE_DATE DATETIME(6);
+    DECLARE VAR_USER_FAILURE_COUNT INT DEFAULT 0;
+    DECLARE VAR_USER_LAST_FAILURE_DATE DATETIME(6);
+
+    SELECT
+        COUNT(*),
+        MAX(CREATED_ON_DATE)
+    INTO
+        VAR_IP_FAILURE_COUNT,
+        VAR_IP_LAST_FAILURE_DATE
+    FROM ACCESS_FAILURE
+    WHERE IP_ADDRESS = VAR_IP_ADDRESS
+        AND CREATED_ON_DATE > DATE_SUB(NOW(), INTERVAL 1 HOUR);
+
+    SELECT
+        COUNT(*),
+        MAX(CREATED_ON_DATE)
+    INTO
+        VAR_USER_FAILURE_COUNT,
+        VAR_USER_LAST_FAILURE_DATE
+    FROM ACCESS_FAILURE
+    WHERE USER_ID = VAR_USER_ID
+        AND CREATED_ON_DATE > DATE_SUB(NOW(), INTERVAL 1 HOUR);
+
+    RETURN GREATEST(
+            FN_GET_ACCESS_BACKOFF_SECONDS(VAR_IP_FAILURE_COUNT, VAR_IP_LAST_FAILURE_DATE),
+            FN_GET_ACCESS_BACKOFF_SECONDS(VAR_USER_FAILURE_COUNT, VAR_USER_LAST_FAILURE_DATE)
+        );
+END;
\ No newline at end of file
@@ -1,5 +1,5 @@
 CREATE
-OR REPLACE FUNCTION FN_GET_SPECIAL_COST(
+OR REPLACE FUNCTION FN_GET_DEFAULT_ECONOMY_VALUE(
     IN VAR_CATEGORY ENUM(
         'WINNING-STREAK',
         'LOSING-STREAK',
@@ -12,7 +12,13 @@ OR REPLACE FUNCTION FN_GET_SPECIAL_COST(
         'SURPRISE',
         'FIND',
         'WILD',
-        'PERK'
+        'PERK',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'BET-WIN',
+        'GAMBLE-WIN',
+        'GAMBLE-CHANCE'
     )
 )
 RETURNS INT
@@ -31,5 +37,13 @@ BEGIN
         WHEN VAR_CATEGORY = 'FIND' THEN 2
         WHEN VAR_CATEGORY = 'WILD' THEN 3
         WHEN VAR_CATEGORY = 'PERK' THEN 15
+        WHEN VAR_CATEGORY = 'SWAP' THEN 2
+        WHEN VAR_CATEGORY = 'VETO' THEN 3
+        WHEN VAR_CATEGORY = 'MULLIGAN' THEN 3
+        -- PAYOUTS ARE MULTIPLES OF THE STAKE
+        WHEN VAR_CATEGORY = 'BET-WIN' THEN 2
+        WHEN VAR_CATEGORY = 'GAMBLE-WIN' THEN 2
+        -- PERCENT CHANCE OF WINNING A GAMBLE
+        WHEN VAR_CATEGORY = 'GAMBLE-CHANCE' THEN 45
     END;
 END;
\ No newline at end of file
@@ -11,6 +11,36 @@ BEGIN
             FROM CJ_LOBBY_SETTINGS
             WHERE LOBBY_ID = VAR_LOBBY_ID
         );
+    DECLARE VAR_IS_WEIGHTED BOOLEAN DEFAULT EXISTS(
+            SELECT
+                ID
+            FROM CJ_LOBBY_DECK_WEIGHT
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+    DECLARE VAR_DECK_ID UUID;
+
+    -- WEIGHTED LOBBIES PICK A DECK FIRST, DECKS WITHOUT A WEIGHT COUNT AS 1
+    IF VAR_IS_WEIGHTED THEN
+        SET VAR_DECK_ID = (
+                SELECT
+                    DECK_ID
+                FROM (
+                        SELECT
+                            C.DECK_ID,
+                            COALESCE(MAX(CJLDW.WEIGHT), 1) AS WEIGHT
+                        FROM DRAW_PILE AS DP
+                            INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
+                            LEFT JOIN CJ_LOBBY_DECK_WEIGHT AS CJLDW ON CJLDW.LOBBY_ID = DP.LOBBY_ID
+                            AND CJLDW.DECK_ID = C.DECK_ID
+                        WHERE C.CATEGORY = VAR_CATEGORY
+                            AND DP.LOBBY_ID = VAR_LOBBY_ID
+                        GROUP BY C.DECK_ID
+                    ) AS D
+                ORDER BY -LN(1 - RAND()) / D.WEIGHT
+                LIMIT 1
+            );
+    END
+    IF;
 
     IF VAR_LOBBY_DRAW_PRIORITY = 'PLAYCOUNT' THEN
         IF VAR_CATEGORY = 'PROMPT' THEN
@@ -36,6 +66,7 @@ BEGIN
                 ) AS CS ON CS.CARD_ID = C.ID
             WHERE C.CATEGORY = VAR_CATEGORY
                 AND DP.LOBBY_ID = VAR_LOBBY_ID
+                AND (NOT VAR_IS_WEIGHTED OR C.DECK_ID <=> VAR_DECK_ID)
             ORDER BY CP.PLAY_COUNT,
                 CS.SKIP_COUNT,
                 RAND()
@@ -65,6 +96,7 @@ BEGIN
             ) AS CD ON CD.CARD_ID = C.ID
         WHERE C.CATEGORY = VAR_CATEGORY
             AND DP.LOBBY_ID = VAR_LOBBY_ID
+            AND (NOT VAR_IS_WEIGHTED OR C.DECK_ID <=> VAR_DECK_ID)
         ORDER BY CP.PLAY_COUNT,
             CD.DISCARD_COUNT,
             RAND()
@@ -80,6 +112,7 @@ RETURN (
         INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
     WHERE C.CATEGORY = VAR_CATEGORY
         AND DP.LOBBY_ID = VAR_LOBBY_ID
+        AND (NOT VAR_IS_WEIGHTED OR C.DECK_ID <=> VAR_DECK_ID)
     ORDER BY RAND()
     LIMIT 1
 );
@@ -0,0 +1,38 @@
+CREATE
+OR REPLACE FUNCTION FN_GET_ECONOMY_VALUE(
+    IN VAR_LOBBY_ID UUID,
+    IN VAR_CATEGORY ENUM(
+        'WINNING-STREAK',
+        'LOSING-STREAK',
+        'PURCHASE',
+        'SKIP-JUDGE',
+        'EXTRA-RESPONSE',
+        'BLOCK-RESPONSE',
+        'STEAL',
+        'STEAL-VICTIM',
+        'SURPRISE',
+        'FIND',
+        'WILD',
+        'PERK',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'BET-WIN',
+        'GAMBLE-WIN',
+        'GAMBLE-CHANCE'
+    )
+)
+RETURNS INT
+BEGIN
+    -- LOBBIES OPENED BEFORE THE ECONOMY TABLE EXISTED HAVE NO ROWS
+    RETURN COALESCE(
+            (
+                SELECT
+                    AMOUNT
+                FROM CJ_LOBBY_ECONOMY
+                WHERE LOBBY_ID = VAR_LOBBY_ID
+                    AND CATEGORY = VAR_CATEGORY
+            ),
+            FN_GET_DEFAULT_ECONOMY_VALUE(VAR_CATEGORY)
+        );
+END;
\ No newline at end of file
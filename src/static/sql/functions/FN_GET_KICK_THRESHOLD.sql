@@ -0,0 +1,40 @@
+CREATE
+OR REPLACE FUNCTION FN_GET_KICK_THRESHOLD(IN VAR_SUBJECT_PLAYER_ID UUID)
+RETURNS INT
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_SUBJECT_PLAYER_ID);
+
+    DECLARE VAR_KICK_THRESHOLD_MODE VARCHAR(10) DEFAULT (
+            SELECT
+                KICK_THRESHOLD_MODE
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    DECLARE VAR_VOTER_COUNT INT DEFAULT 0;
+
+    IF VAR_KICK_THRESHOLD_MODE = 'MAJORITY' THEN
+        -- EVERYONE WHO COULD VOTE AGAINST THE SUBJECT
+        SET VAR_VOTER_COUNT = (
+                SELECT
+                    COUNT(*)
+                FROM PLAYER AS P
+                    INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+                WHERE P.LOBBY_ID = VAR_LOBBY_ID
+                    AND P.ID <> VAR_SUBJECT_PLAYER_ID
+                    AND P.IS_ACTIVE = 1
+                    AND CJPS.IS_SPECTATOR = 0
+                    AND NOT EXISTS(SELECT ID FROM BOT WHERE PLAYER_ID = P.ID)
+            );
+
+        RETURN GREATEST(FLOOR(VAR_VOTER_COUNT / 2) + 1, 1);
+    END
+    IF;
+
+    RETURN (
+        SELECT
+            GREATEST(KICK_VOTE_COUNT, 1)
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+    );
+END;
\ No newline at end of file
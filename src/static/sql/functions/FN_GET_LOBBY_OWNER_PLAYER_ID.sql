@@ -0,0 +1,36 @@
+CREATE
+OR REPLACE FUNCTION FN_GET_LOBBY_OWNER_PLAYER_ID(IN VAR_LOBBY_ID UUID)
+RETURNS UUID
+BEGIN
+    -- THE PLAYER THE LOBBY WAS HANDED TO, WHILE THEY ARE PLAYING
+    DECLARE VAR_OWNER_PLAYER_ID UUID DEFAULT (
+            SELECT
+                P.ID
+            FROM CJ_LOBBY_SETTINGS AS CJLS
+                INNER JOIN PLAYER AS P ON P.ID = CJLS.OWNER_PLAYER_ID
+                INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+            WHERE CJLS.LOBBY_ID = VAR_LOBBY_ID
+                AND P.IS_ACTIVE = 1
+                AND CJPS.IS_SPECTATOR = 0
+        );
+
+    -- OTHERWISE THE FIRST CO-HOST TO JOIN, THEN THE FIRST PLAYER TO JOIN
+    IF VAR_OWNER_PLAYER_ID IS NULL THEN
+        SET VAR_OWNER_PLAYER_ID = (
+                SELECT
+                    P.ID
+                FROM PLAYER AS P
+                    INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+                WHERE P.LOBBY_ID = VAR_LOBBY_ID
+                    AND P.IS_ACTIVE = 1
+                    AND CJPS.IS_SPECTATOR = 0
+                    AND NOT EXISTS(SELECT ID FROM BOT WHERE PLAYER_ID = P.ID)
+                ORDER BY CJPS.IS_CO_HOST DESC,
+                    P.JOIN_ORDER ASC
+                LIMIT 1
+            );
+    END
+    IF;
+
+    RETURN VAR_OWNER_PLAYER_ID;
+END;
\ No newline at end of file
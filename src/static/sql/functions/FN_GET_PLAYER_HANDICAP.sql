@@ -5,23 +5,54 @@ BEGIN
     DECLARE VAR_HANDICAP INT DEFAULT 0;
     DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
 
-    WITH PLAYER_RANK AS (
+    DECLARE VAR_HANDICAP_MODE VARCHAR(10) DEFAULT (
+            SELECT
+                HANDICAP_MODE
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    DECLARE VAR_HANDICAP_SCALE DECIMAL(4, 2) DEFAULT (
+            SELECT
+                HANDICAP_SCALE
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    IF VAR_HANDICAP_MODE = 'OFF' THEN
+        RETURN 0;
+    END
+    IF;
+
+    WITH PLAYER_WINS AS (
             SELECT
                 P.ID,
-                RANK() OVER (ORDER BY COUNT(W.ID) ASC) AS RANKING
+                COUNT(W.ID) + CJPS.BONUS_POINTS AS WINS
             FROM PLAYER AS P
+                INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
                 LEFT JOIN WIN AS W ON W.PLAYER_ID = P.ID
             WHERE P.LOBBY_ID = VAR_LOBBY_ID
                 AND P.IS_ACTIVE = 1
-            GROUP BY P.ID
+                AND CJPS.IS_SPECTATOR = 0
+            GROUP BY P.ID,
+                CJPS.BONUS_POINTS
+        ),
+        PLAYER_RANK AS (
+            SELECT
+                ID,
+                RANK() OVER (ORDER BY WINS ASC) - 1 AS RANKING,
+                WINS - MIN(WINS) OVER () AS POINT_GAP
+            FROM PLAYER_WINS
         )
     SELECT
-        RANKING - 1
+        IF(VAR_HANDICAP_MODE = 'POINT-GAP', POINT_GAP, RANKING)
     INTO
         VAR_HANDICAP
     FROM PLAYER_RANK
     WHERE ID = VAR_PLAYER_ID;
 
+    SET VAR_HANDICAP = ROUND(VAR_HANDICAP * VAR_HANDICAP_SCALE);
+
     IF(SELECT HANDICAP_ADVANTAGE FROM CJ_PLAYER_STATE WHERE PLAYER_ID = VAR_PLAYER_ID) THEN
         SET VAR_HANDICAP = VAR_HANDICAP - 1;
     END
@@ -0,0 +1,24 @@
+CREATE
+OR REPLACE FUNCTION FN_GET_PLAYER_LOBBY_ROLE(IN VAR_PLAYER_ID UUID)
+RETURNS VARCHAR(10)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+
+    IF VAR_PLAYER_ID = FN_GET_LOBBY_OWNER_PLAYER_ID(VAR_LOBBY_ID) THEN
+        RETURN 'OWNER';
+    END
+    IF;
+
+    IF EXISTS(
+        SELECT
+            PLAYER_ID
+        FROM CJ_PLAYER_STATE
+        WHERE PLAYER_ID = VAR_PLAYER_ID
+            AND IS_CO_HOST = 1
+    ) THEN
+        RETURN 'CO-HOST';
+    END
+    IF;
+
+    RETURN 'PLAYER';
+END;
\ No newline at end of file
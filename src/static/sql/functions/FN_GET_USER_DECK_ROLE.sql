@@ -0,0 +1,52 @@
+CREATE
+OR REPLACE FUNCTION FN_GET_USER_DECK_ROLE(
+    IN VAR_USER_ID UUID,
+    IN VAR_DECK_ID UUID
+)
+RETURNS VARCHAR(20)
+BEGIN
+    DECLARE VAR_ROLE VARCHAR(20) DEFAULT (
+            SELECT
+                ROLE
+            FROM DECK_COLLABORATOR
+            WHERE USER_ID = VAR_USER_ID
+                AND DECK_ID = VAR_DECK_ID
+        );
+
+    -- USER IS ADMIN
+    IF EXISTS(SELECT ID FROM USER WHERE ID = VAR_USER_ID AND IS_ADMIN = 1) THEN
+        RETURN 'OWNER';
+    END
+    IF;
+
+    -- A ROLE GIVEN BY THE OWNER WINS OVER HOW THE USER GOT IN
+    IF VAR_ROLE IS NOT NULL THEN
+        RETURN VAR_ROLE;
+    END
+    IF;
+
+    -- THE PASSWORD ONLY LETS USERS SUGGEST CARDS, EDITORS ARE ADDED BY THE OWNER
+    IF EXISTS(
+        SELECT
+            ID
+        FROM USER_ACCESS_DECK
+        WHERE USER_ID = VAR_USER_ID
+            AND DECK_ID = VAR_DECK_ID
+    ) THEN
+        RETURN 'CONTRIBUTOR';
+    END
+    IF;
+
+    IF EXISTS(
+        SELECT
+            ID
+        FROM USER_READ_ACCESS_DECK
+        WHERE USER_ID = VAR_USER_ID
+            AND DECK_ID = VAR_DECK_ID
+    ) THEN
+        RETURN 'VIEWER';
+    END
+    IF;
+
+    RETURN 'NONE';
+END;
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- Re-asserts every CJ_LOBBY_DISABLED_SPECIAL.SPECIAL so lobbies on databases
+-- provisioned before a special was added can turn it off. Keep in step with
+-- the table. Idempotent.
+ALTER TABLE CJ_LOBBY_DISABLED_SPECIAL MODIFY SPECIAL ENUM(
+    'ALERT',
+    'GAMBLE',
+    'BET',
+    'SKIP-JUDGE',
+    'EXTRA-RESPONSE',
+    'BLOCK-RESPONSE',
+    'SURPRISE',
+    'STEAL',
+    'FIND',
+    'WILD',
+    'SWAP',
+    'VETO',
+    'MULLIGAN',
+    'SIDE-BET',
+    'PERK-HAND-SIZE',
+    'PERK-DISCARD',
+    'PERK-HANDICAP',
+    'PERK-SPY'
+) NOT NULL;
@@ -0,0 +1,23 @@
+-- Re-asserts every CJ_LOBBY_ECONOMY.CATEGORY so databases provisioned before a
+-- price was added can store it. Older lobbies have no rows for new prices and
+-- fall back to the defaults. Keep in step with the table. Idempotent.
+ALTER TABLE CJ_LOBBY_ECONOMY MODIFY CATEGORY ENUM(
+    'WINNING-STREAK',
+    'LOSING-STREAK',
+    'PURCHASE',
+    'SKIP-JUDGE',
+    'EXTRA-RESPONSE',
+    'BLOCK-RESPONSE',
+    'STEAL',
+    'STEAL-VICTIM',
+    'SURPRISE',
+    'FIND',
+    'WILD',
+    'PERK',
+    'SWAP',
+    'VETO',
+    'MULLIGAN',
+    'BET-WIN',
+    'GAMBLE-WIN',
+    'GAMBLE-CHANCE'
+) NOT NULL;
@@ -0,0 +1,3 @@
+-- Adds CJ_LOBBY_SETTINGS.CAPTION_MODE for caption contest lobbies on
+-- databases provisioned before the mode existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS ADD COLUMN IF NOT EXISTS CAPTION_MODE BOOLEAN NOT NULL DEFAULT FALSE;
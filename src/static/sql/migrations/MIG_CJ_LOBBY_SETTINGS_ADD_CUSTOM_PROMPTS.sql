@@ -0,0 +1,4 @@
+-- Adds CJ_LOBBY_SETTINGS.CUSTOM_PROMPTS (whether the judge may write their own
+-- prompt card for the round) on databases provisioned before the setting
+-- existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS ADD COLUMN IF NOT EXISTS CUSTOM_PROMPTS BOOLEAN NOT NULL DEFAULT FALSE;
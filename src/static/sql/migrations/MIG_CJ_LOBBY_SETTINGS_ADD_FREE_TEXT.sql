@@ -0,0 +1,9 @@
+-- Adds the free-text answer mode settings to CJ_LOBBY_SETTINGS on databases
+-- provisioned before the mode existed. Idempotent.
+-- Must run before SP_DRAW_HAND and TR_CJ_LOBBY_SETTINGS_AFTER_UPDATE are
+-- (re)created, since both reference the new columns.
+ALTER TABLE CJ_LOBBY_SETTINGS
+    ADD COLUMN IF NOT EXISTS FREE_TEXT_MODE BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS FREE_TEXT_HANDS BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS FREE_TEXT_MAX_LENGTH INT NOT NULL DEFAULT 140,
+    ADD COLUMN IF NOT EXISTS FREE_TEXT_WORD_FILTER VARCHAR(1020) NULL;
@@ -0,0 +1,7 @@
+-- Adds the handicap settings (how the handicap is worked out and scaled) and
+-- the catch-up given to late joiners to CJ_LOBBY_SETTINGS on databases
+-- provisioned before they existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS
+    ADD COLUMN IF NOT EXISTS HANDICAP_MODE ENUM('OFF', 'RANK', 'POINT-GAP') NOT NULL DEFAULT 'RANK',
+    ADD COLUMN IF NOT EXISTS HANDICAP_SCALE DECIMAL(4, 2) NOT NULL DEFAULT 1.00,
+    ADD COLUMN IF NOT EXISTS CATCH_UP_MODE ENUM('OFF', 'POINTS', 'CREDITS') NOT NULL DEFAULT 'OFF';
@@ -0,0 +1,4 @@
+-- Adds CJ_LOBBY_SETTINGS.IDLE_ROUNDS (how many rounds a player can miss
+-- before they are played for, 0 to never) on databases provisioned before
+-- idle tracking existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS ADD COLUMN IF NOT EXISTS IDLE_ROUNDS INT NOT NULL DEFAULT 3;
@@ -0,0 +1,8 @@
+-- Adds the vote-kick settings to CJ_LOBBY_SETTINGS (how many votes kick a
+-- player, how long votes last, and how long a kick bans for) on databases
+-- provisioned before they existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS
+    ADD COLUMN IF NOT EXISTS KICK_THRESHOLD_MODE ENUM('COUNT', 'MAJORITY') NOT NULL DEFAULT 'COUNT',
+    ADD COLUMN IF NOT EXISTS KICK_VOTE_COUNT INT NOT NULL DEFAULT 2,
+    ADD COLUMN IF NOT EXISTS KICK_VOTE_MINUTES INT NOT NULL DEFAULT 10,
+    ADD COLUMN IF NOT EXISTS KICK_BAN_MINUTES INT NOT NULL DEFAULT 15;
@@ -0,0 +1,4 @@
+-- Adds CJ_LOBBY_SETTINGS.OWNER_PLAYER_ID (player the lobby was handed to, NULL
+-- until ownership is transferred) on databases provisioned before lobby roles
+-- existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS ADD COLUMN IF NOT EXISTS OWNER_PLAYER_ID UUID NULL;
@@ -0,0 +1,3 @@
+-- Adds CJ_LOBBY_SETTINGS.PAUSED_ON_DATE (when the host paused the game, NULL
+-- while playing) on databases provisioned before pausing existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS ADD COLUMN IF NOT EXISTS PAUSED_ON_DATE DATETIME NULL;
@@ -0,0 +1,4 @@
+-- Adds CJ_LOBBY_SETTINGS.PROMPT_CHOICES (how many candidate prompts the judge
+-- is dealt each round, 1 meaning no choice) on databases provisioned before
+-- the setting existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS ADD COLUMN IF NOT EXISTS PROMPT_CHOICES INT NOT NULL DEFAULT 1;
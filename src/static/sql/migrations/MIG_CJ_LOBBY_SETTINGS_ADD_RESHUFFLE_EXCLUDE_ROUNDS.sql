@@ -0,0 +1,3 @@
+-- Adds how many recent rounds of cards are kept out of a reshuffle to
+-- CJ_LOBBY_SETTINGS on databases provisioned before it existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS ADD COLUMN IF NOT EXISTS RESHUFFLE_EXCLUDE_ROUNDS INT NOT NULL DEFAULT 0;
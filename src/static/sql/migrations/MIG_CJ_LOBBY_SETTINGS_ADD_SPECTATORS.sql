@@ -0,0 +1,6 @@
+-- Adds the spectator settings to CJ_LOBBY_SETTINGS (whether spectators may
+-- join and how many at once) on databases provisioned before spectators
+-- existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS
+    ADD COLUMN IF NOT EXISTS ALLOW_SPECTATORS BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS MAX_SPECTATORS INT NOT NULL DEFAULT 10;
@@ -0,0 +1,5 @@
+-- Adds the pre-game waiting room settings to CJ_LOBBY_SETTINGS on databases
+-- provisioned before the waiting room existed. Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS
+    ADD COLUMN IF NOT EXISTS MIN_PLAYERS INT NOT NULL DEFAULT 3,
+    ADD COLUMN IF NOT EXISTS IS_STARTED BOOLEAN NOT NULL DEFAULT FALSE;
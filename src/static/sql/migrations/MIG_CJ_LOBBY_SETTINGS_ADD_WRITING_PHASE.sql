@@ -0,0 +1,9 @@
+-- Adds the pre-game writing phase settings to CJ_LOBBY_SETTINGS (how many
+-- prompts and responses each player writes, how long they have, and when
+-- the phase ends) on databases provisioned before the phase existed.
+-- Idempotent.
+ALTER TABLE CJ_LOBBY_SETTINGS
+    ADD COLUMN IF NOT EXISTS WRITE_PROMPT_COUNT INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS WRITE_RESPONSE_COUNT INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS WRITE_PHASE_MINUTES INT NOT NULL DEFAULT 5,
+    ADD COLUMN IF NOT EXISTS WRITE_PHASE_END DATETIME NULL;
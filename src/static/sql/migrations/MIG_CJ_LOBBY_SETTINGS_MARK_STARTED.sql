@@ -0,0 +1,11 @@
+-- Marks the lobbies that were already in play before the waiting room
+-- existed as started. Only started lobbies have a judge. Idempotent.
+UPDATE CJ_LOBBY_SETTINGS AS CJLS
+SET CJLS.IS_STARTED = TRUE
+WHERE CJLS.IS_STARTED = FALSE
+    AND EXISTS(
+        SELECT
+            J.LOBBY_ID
+        FROM JUDGE AS J
+        WHERE J.LOBBY_ID = CJLS.LOBBY_ID
+    );
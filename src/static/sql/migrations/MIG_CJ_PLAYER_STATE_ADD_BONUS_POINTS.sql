@@ -0,0 +1,4 @@
+-- Adds CJ_PLAYER_STATE.BONUS_POINTS (points given to a late joiner to catch
+-- up, which count on the scoreboard but are not round wins) on databases
+-- provisioned before it. Idempotent.
+ALTER TABLE CJ_PLAYER_STATE ADD COLUMN IF NOT EXISTS BONUS_POINTS INT NOT NULL DEFAULT 0;
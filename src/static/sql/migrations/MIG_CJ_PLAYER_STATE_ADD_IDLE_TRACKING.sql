@@ -0,0 +1,8 @@
+-- Adds the idle tracking columns to CJ_PLAYER_STATE (the last round the
+-- player did something in, how many rounds in a row they have missed, and the
+-- round they were last warned in) on databases provisioned before idle
+-- tracking existed. Idempotent.
+ALTER TABLE CJ_PLAYER_STATE
+    ADD COLUMN IF NOT EXISTS LAST_ACTION_ROUND_ID UUID NULL,
+    ADD COLUMN IF NOT EXISTS MISSED_ROUNDS INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS IDLE_WARNED_ROUND_ID UUID NULL;
@@ -0,0 +1,3 @@
+-- Adds CJ_PLAYER_STATE.IS_CO_HOST (player helps the owner run the lobby) on
+-- databases provisioned before lobby roles existed. Idempotent.
+ALTER TABLE CJ_PLAYER_STATE ADD COLUMN IF NOT EXISTS IS_CO_HOST BOOLEAN NOT NULL DEFAULT 0;
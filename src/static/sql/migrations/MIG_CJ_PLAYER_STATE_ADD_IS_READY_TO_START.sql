@@ -0,0 +1,4 @@
+-- Adds CJ_PLAYER_STATE.IS_READY_TO_START (player is ready in the pre-game
+-- waiting room) on databases provisioned before the waiting room existed.
+-- Idempotent.
+ALTER TABLE CJ_PLAYER_STATE ADD COLUMN IF NOT EXISTS IS_READY_TO_START BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Adds CJ_PLAYER_STATE.IS_SPECTATOR (player is watching without a hand or a
+-- judge turn) on databases provisioned before spectators existed. Idempotent.
+ALTER TABLE CJ_PLAYER_STATE ADD COLUMN IF NOT EXISTS IS_SPECTATOR BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Adds CJ_PLAYER_STATE.JUDGE_IMMUNITY (how many turns as judge the player
+-- will be passed over for, earned from streak rules) on databases provisioned
+-- before streak rules existed. Idempotent.
+ALTER TABLE CJ_PLAYER_STATE ADD COLUMN IF NOT EXISTS JUDGE_IMMUNITY INT NOT NULL DEFAULT 0;
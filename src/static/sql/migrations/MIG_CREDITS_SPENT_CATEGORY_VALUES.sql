@@ -0,0 +1,28 @@
+-- Re-asserts every CREDITS_SPENT.CATEGORY so databases provisioned before a
+-- category was added can record it. Keep in step with the table. Idempotent.
+ALTER TABLE CREDITS_SPENT MODIFY CATEGORY ENUM(
+    'WINNING-STREAK',
+    'LOSING-STREAK',
+    'PURCHASE',
+    'SKIP-JUDGE',
+    'ALERT',
+    'GAMBLE',
+    'GAMBLE-WIN',
+    'BET',
+    'BET-WIN',
+    'EXTRA-RESPONSE',
+    'BLOCK-RESPONSE',
+    'STEAL',
+    'STEAL-VICTIM',
+    'SURPRISE',
+    'FIND',
+    'WILD',
+    'PERK',
+    'SWAP',
+    'VETO',
+    'MULLIGAN',
+    'SIDE-BET',
+    'SIDE-BET-WIN',
+    'CATCH-UP',
+    'STREAK-REWARD'
+) NOT NULL;
@@ -0,0 +1,28 @@
+-- Gives decks made before collaborator roles an owner: the first user who
+-- still has password access to them. Decks without one are left to the
+-- admins. Only runs once, see MIG_DECK_COLLABORATOR_ADD_OWNERS_DONE.
+INSERT IGNORE INTO DECK_COLLABORATOR(USER_ID, DECK_ID, ROLE)
+SELECT
+    UAD.USER_ID,
+    UAD.DECK_ID,
+    'OWNER' AS ROLE
+FROM USER_ACCESS_DECK AS UAD
+WHERE UAD.CREATED_ON_DATE = (
+        SELECT
+            MIN(CREATED_ON_DATE)
+        FROM USER_ACCESS_DECK
+        WHERE DECK_ID = UAD.DECK_ID
+    )
+    AND NOT EXISTS(
+        SELECT
+            ID
+        FROM DECK_COLLABORATOR
+        WHERE DECK_ID = UAD.DECK_ID
+            AND ROLE = 'OWNER'
+    )
+    AND NOT EXISTS(
+        SELECT
+            NAME
+        FROM CJ_MIGRATION
+        WHERE NAME = 'MIG_DECK_COLLABORATOR_ADD_OWNERS'
+    );
@@ -0,0 +1,4 @@
+-- Records that MIG_DECK_COLLABORATOR_ADD_OWNERS has run, so decks that later
+-- lose their owner are not handed to whoever has the password. Idempotent.
+INSERT IGNORE INTO CJ_MIGRATION(NAME)
+VALUES ('MIG_DECK_COLLABORATOR_ADD_OWNERS');
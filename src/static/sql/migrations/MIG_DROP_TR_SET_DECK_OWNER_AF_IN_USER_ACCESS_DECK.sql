@@ -0,0 +1,4 @@
+-- Drops the trigger that made the first user with password access the owner
+-- of a deck without one. Decks get their owner when they are created.
+-- Idempotent.
+DROP TRIGGER IF EXISTS TR_SET_DECK_OWNER_AF_IN_USER_ACCESS_DECK;
@@ -0,0 +1,6 @@
+-- Adds LOG_KICK.THRESHOLD and LOG_KICK.BAN_MINUTES (the votes needed and the
+-- ban handed out when the kick happened, NULL for older kicks) on databases
+-- provisioned before they were logged. Idempotent.
+ALTER TABLE LOG_KICK
+    ADD COLUMN IF NOT EXISTS THRESHOLD INT NULL,
+    ADD COLUMN IF NOT EXISTS BAN_MINUTES INT NULL;
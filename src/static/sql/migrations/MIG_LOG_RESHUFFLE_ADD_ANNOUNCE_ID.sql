@@ -0,0 +1,6 @@
+-- Adds LOG_RESHUFFLE.ANNOUNCE_ID, set by the action that claims a reshuffle to
+-- announce it, and an index on the lobby so the claim does not scan the whole
+-- log. Idempotent.
+ALTER TABLE LOG_RESHUFFLE
+    ADD COLUMN IF NOT EXISTS ANNOUNCE_ID UUID NULL,
+    ADD KEY IF NOT EXISTS LOBBY_ANNOUNCE_INDEX (LOBBY_ID, ANNOUNCE_ID);
@@ -0,0 +1,12 @@
+-- Marks the reshuffles logged before ANNOUNCE_ID existed as announced, so the
+-- next action in their lobby does not announce them all again. Only runs once,
+-- see MIG_LOG_RESHUFFLE_CLAIM_EXISTING_DONE.
+UPDATE LOG_RESHUFFLE
+SET ANNOUNCE_ID = ID
+WHERE ANNOUNCE_ID IS NULL
+    AND NOT EXISTS(
+        SELECT
+            NAME
+        FROM CJ_MIGRATION
+        WHERE NAME = 'MIG_LOG_RESHUFFLE_CLAIM_EXISTING'
+    );
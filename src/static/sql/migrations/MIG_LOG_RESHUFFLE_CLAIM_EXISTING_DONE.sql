@@ -0,0 +1,4 @@
+-- Records that MIG_LOG_RESHUFFLE_CLAIM_EXISTING has run, so reshuffles waiting
+-- to be announced are left alone on later startups. Idempotent.
+INSERT IGNORE INTO CJ_MIGRATION(NAME)
+VALUES ('MIG_LOG_RESHUFFLE_CLAIM_EXISTING');
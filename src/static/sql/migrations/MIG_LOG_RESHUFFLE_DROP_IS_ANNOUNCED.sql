@@ -0,0 +1,3 @@
+-- Drops LOG_RESHUFFLE.IS_ANNOUNCED, which marked reshuffles the lobby had been
+-- told about back when they were announced by polling the log. Idempotent.
+ALTER TABLE LOG_RESHUFFLE DROP COLUMN IF EXISTS IS_ANNOUNCED;
@@ -0,0 +1,3 @@
+-- Adds RESPONSE.IS_VETOED (a response ruled out by the veto special, which the
+-- judge cannot rule back in) on databases provisioned before it. Idempotent.
+ALTER TABLE RESPONSE ADD COLUMN IF NOT EXISTS IS_VETOED BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+-- Adds WALLET_LEDGER.ACHIEVEMENT (the reference of achievement rows only) and
+-- a unique key on it, so a milestone can only be paid once per user.
+-- Idempotent.
+ALTER TABLE WALLET_LEDGER
+    ADD COLUMN IF NOT EXISTS ACHIEVEMENT VARCHAR(255) AS (IF(CATEGORY = 'ACHIEVEMENT', REFERENCE, NULL)) PERSISTENT,
+    ADD UNIQUE KEY IF NOT EXISTS USER_ACHIEVEMENT_UNIQUE (USER_ID, ACHIEVEMENT);
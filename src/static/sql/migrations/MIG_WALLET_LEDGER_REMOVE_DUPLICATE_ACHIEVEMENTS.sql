@@ -0,0 +1,10 @@
+-- Removes achievement milestones that were paid more than once before
+-- MIG_WALLET_LEDGER_ADD_ACHIEVEMENT_UNIQUE, keeping the first payment, so the
+-- unique key can be added. Idempotent.
+DELETE WL
+FROM WALLET_LEDGER AS WL
+    INNER JOIN WALLET_LEDGER AS EARLIER ON EARLIER.USER_ID = WL.USER_ID
+        AND EARLIER.CATEGORY = WL.CATEGORY
+        AND EARLIER.REFERENCE = WL.REFERENCE
+        AND (EARLIER.CREATED_ON_DATE, EARLIER.ID) < (WL.CREATED_ON_DATE, WL.ID)
+WHERE WL.CATEGORY = 'ACHIEVEMENT';
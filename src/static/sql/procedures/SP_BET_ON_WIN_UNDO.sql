@@ -13,7 +13,7 @@ BEGIN
         SET BET_ON_WIN = 0
         WHERE PLAYER_ID = VAR_PLAYER_ID;
 
-        CALL SP_SPEND_CREDITS_UNDO(VAR_PLAYER_ID, VAR_BET_ON_WIN, 'BET');
+        CALL SP_SPEND_CREDITS_UNDO(VAR_PLAYER_ID, 'BET');
     END
     IF;
 END;
\ No newline at end of file
@@ -0,0 +1,62 @@
+CREATE
+OR REPLACE PROCEDURE SP_CJ_CATCH_UP_PLAYER(IN VAR_PLAYER_ID UUID)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+    DECLARE VAR_CATCH_UP_MODE VARCHAR(10);
+    DECLARE VAR_IS_STARTED BOOLEAN;
+    DECLARE VAR_POINTS INT DEFAULT 0;
+    DECLARE VAR_ROUNDS_PLAYED INT DEFAULT 0;
+
+    SELECT
+        CATCH_UP_MODE,
+        IS_STARTED
+    INTO
+        VAR_CATCH_UP_MODE,
+        VAR_IS_STARTED
+    FROM CJ_LOBBY_SETTINGS
+    WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+    -- ONLY PLAYERS WHO JOIN A GAME ALREADY UNDER WAY NEED TO CATCH UP
+    IF VAR_IS_STARTED AND VAR_CATCH_UP_MODE = 'POINTS' THEN
+        -- START LEVEL WITH THE LOWEST SCORE AT THE TABLE
+        SELECT
+            COALESCE(MIN(WINS), 0)
+        INTO
+            VAR_POINTS
+        FROM (
+                SELECT
+                    COUNT(W.ID) + CJPS.BONUS_POINTS AS WINS
+                FROM PLAYER AS P
+                    INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+                    LEFT JOIN WIN AS W ON W.PLAYER_ID = P.ID
+                WHERE P.LOBBY_ID = VAR_LOBBY_ID
+                    AND P.ID <> VAR_PLAYER_ID
+                    AND P.IS_ACTIVE = 1
+                    AND CJPS.IS_SPECTATOR = 0
+                GROUP BY P.ID,
+                    CJPS.BONUS_POINTS
+            ) AS PLAYER_WINS;
+
+        -- BONUS POINTS ARE NOT ROUND WINS, SO THEY CANNOT WIN THE GAME OR EARN COINS
+        UPDATE CJ_PLAYER_STATE
+        SET BONUS_POINTS = VAR_POINTS
+        WHERE PLAYER_ID = VAR_PLAYER_ID;
+    END
+    IF;
+
+    IF VAR_IS_STARTED AND VAR_CATCH_UP_MODE = 'CREDITS' THEN
+        -- ONE CREDIT FOR EVERY ROUND ALREADY WON
+        SELECT
+            COUNT(DISTINCT ROUND_ID)
+        INTO
+            VAR_ROUNDS_PLAYED
+        FROM V_ROUND_WINNER
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+        IF VAR_ROUNDS_PLAYED > 0 THEN
+            CALL SP_SPEND_CREDITS(VAR_PLAYER_ID, VAR_ROUNDS_PLAYED * -1, 'CATCH-UP');
+        END
+        IF;
+    END
+    IF;
+END;
\ No newline at end of file
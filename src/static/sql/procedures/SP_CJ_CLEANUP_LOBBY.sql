@@ -7,4 +7,28 @@ BEGIN
     DELETE
     FROM CARD
     WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+    -- Game winners earn ten coins for the wallet once the game is over.
+    INSERT INTO WALLET_LEDGER(USER_ID, AMOUNT, CATEGORY, REFERENCE)
+    SELECT DISTINCT
+        GW.USER_ID,
+        10,
+        'GAME-WIN',
+        VAR_LOBBY_ID
+    FROM V_GAME_WINNER AS GW
+    WHERE GW.LOBBY_ID = VAR_LOBBY_ID
+        AND NOT EXISTS(
+            SELECT
+                ID
+            FROM WALLET_LEDGER
+            WHERE USER_ID = GW.USER_ID
+                AND CATEGORY = 'GAME-WIN'
+                AND REFERENCE = VAR_LOBBY_ID
+        );
+
+    -- Bot accounts only exist to seat bots in this lobby, so they go with it.
+    DELETE U
+    FROM USER AS U
+        INNER JOIN BOT AS B ON B.USER_ID = U.ID
+    WHERE B.LOBBY_ID = VAR_LOBBY_ID;
 END;
@@ -1,9 +1,55 @@
 CREATE
 OR REPLACE PROCEDURE SP_CJ_INIT_LOBBY(IN VAR_LOBBY_ID UUID)
 BEGIN
-    INSERT INTO CJ_LOBBY_SETTINGS(LOBBY_ID)
-    VALUES (VAR_LOBBY_ID);
+    DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
+    DECLARE VAR_PLAYER_ID UUID;
 
-    INSERT INTO JUDGE(LOBBY_ID)
-    VALUES (VAR_LOBBY_ID);
-END;
+    DECLARE VAR_PLAYER_CURSOR CURSOR
+    FOR
+    SELECT
+        ID
+    FROM PLAYER
+    WHERE LOBBY_ID = VAR_LOBBY_ID
+        AND IS_ACTIVE = 1
+    ORDER BY JOIN_ORDER;
+
+    DECLARE CONTINUE HANDLER
+    FOR NOT FOUND
+    SET VAR_LOOP_DONE = TRUE;
+
+    IF NOT EXISTS(SELECT ID FROM JUDGE WHERE LOBBY_ID = VAR_LOBBY_ID) THEN
+        UPDATE CJ_LOBBY_SETTINGS
+        SET IS_STARTED = 1,
+            WRITE_PHASE_END = IF(
+                WRITE_PROMPT_COUNT > 0 OR WRITE_RESPONSE_COUNT > 0,
+                DATE_ADD(NOW(), INTERVAL WRITE_PHASE_MINUTES MINUTE),
+                NULL
+            )
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+        -- START BEFORE THE FIRST SEAT SO THE HOST JUDGES FIRST
+        INSERT INTO JUDGE(LOBBY_ID, POSITION)
+        VALUES (VAR_LOBBY_ID, 0);
+
+        -- DEAL EVERY SEATED PLAYER IN
+        OPEN VAR_PLAYER_CURSOR;
+
+            READ_LOOP: LOOP
+            FETCH VAR_PLAYER_CURSOR
+            INTO
+                VAR_PLAYER_ID;
+
+            IF VAR_LOOP_DONE THEN LEAVE READ_LOOP;
+            END
+            IF;
+
+            CALL SP_DRAW_HAND(VAR_PLAYER_ID);
+            END LOOP;
+        CLOSE VAR_PLAYER_CURSOR;
+
+        CALL SP_SET_MISSING_JUDGE_PLAYER(VAR_LOBBY_ID);
+        CALL SP_SET_MISSING_JUDGE_CARD(VAR_LOBBY_ID);
+        CALL SP_SET_RESPONSES_LOBBY(VAR_LOBBY_ID);
+    END
+    IF;
+END;
\ No newline at end of file
@@ -6,6 +6,7 @@ BEGIN
     INSERT INTO CJ_PLAYER_STATE(PLAYER_ID)
     VALUES (VAR_PLAYER_ID);
 
+    CALL SP_CJ_CATCH_UP_PLAYER(VAR_PLAYER_ID);
     CALL SP_DRAW_HAND(VAR_PLAYER_ID);
     CALL SP_SET_MISSING_JUDGE_PLAYER(VAR_LOBBY_ID);
     CALL SP_SET_MISSING_JUDGE_CARD(VAR_LOBBY_ID);
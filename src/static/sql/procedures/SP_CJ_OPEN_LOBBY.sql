@@ -0,0 +1,29 @@
+CREATE
+OR REPLACE PROCEDURE SP_CJ_OPEN_LOBBY(IN VAR_LOBBY_ID UUID)
+BEGIN
+    -- THE GAME ITSELF IS SET UP BY SP_CJ_INIT_LOBBY ONCE THE HOST STARTS IT
+    INSERT INTO CJ_LOBBY_SETTINGS(LOBBY_ID)
+    VALUES (VAR_LOBBY_ID);
+
+    -- START FROM THE DEFAULT PRICES, THE HOST CAN CHANGE THEM LATER
+    INSERT INTO CJ_LOBBY_ECONOMY(LOBBY_ID, CATEGORY, AMOUNT)
+    VALUES
+        (VAR_LOBBY_ID, 'WINNING-STREAK', FN_GET_DEFAULT_ECONOMY_VALUE('WINNING-STREAK')),
+        (VAR_LOBBY_ID, 'LOSING-STREAK', FN_GET_DEFAULT_ECONOMY_VALUE('LOSING-STREAK')),
+        (VAR_LOBBY_ID, 'PURCHASE', FN_GET_DEFAULT_ECONOMY_VALUE('PURCHASE')),
+        (VAR_LOBBY_ID, 'SKIP-JUDGE', FN_GET_DEFAULT_ECONOMY_VALUE('SKIP-JUDGE')),
+        (VAR_LOBBY_ID, 'EXTRA-RESPONSE', FN_GET_DEFAULT_ECONOMY_VALUE('EXTRA-RESPONSE')),
+        (VAR_LOBBY_ID, 'BLOCK-RESPONSE', FN_GET_DEFAULT_ECONOMY_VALUE('BLOCK-RESPONSE')),
+        (VAR_LOBBY_ID, 'STEAL', FN_GET_DEFAULT_ECONOMY_VALUE('STEAL')),
+        (VAR_LOBBY_ID, 'STEAL-VICTIM', FN_GET_DEFAULT_ECONOMY_VALUE('STEAL-VICTIM')),
+        (VAR_LOBBY_ID, 'SURPRISE', FN_GET_DEFAULT_ECONOMY_VALUE('SURPRISE')),
+        (VAR_LOBBY_ID, 'FIND', FN_GET_DEFAULT_ECONOMY_VALUE('FIND')),
+        (VAR_LOBBY_ID, 'WILD', FN_GET_DEFAULT_ECONOMY_VALUE('WILD')),
+        (VAR_LOBBY_ID, 'PERK', FN_GET_DEFAULT_ECONOMY_VALUE('PERK')),
+        (VAR_LOBBY_ID, 'SWAP', FN_GET_DEFAULT_ECONOMY_VALUE('SWAP')),
+        (VAR_LOBBY_ID, 'VETO', FN_GET_DEFAULT_ECONOMY_VALUE('VETO')),
+        (VAR_LOBBY_ID, 'MULLIGAN', FN_GET_DEFAULT_ECONOMY_VALUE('MULLIGAN')),
+        (VAR_LOBBY_ID, 'BET-WIN', FN_GET_DEFAULT_ECONOMY_VALUE('BET-WIN')),
+        (VAR_LOBBY_ID, 'GAMBLE-WIN', FN_GET_DEFAULT_ECONOMY_VALUE('GAMBLE-WIN')),
+        (VAR_LOBBY_ID, 'GAMBLE-CHANCE', FN_GET_DEFAULT_ECONOMY_VALUE('GAMBLE-CHANCE'));
+END;
\ No newline at end of file
@@ -1,6 +1,11 @@
 CREATE
 OR REPLACE PROCEDURE SP_CJ_PLAYER_ACTIVE(IN VAR_PLAYER_ID UUID)
 BEGIN
+    -- COMING BACK CLEARS ANY ROUNDS MISSED BEFORE LEAVING
+    UPDATE CJ_PLAYER_STATE
+    SET MISSED_ROUNDS = 0
+    WHERE PLAYER_ID = VAR_PLAYER_ID;
+
     CALL SP_DRAW_HAND(VAR_PLAYER_ID);
     CALL SP_SET_RESPONSES_PLAYER(VAR_PLAYER_ID);
-END;
+END;
\ No newline at end of file
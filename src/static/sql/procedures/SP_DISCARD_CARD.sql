@@ -25,6 +25,14 @@ BEGIN
         WHERE PLAYER_ID = VAR_PLAYER_ID
             AND CARD_ID = VAR_CARD_ID;
 
+        INSERT IGNORE INTO DISCARD_PILE(LOBBY_ID, ROUND_ID, CARD_ID)
+        SELECT
+            LOBBY_ID,
+            ROUND_ID,
+            VAR_CARD_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+
         CALL SP_DRAW_HAND(VAR_PLAYER_ID);
 
         INSERT INTO LOG_DISCARD(LOBBY_ID, USER_ID, CARD_ID)
@@ -0,0 +1,36 @@
+CREATE
+OR REPLACE PROCEDURE SP_DISCARD_ROUND(IN VAR_LOBBY_ID UUID)
+BEGIN
+    DECLARE VAR_ROUND_ID UUID DEFAULT (
+            SELECT
+                ROUND_ID
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    -- PLAYED DECK AND WRITTEN CARDS, WILD AND FREE-TEXT CARDS ARE DELETED
+    INSERT IGNORE INTO DISCARD_PILE(LOBBY_ID, ROUND_ID, CARD_ID)
+    SELECT
+        P.LOBBY_ID AS LOBBY_ID,
+        VAR_ROUND_ID AS ROUND_ID,
+        RC.CARD_ID AS CARD_ID
+    FROM RESPONSE_CARD AS RC
+        INNER JOIN RESPONSE AS R ON R.ID = RC.RESPONSE_ID
+        INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
+        INNER JOIN CARD AS C ON C.ID = RC.CARD_ID
+        LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+    WHERE P.LOBBY_ID = VAR_LOBBY_ID
+        AND (C.LOBBY_ID IS NULL OR WC.ID IS NOT NULL);
+
+    -- THE PROMPT, UNLESS IT WAS A CUSTOM ONE
+    INSERT IGNORE INTO DISCARD_PILE(LOBBY_ID, ROUND_ID, CARD_ID)
+    SELECT
+        J.LOBBY_ID AS LOBBY_ID,
+        VAR_ROUND_ID AS ROUND_ID,
+        J.CARD_ID AS CARD_ID
+    FROM JUDGE AS J
+        INNER JOIN CARD AS C ON C.ID = J.CARD_ID
+        LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+    WHERE J.LOBBY_ID = VAR_LOBBY_ID
+        AND (C.LOBBY_ID IS NULL OR WC.ID IS NOT NULL);
+END;
\ No newline at end of file
@@ -7,15 +7,6 @@ BEGIN
     DECLARE VAR_CURRENT_HAND_SIZE INT;
     DECLARE VAR_CARD_ID UUID;
 
-    SELECT
-        COUNT(C.ID)
-    INTO
-        VAR_LOBBY_DRAW_PILE_SIZE
-    FROM DRAW_PILE AS DP
-        INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
-    WHERE C.CATEGORY = 'RESPONSE'
-        AND DP.LOBBY_ID = VAR_LOBBY_ID;
-
     SET VAR_FINAL_HAND_SIZE = (
             SELECT
                 HAND_SIZE
@@ -24,6 +15,43 @@ BEGIN
         ) +
     (SELECT HAND_SIZE_ADVANTAGE FROM CJ_PLAYER_STATE WHERE PLAYER_ID = VAR_PLAYER_ID);
 
+    -- FREE-TEXT LOBBIES CAN PLAY WITHOUT HANDS
+    IF EXISTS(
+        SELECT
+            LOBBY_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND FREE_TEXT_MODE = 1
+            AND FREE_TEXT_HANDS = 0
+    ) THEN
+        SET VAR_FINAL_HAND_SIZE = 0;
+    END
+    IF;
+
+    -- NO HANDS ARE DEALT UNTIL THE GAME STARTS
+    IF EXISTS(
+        SELECT
+            LOBBY_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND IS_STARTED = 0
+    ) THEN
+        SET VAR_FINAL_HAND_SIZE = 0;
+    END
+    IF;
+
+    -- SPECTATORS DO NOT HOLD A HAND
+    IF EXISTS(
+        SELECT
+            PLAYER_ID
+        FROM CJ_PLAYER_STATE
+        WHERE PLAYER_ID = VAR_PLAYER_ID
+            AND IS_SPECTATOR = 1
+    ) THEN
+        SET VAR_FINAL_HAND_SIZE = 0;
+    END
+    IF;
+
     SELECT
         COUNT(CARD_ID)
     INTO
@@ -31,6 +59,20 @@ BEGIN
     FROM HAND
     WHERE PLAYER_ID = VAR_PLAYER_ID;
 
+    IF VAR_FINAL_HAND_SIZE > VAR_CURRENT_HAND_SIZE THEN
+        CALL SP_RESHUFFLE_DISCARD_PILE(VAR_LOBBY_ID, 'RESPONSE');
+    END
+    IF;
+
+    SELECT
+        COUNT(C.ID)
+    INTO
+        VAR_LOBBY_DRAW_PILE_SIZE
+    FROM DRAW_PILE AS DP
+        INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
+    WHERE C.CATEGORY = 'RESPONSE'
+        AND DP.LOBBY_ID = VAR_LOBBY_ID;
+
     WHILE VAR_LOBBY_DRAW_PILE_SIZE > 0
         AND VAR_FINAL_HAND_SIZE > VAR_CURRENT_HAND_SIZE
     DO
@@ -44,6 +86,8 @@ BEGIN
         WHERE LOBBY_ID = VAR_LOBBY_ID
             AND CARD_ID = VAR_CARD_ID;
 
+        CALL SP_RESHUFFLE_DISCARD_PILE(VAR_LOBBY_ID, 'RESPONSE');
+
         SELECT
             COUNT(C.ID)
         INTO
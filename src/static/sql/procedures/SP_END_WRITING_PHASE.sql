@@ -0,0 +1,73 @@
+CREATE
+OR REPLACE PROCEDURE SP_END_WRITING_PHASE(IN VAR_LOBBY_ID UUID)
+BEGIN
+    DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
+    DECLARE VAR_PLAYER_ID UUID;
+
+    DECLARE VAR_PLAYER_CURSOR CURSOR
+    FOR
+    SELECT
+        ID
+    FROM PLAYER
+    WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+    DECLARE CONTINUE HANDLER
+    FOR NOT FOUND
+    SET VAR_LOOP_DONE = TRUE;
+
+    IF EXISTS(
+        SELECT
+            LOBBY_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND WRITE_PHASE_END IS NOT NULL
+    ) THEN
+        UPDATE CJ_LOBBY_SETTINGS
+        SET WRITE_PHASE_END = NULL
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+        CALL SP_WITHDRAW_LOBBY(VAR_LOBBY_ID);
+
+        -- RETURN EVERYTHING DEALT DURING THE PHASE SO THE WRITTEN CARDS ARE MIXED IN
+        INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+        SELECT
+            P.LOBBY_ID AS LOBBY_ID,
+            H.CARD_ID AS CARD_ID
+        FROM HAND AS H
+            INNER JOIN PLAYER AS P ON P.ID = H.PLAYER_ID
+        WHERE P.LOBBY_ID = VAR_LOBBY_ID;
+
+        DELETE H
+        FROM HAND AS H
+            INNER JOIN PLAYER AS P ON P.ID = H.PLAYER_ID
+        WHERE P.LOBBY_ID = VAR_LOBBY_ID;
+
+        INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+        SELECT
+            J.LOBBY_ID AS LOBBY_ID,
+            J.CARD_ID AS CARD_ID
+        FROM JUDGE AS J
+            INNER JOIN CARD AS C ON C.ID = J.CARD_ID
+            LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+        WHERE J.LOBBY_ID = VAR_LOBBY_ID
+            AND (C.LOBBY_ID IS NULL OR WC.ID IS NOT NULL);
+
+        CALL SP_SET_NEXT_JUDGE_CARD(VAR_LOBBY_ID);
+
+        OPEN VAR_PLAYER_CURSOR;
+
+            READ_LOOP: LOOP
+            FETCH VAR_PLAYER_CURSOR
+            INTO
+                VAR_PLAYER_ID;
+
+            IF VAR_LOOP_DONE THEN LEAVE READ_LOOP;
+            END
+            IF;
+
+            CALL SP_DRAW_HAND(VAR_PLAYER_ID);
+            END LOOP;
+        CLOSE VAR_PLAYER_CURSOR;
+    END
+    IF;
+END;
\ No newline at end of file
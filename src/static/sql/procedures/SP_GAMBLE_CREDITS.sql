@@ -1,15 +1,16 @@
 CREATE
 OR REPLACE PROCEDURE SP_GAMBLE_CREDITS(IN VAR_PLAYER_ID UUID, IN VAR_AMOUNT INT)
 BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
     DECLARE VAR_GAMBLE_WON BOOLEAN DEFAULT FALSE;
     CALL SP_SPEND_CREDITS(VAR_PLAYER_ID, VAR_AMOUNT, 'GAMBLE');
 
-    IF RAND() > 0.55 THEN
+    IF RAND() * 100 < FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'GAMBLE-CHANCE') THEN
         SET VAR_GAMBLE_WON = TRUE;
 
         CALL SP_SPEND_CREDITS(
                 VAR_PLAYER_ID,
-                (VAR_AMOUNT * 2) * -1,
+                (VAR_AMOUNT * FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'GAMBLE-WIN')) * -1,
                 'GAMBLE-WIN'
             );
     END
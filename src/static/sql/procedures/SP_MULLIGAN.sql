@@ -0,0 +1,31 @@
+CREATE
+OR REPLACE PROCEDURE SP_MULLIGAN(IN VAR_PLAYER_ID UUID)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+
+    -- THE HAND IS NOT LOGGED AS DISCARDS, THE PLAYER DID NOT PICK THE CARDS
+    -- OUT AS BAD ONES
+    INSERT IGNORE INTO DISCARD_PILE(LOBBY_ID, ROUND_ID, CARD_ID)
+    SELECT
+        CJLS.LOBBY_ID,
+        CJLS.ROUND_ID,
+        H.CARD_ID
+    FROM HAND AS H
+        INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = VAR_LOBBY_ID
+    WHERE H.PLAYER_ID = VAR_PLAYER_ID;
+
+    DELETE
+    FROM HAND
+    WHERE PLAYER_ID = VAR_PLAYER_ID;
+
+    CALL SP_DRAW_HAND(VAR_PLAYER_ID);
+
+    CALL SP_SPEND_CREDITS(
+            VAR_PLAYER_ID,
+            (
+                FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'MULLIGAN') +
+                    FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
+            ),
+            'MULLIGAN'
+        );
+END;
\ No newline at end of file
@@ -0,0 +1,27 @@
+CREATE
+OR REPLACE PROCEDURE SP_PAUSE_LOBBY(
+    IN VAR_LOBBY_ID UUID,
+    IN VAR_USER_ID UUID
+)
+BEGIN
+    IF EXISTS(
+        SELECT
+            LOBBY_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND PAUSED_ON_DATE IS NULL
+    ) THEN
+        UPDATE CJ_LOBBY_SETTINGS
+        SET PAUSED_ON_DATE = NOW()
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+        INSERT INTO LOG_PAUSE(LOBBY_ID, ROUND_ID, USER_ID)
+        SELECT
+            LOBBY_ID,
+            ROUND_ID,
+            VAR_USER_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+    END
+    IF;
+END;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE
+OR REPLACE PROCEDURE SP_PAY_ACHIEVEMENT_REWARD(
+    IN VAR_USER_ID UUID,
+    IN VAR_REFERENCE VARCHAR(255),
+    IN VAR_REWARD INT
+)
+BEGIN
+    -- THE UNIQUE KEY ON ACHIEVEMENT ROWS KEEPS TWO REQUESTS FROM BOTH PAYING
+    -- THE SAME MILESTONE
+    INSERT IGNORE INTO WALLET_LEDGER(USER_ID, AMOUNT, CATEGORY, REFERENCE)
+    VALUES (VAR_USER_ID, VAR_REWARD, 'ACHIEVEMENT', VAR_REFERENCE);
+
+    SELECT ROW_COUNT() > 0 AS IS_PAID;
+END;
\ No newline at end of file
@@ -10,7 +10,7 @@ BEGIN
     CALL SP_SPEND_CREDITS(
             VAR_PLAYER_ID,
             (
-                FN_GET_SPECIAL_COST('PERK') +
+                FN_GET_ECONOMY_VALUE(FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID), 'PERK') +
                     FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
             ),
             'PERK'
@@ -0,0 +1,45 @@
+CREATE
+OR REPLACE PROCEDURE SP_PICK_PROMPT_CANDIDATE(
+    IN VAR_LOBBY_ID UUID,
+    IN VAR_CARD_ID UUID
+)
+BEGIN
+    IF EXISTS(
+        SELECT
+            ID
+        FROM PROMPT_CANDIDATE
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND CARD_ID = VAR_CARD_ID
+    ) THEN
+        INSERT INTO LOG_PROMPT_PICK(LOBBY_ID, USER_ID, CARD_ID, CANDIDATE_COUNT)
+        SELECT
+            P.LOBBY_ID,
+            P.USER_ID,
+            VAR_CARD_ID,
+            (
+                SELECT
+                    COUNT(*)
+                FROM PROMPT_CANDIDATE
+                WHERE LOBBY_ID = VAR_LOBBY_ID
+            )
+        FROM PLAYER AS P
+        WHERE P.LOBBY_ID = VAR_LOBBY_ID
+            AND P.ID = FN_GET_LOBBY_JUDGE_PLAYER_ID(VAR_LOBBY_ID);
+
+        -- UNCHOSEN CANDIDATES GO BACK WITHOUT COUNTING AS A SKIP
+        INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+        SELECT
+            LOBBY_ID,
+            CARD_ID
+        FROM PROMPT_CANDIDATE
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND CARD_ID <> VAR_CARD_ID;
+
+        DELETE
+        FROM PROMPT_CANDIDATE
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+        CALL SP_SET_JUDGE_CARD(VAR_LOBBY_ID, VAR_CARD_ID);
+    END
+    IF;
+END;
\ No newline at end of file
@@ -21,18 +21,63 @@ BEGIN
     IF(VAR_PLAYER_BET_ON_WIN > 0) THEN
         CALL SP_SPEND_CREDITS(
                 VAR_PLAYER_ID,
-                (VAR_PLAYER_BET_ON_WIN * 2) * -1,
+                (VAR_PLAYER_BET_ON_WIN * FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'BET-WIN')) * -1,
                 'BET-WIN'
             );
     END
     IF;
 
+    CALL SP_SETTLE_SIDE_BETS(VAR_RESPONSE_ID);
+
     INSERT INTO WIN(PLAYER_ID)
     VALUES (VAR_PLAYER_ID);
 
+    -- EVERY ROUND WIN EARNS ONE COIN FOR THE WALLET
+    INSERT INTO WALLET_LEDGER(USER_ID, AMOUNT, CATEGORY, REFERENCE)
+    SELECT
+        USER_ID,
+        1,
+        'ROUND-WIN',
+        VAR_LOBBY_ID
+    FROM PLAYER
+    WHERE ID = VAR_PLAYER_ID;
+
     INSERT INTO LOG_WIN(RESPONSE_ID)
     VALUES (VAR_RESPONSE_ID);
 
+    -- CAPTION CONTEST WINNERS GO TO THE GALLERY
+    IF EXISTS(
+        SELECT
+            LOBBY_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND CAPTION_MODE = 1
+    ) THEN
+        INSERT INTO LOG_CAPTION_WIN(
+            LOBBY_ID,
+            JUDGE_CARD_ID,
+            PLAYER_USER_ID,
+            CAPTION_TEXT
+        )
+        SELECT
+            P.LOBBY_ID AS LOBBY_ID,
+            J.CARD_ID AS JUDGE_CARD_ID,
+            P.USER_ID AS PLAYER_USER_ID,
+            GROUP_CONCAT(
+                C.TEXT
+                ORDER BY RC.CREATED_ON_DATE SEPARATOR ' / '
+            ) AS CAPTION_TEXT
+        FROM RESPONSE AS R
+            INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
+            INNER JOIN JUDGE AS J ON J.LOBBY_ID = P.LOBBY_ID
+            INNER JOIN RESPONSE_CARD AS RC ON RC.RESPONSE_ID = R.ID
+            INNER JOIN CARD AS C ON C.ID = RC.CARD_ID
+        WHERE R.ID = VAR_RESPONSE_ID
+            AND J.CARD_ID IS NOT NULL
+        GROUP BY R.ID;
+    END
+    IF;
+
     CALL SP_SET_WINNING_STREAK(VAR_PLAYER_ID);
     CALL SP_SET_LOSING_STREAK(VAR_PLAYER_ID);
     CALL SP_START_NEW_ROUND(VAR_LOBBY_ID);
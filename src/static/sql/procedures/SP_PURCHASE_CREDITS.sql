@@ -31,7 +31,7 @@ BEGIN
         CALL SP_SPEND_CREDITS(
                 VAR_OTHER_PLAYER_ID,
                 (
-                    FN_GET_SPECIAL_COST('PURCHASE') +
+                    FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'PURCHASE') +
                     FN_GET_PLAYER_HANDICAP_INVERSE(VAR_OTHER_PLAYER_ID)
                 ) * -1,
                 'PURCHASE'
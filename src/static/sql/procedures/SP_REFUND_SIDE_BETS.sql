@@ -0,0 +1,38 @@
+CREATE
+OR REPLACE PROCEDURE SP_REFUND_SIDE_BETS(IN VAR_LOBBY_ID UUID)
+BEGIN
+    DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
+    DECLARE VAR_BET_PLAYER_ID UUID;
+
+    DECLARE VAR_BET_CURSOR CURSOR
+    FOR
+    SELECT
+        SB.PLAYER_ID
+    FROM SIDE_BET AS SB
+        INNER JOIN PLAYER AS P ON P.ID = SB.PLAYER_ID
+    WHERE P.LOBBY_ID = VAR_LOBBY_ID;
+
+    DECLARE CONTINUE HANDLER
+    FOR NOT FOUND
+    SET VAR_LOOP_DONE = TRUE;
+
+    OPEN VAR_BET_CURSOR;
+
+        READ_LOOP: LOOP
+        FETCH VAR_BET_CURSOR
+        INTO
+            VAR_BET_PLAYER_ID;
+
+        IF VAR_LOOP_DONE THEN LEAVE READ_LOOP;
+        END
+        IF;
+
+        CALL SP_SPEND_CREDITS_UNDO(VAR_BET_PLAYER_ID, 'SIDE-BET');
+        END LOOP;
+    CLOSE VAR_BET_CURSOR;
+
+    DELETE SB
+    FROM SIDE_BET AS SB
+        INNER JOIN PLAYER AS P ON P.ID = SB.PLAYER_ID
+    WHERE P.LOBBY_ID = VAR_LOBBY_ID;
+END;
\ No newline at end of file
@@ -0,0 +1,59 @@
+CREATE
+OR REPLACE PROCEDURE SP_RESHUFFLE_DISCARD_PILE(
+    IN VAR_LOBBY_ID UUID,
+    IN VAR_CATEGORY ENUM('PROMPT', 'RESPONSE')
+)
+BEGIN
+    DECLARE VAR_EXCLUDE_ROUNDS INT DEFAULT (
+            SELECT
+                RESHUFFLE_EXCLUDE_ROUNDS
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+    DECLARE VAR_CARD_COUNT INT DEFAULT 0;
+
+    -- ONLY RESHUFFLE ONCE THE DRAW PILE HAS RUN OUT
+    IF NOT EXISTS(
+        SELECT
+            DP.ID
+        FROM DRAW_PILE AS DP
+            INNER JOIN CARD AS C ON C.ID = DP.CARD_ID
+        WHERE DP.LOBBY_ID = VAR_LOBBY_ID
+            AND C.CATEGORY = VAR_CATEGORY
+    ) THEN
+        -- CARDS FROM THE MOST RECENT ROUNDS STAY IN THE DISCARD PILE
+        INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+        SELECT
+            DSP.LOBBY_ID,
+            DSP.CARD_ID
+        FROM DISCARD_PILE AS DSP
+            INNER JOIN CARD AS C ON C.ID = DSP.CARD_ID
+            LEFT JOIN (
+                SELECT
+                    ROUND_ID
+                FROM DISCARD_PILE
+                WHERE LOBBY_ID = VAR_LOBBY_ID
+                GROUP BY ROUND_ID
+                ORDER BY MAX(CREATED_ON_DATE) DESC
+                LIMIT VAR_EXCLUDE_ROUNDS
+            ) AS RR ON RR.ROUND_ID = DSP.ROUND_ID
+        WHERE DSP.LOBBY_ID = VAR_LOBBY_ID
+            AND C.CATEGORY = VAR_CATEGORY
+            AND RR.ROUND_ID IS NULL;
+
+        SET VAR_CARD_COUNT = ROW_COUNT();
+
+        IF VAR_CARD_COUNT > 0 THEN
+            DELETE DSP
+            FROM DISCARD_PILE AS DSP
+                INNER JOIN DRAW_PILE AS DP ON DP.LOBBY_ID = DSP.LOBBY_ID
+                AND DP.CARD_ID = DSP.CARD_ID
+            WHERE DSP.LOBBY_ID = VAR_LOBBY_ID;
+
+            INSERT INTO LOG_RESHUFFLE(LOBBY_ID, CATEGORY, CARD_COUNT)
+            VALUES (VAR_LOBBY_ID, VAR_CATEGORY, VAR_CARD_COUNT);
+        END
+        IF;
+    END
+    IF;
+END;
\ No newline at end of file
@@ -27,48 +27,57 @@ BEGIN
             LIMIT 1
         );
 
-    INSERT INTO RESPONSE_CARD(ID, RESPONSE_ID, CARD_ID, SPECIAL_CATEGORY)
-    VALUES (
-        VAR_RESPONSE_CARD_ID,
-        VAR_RESPONSE_ID,
-        VAR_CARD_ID,
-        VAR_SPECIAL_CATEGORY
-    );
+    -- THERE IS NOTHING TO RESPOND TO WHILE THE JUDGE IS CHOOSING THE PROMPT
+    IF NOT EXISTS(
+        SELECT
+            ID
+        FROM PROMPT_CANDIDATE
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+    ) THEN
+        INSERT INTO RESPONSE_CARD(ID, RESPONSE_ID, CARD_ID, SPECIAL_CATEGORY)
+        VALUES (
+            VAR_RESPONSE_CARD_ID,
+            VAR_RESPONSE_ID,
+            VAR_CARD_ID,
+            VAR_SPECIAL_CATEGORY
+        );
 
-    INSERT INTO LOG_RESPONSE_CARD(
-        LOBBY_ID,
-        ROUND_ID,
-        RESPONSE_ID,
-        RESPONSE_CARD_ID,
-        JUDGE_USER_ID,
-        JUDGE_CARD_ID,
-        PLAYER_USER_ID,
-        PLAYER_CARD_ID,
-        SPECIAL_CATEGORY
-    )
-    SELECT
-        L.ID AS LOBBY_ID,
-        CJLS.ROUND_ID AS ROUND_ID,
-        R.ID AS RESPONSE_ID,
-        RC.ID AS RESPONSE_CARD_ID,
-        JP.USER_ID AS JUDGE_USER_ID,
-        J.CARD_ID AS JUDGE_CARD_ID,
-        P.USER_ID AS PLAYER_USER_ID,
-        RC.CARD_ID AS PLAYER_CARD_ID,
-        RC.SPECIAL_CATEGORY AS SPECIAL_CATEGORY
-    FROM RESPONSE_CARD AS RC
-        INNER JOIN RESPONSE AS R ON R.ID = RC.RESPONSE_ID
-        INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
-        INNER JOIN LOBBY AS L ON L.ID = P.LOBBY_ID
-        INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = L.ID
-        INNER JOIN JUDGE AS J ON J.LOBBY_ID = L.ID
-        INNER JOIN PLAYER AS JP ON JP.ID = J.PLAYER_ID
-    WHERE RC.ID = VAR_RESPONSE_CARD_ID;
+        INSERT INTO LOG_RESPONSE_CARD(
+            LOBBY_ID,
+            ROUND_ID,
+            RESPONSE_ID,
+            RESPONSE_CARD_ID,
+            JUDGE_USER_ID,
+            JUDGE_CARD_ID,
+            PLAYER_USER_ID,
+            PLAYER_CARD_ID,
+            SPECIAL_CATEGORY
+        )
+        SELECT
+            L.ID AS LOBBY_ID,
+            CJLS.ROUND_ID AS ROUND_ID,
+            R.ID AS RESPONSE_ID,
+            RC.ID AS RESPONSE_CARD_ID,
+            JP.USER_ID AS JUDGE_USER_ID,
+            J.CARD_ID AS JUDGE_CARD_ID,
+            P.USER_ID AS PLAYER_USER_ID,
+            RC.CARD_ID AS PLAYER_CARD_ID,
+            RC.SPECIAL_CATEGORY AS SPECIAL_CATEGORY
+        FROM RESPONSE_CARD AS RC
+            INNER JOIN RESPONSE AS R ON R.ID = RC.RESPONSE_ID
+            INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
+            INNER JOIN LOBBY AS L ON L.ID = P.LOBBY_ID
+            INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = L.ID
+            INNER JOIN JUDGE AS J ON J.LOBBY_ID = L.ID
+            INNER JOIN PLAYER AS JP ON JP.ID = J.PLAYER_ID
+        WHERE RC.ID = VAR_RESPONSE_CARD_ID;
 
-    DELETE
-    FROM HAND
-    WHERE PLAYER_ID = VAR_PLAYER_ID
-        AND CARD_ID = VAR_CARD_ID;
+        DELETE
+        FROM HAND
+        WHERE PLAYER_ID = VAR_PLAYER_ID
+            AND CARD_ID = VAR_CARD_ID;
 
-    CALL SP_DRAW_HAND(VAR_PLAYER_ID);
+        CALL SP_DRAW_HAND(VAR_PLAYER_ID);
+    END
+    IF;
 END;
\ No newline at end of file
@@ -21,7 +21,7 @@ BEGIN
         CALL SP_SPEND_CREDITS(
                 VAR_PLAYER_ID,
                 (
-                    FN_GET_SPECIAL_COST('FIND') +
+                    FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'FIND') +
                         FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
                 ),
                 'FIND'
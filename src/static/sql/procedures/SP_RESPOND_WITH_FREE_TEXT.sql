@@ -0,0 +1,21 @@
+CREATE
+OR REPLACE PROCEDURE SP_RESPOND_WITH_FREE_TEXT(
+    IN VAR_PLAYER_ID UUID,
+    IN VAR_CARD_TEXT VARCHAR(255)
+)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+    DECLARE VAR_CARD_ID UUID DEFAULT UUID();
+
+    -- Free-text answers are per-lobby ephemeral cards, like wild cards, but
+    -- cost nothing and are not a special.
+    INSERT INTO CARD(ID, LOBBY_ID, CATEGORY, TEXT)
+    VALUES (
+        VAR_CARD_ID,
+        VAR_LOBBY_ID,
+        'RESPONSE',
+        VAR_CARD_TEXT
+    );
+
+    CALL SP_RESPOND_WITH_CARD(VAR_PLAYER_ID, VAR_CARD_ID, NULL);
+END;
@@ -29,7 +29,7 @@ BEGIN
     CALL SP_SPEND_CREDITS(
             VAR_PLAYER_ID,
             (
-                FN_GET_SPECIAL_COST('STEAL') +
+                FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'STEAL') +
                     FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
             ),
             'STEAL'
@@ -38,7 +38,7 @@ BEGIN
     CALL SP_SPEND_CREDITS(
             VAR_VICTIM_PLAYER_ID,
             (
-                FN_GET_SPECIAL_COST('STEAL-VICTIM') +
+                FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'STEAL-VICTIM') +
                 FN_GET_PLAYER_HANDICAP_INVERSE(VAR_VICTIM_PLAYER_ID)
             ) * -1,
             'STEAL-VICTIM'
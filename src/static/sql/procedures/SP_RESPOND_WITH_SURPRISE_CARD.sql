@@ -3,10 +3,11 @@ OR REPLACE PROCEDURE SP_RESPOND_WITH_SURPRISE_CARD(IN VAR_PLAYER_ID UUID)
 BEGIN
     DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
 
-    DECLARE VAR_CARD_ID UUID DEFAULT FN_GET_DRAW_PILE_CARD_ID(
-            'RESPONSE',
-            VAR_LOBBY_ID
-        );
+    DECLARE VAR_CARD_ID UUID;
+
+    CALL SP_RESHUFFLE_DISCARD_PILE(VAR_LOBBY_ID, 'RESPONSE');
+
+    SET VAR_CARD_ID = FN_GET_DRAW_PILE_CARD_ID('RESPONSE', VAR_LOBBY_ID);
 
     DELETE
     FROM DRAW_PILE
@@ -16,7 +17,7 @@ BEGIN
     CALL SP_SPEND_CREDITS(
             VAR_PLAYER_ID,
             (
-                FN_GET_SPECIAL_COST('SURPRISE') +
+                FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'SURPRISE') +
                     FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
             ),
             'SURPRISE'
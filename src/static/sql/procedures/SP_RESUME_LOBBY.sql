@@ -0,0 +1,25 @@
+CREATE
+OR REPLACE PROCEDURE SP_RESUME_LOBBY(IN VAR_LOBBY_ID UUID)
+BEGIN
+    DECLARE VAR_PAUSED_SECONDS INT DEFAULT (
+            SELECT
+                TIMESTAMPDIFF(SECOND, PAUSED_ON_DATE, NOW())
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    IF VAR_PAUSED_SECONDS IS NOT NULL THEN
+        -- THE WRITING PHASE DEADLINE DOES NOT RUN WHILE PAUSED
+        UPDATE CJ_LOBBY_SETTINGS
+        SET PAUSED_ON_DATE = NULL,
+            WRITE_PHASE_END = DATE_ADD(WRITE_PHASE_END, INTERVAL VAR_PAUSED_SECONDS SECOND)
+        WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+        UPDATE LOG_PAUSE
+        SET RESUMED_ON_DATE = NOW(),
+            DURATION_SECONDS = VAR_PAUSED_SECONDS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND RESUMED_ON_DATE IS NULL;
+    END
+    IF;
+END;
\ No newline at end of file
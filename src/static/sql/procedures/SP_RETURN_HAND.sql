@@ -0,0 +1,16 @@
+CREATE
+OR REPLACE PROCEDURE SP_RETURN_HAND(IN VAR_PLAYER_ID UUID)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+
+    INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+    SELECT
+        VAR_LOBBY_ID AS LOBBY_ID,
+        CARD_ID
+    FROM HAND
+    WHERE PLAYER_ID = VAR_PLAYER_ID;
+
+    DELETE
+    FROM HAND
+    WHERE PLAYER_ID = VAR_PLAYER_ID;
+END;
\ No newline at end of file
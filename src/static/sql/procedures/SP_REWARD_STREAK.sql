@@ -0,0 +1,140 @@
+CREATE
+OR REPLACE PROCEDURE SP_REWARD_STREAK(
+    IN VAR_PLAYER_ID UUID,
+    IN VAR_STREAK ENUM('WINNING', 'LOSING'),
+    IN VAR_LENGTH INT
+)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+
+    DECLARE VAR_ROUND_ID UUID DEFAULT (
+            SELECT
+                ROUND_ID
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
+    DECLARE VAR_REWARD VARCHAR(20);
+    DECLARE VAR_AMOUNT INT;
+    DECLARE VAR_CARD_ID UUID;
+    DECLARE VAR_CARDS_DRAWN INT;
+
+    DECLARE VAR_RULE_CURSOR CURSOR
+    FOR
+    SELECT
+        REWARD,
+        AMOUNT
+    FROM CJ_LOBBY_STREAK_RULE
+    WHERE LOBBY_ID = VAR_LOBBY_ID
+        AND STREAK = VAR_STREAK
+        AND LENGTH = VAR_LENGTH;
+
+    DECLARE CONTINUE HANDLER
+    FOR NOT FOUND
+    SET VAR_LOOP_DONE = TRUE;
+
+    OPEN VAR_RULE_CURSOR;
+
+        READ_LOOP: LOOP
+        FETCH VAR_RULE_CURSOR
+        INTO
+            VAR_REWARD,
+            VAR_AMOUNT;
+
+        IF VAR_LOOP_DONE THEN LEAVE READ_LOOP;
+        END
+        IF;
+
+        IF VAR_REWARD = 'CREDITS' THEN
+            CALL SP_SPEND_CREDITS(VAR_PLAYER_ID, VAR_AMOUNT * -1, 'STREAK-REWARD');
+        END
+        IF;
+
+        -- EXTRA CARDS ON TOP OF THE HAND SIZE, UNTIL THEY ARE PLAYED
+        IF VAR_REWARD = 'HAND-CARD' THEN
+            SET VAR_CARDS_DRAWN = 0;
+            CALL SP_RESHUFFLE_DISCARD_PILE(VAR_LOBBY_ID, 'RESPONSE');
+            SET VAR_CARD_ID = FN_GET_DRAW_PILE_CARD_ID('RESPONSE', VAR_LOBBY_ID);
+
+            WHILE VAR_CARD_ID IS NOT NULL
+                AND VAR_CARDS_DRAWN < VAR_AMOUNT
+            DO
+                INSERT INTO HAND(PLAYER_ID, CARD_ID)
+                VALUES (VAR_PLAYER_ID, VAR_CARD_ID);
+
+                DELETE
+                FROM DRAW_PILE
+                WHERE LOBBY_ID = VAR_LOBBY_ID
+                    AND CARD_ID = VAR_CARD_ID;
+
+                SET VAR_CARDS_DRAWN = VAR_CARDS_DRAWN + 1;
+                CALL SP_RESHUFFLE_DISCARD_PILE(VAR_LOBBY_ID, 'RESPONSE');
+                SET VAR_CARD_ID = FN_GET_DRAW_PILE_CARD_ID('RESPONSE', VAR_LOBBY_ID);
+            END
+            WHILE;
+        END
+        IF;
+
+        IF VAR_REWARD = 'JUDGE-IMMUNITY' THEN
+            UPDATE CJ_PLAYER_STATE
+            SET JUDGE_IMMUNITY = JUDGE_IMMUNITY + VAR_AMOUNT
+            WHERE PLAYER_ID = VAR_PLAYER_ID;
+        END
+        IF;
+
+        -- FREE PERKS, EACH GIVEN ONCE A GAME LIKE THE FLAG PERKS BELOW THAT
+        -- CANNOT STACK
+        IF VAR_REWARD = 'PERK-HAND-SIZE'
+            AND NOT EXISTS(
+                SELECT
+                    LSM.ID
+                FROM LOG_STREAK_MILESTONE AS LSM
+                    INNER JOIN PLAYER AS P ON P.USER_ID = LSM.USER_ID
+                WHERE P.ID = VAR_PLAYER_ID
+                    AND LSM.LOBBY_ID = VAR_LOBBY_ID
+                    AND LSM.REWARD = 'PERK-HAND-SIZE'
+            ) THEN
+            UPDATE CJ_PLAYER_STATE
+            SET HAND_SIZE_ADVANTAGE = HAND_SIZE_ADVANTAGE + 2
+            WHERE PLAYER_ID = VAR_PLAYER_ID;
+
+            CALL SP_DRAW_HAND(VAR_PLAYER_ID);
+        END
+        IF;
+
+        IF VAR_REWARD = 'PERK-DISCARD' THEN
+            UPDATE CJ_PLAYER_STATE
+            SET DISCARD_ADVANTAGE = 1
+            WHERE PLAYER_ID = VAR_PLAYER_ID;
+        END
+        IF;
+
+        IF VAR_REWARD = 'PERK-HANDICAP' THEN
+            UPDATE CJ_PLAYER_STATE
+            SET HANDICAP_ADVANTAGE = 1
+            WHERE PLAYER_ID = VAR_PLAYER_ID;
+        END
+        IF;
+
+        IF VAR_REWARD = 'PERK-SPY' THEN
+            UPDATE CJ_PLAYER_STATE
+            SET SPY_ADVANTAGE = 1
+            WHERE PLAYER_ID = VAR_PLAYER_ID;
+        END
+        IF;
+
+        INSERT INTO LOG_STREAK_MILESTONE(LOBBY_ID, ROUND_ID, USER_ID, STREAK, LENGTH, REWARD, AMOUNT)
+        SELECT
+            VAR_LOBBY_ID,
+            VAR_ROUND_ID,
+            USER_ID,
+            VAR_STREAK,
+            VAR_LENGTH,
+            VAR_REWARD,
+            VAR_AMOUNT
+        FROM PLAYER
+        WHERE ID = VAR_PLAYER_ID;
+        END LOOP;
+    CLOSE VAR_RULE_CURSOR;
+END;
\ No newline at end of file
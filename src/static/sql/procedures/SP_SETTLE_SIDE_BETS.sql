@@ -0,0 +1,71 @@
+CREATE
+OR REPLACE PROCEDURE SP_SETTLE_SIDE_BETS(IN VAR_WINNING_RESPONSE_ID UUID)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT (
+            SELECT
+                P.LOBBY_ID
+            FROM RESPONSE AS R
+                INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
+            WHERE R.ID = VAR_WINNING_RESPONSE_ID
+        );
+    DECLARE VAR_POOL INT;
+    DECLARE VAR_WINNING_POOL INT;
+    DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
+    DECLARE VAR_BET_PLAYER_ID UUID;
+    DECLARE VAR_BET_AMOUNT INT;
+
+    -- WINNERS SPLIT THE WHOLE POOL BY STAKE, OR EVERYONE IS REFUNDED IF NOBODY WON
+    DECLARE VAR_BET_CURSOR CURSOR
+    FOR
+    SELECT
+        SB.PLAYER_ID,
+        SB.AMOUNT
+    FROM SIDE_BET AS SB
+        INNER JOIN PLAYER AS P ON P.ID = SB.PLAYER_ID
+    WHERE P.LOBBY_ID = VAR_LOBBY_ID
+        AND (VAR_WINNING_POOL = 0 OR SB.RESPONSE_ID = VAR_WINNING_RESPONSE_ID);
+
+    DECLARE CONTINUE HANDLER
+    FOR NOT FOUND
+    SET VAR_LOOP_DONE = TRUE;
+
+    SELECT
+        COALESCE(SUM(SB.AMOUNT), 0),
+        COALESCE(SUM(IF(SB.RESPONSE_ID = VAR_WINNING_RESPONSE_ID, SB.AMOUNT, 0)), 0)
+    INTO
+        VAR_POOL,
+        VAR_WINNING_POOL
+    FROM SIDE_BET AS SB
+        INNER JOIN PLAYER AS P ON P.ID = SB.PLAYER_ID
+    WHERE P.LOBBY_ID = VAR_LOBBY_ID;
+
+    OPEN VAR_BET_CURSOR;
+
+        READ_LOOP: LOOP
+        FETCH VAR_BET_CURSOR
+        INTO
+            VAR_BET_PLAYER_ID,
+            VAR_BET_AMOUNT;
+
+        IF VAR_LOOP_DONE THEN LEAVE READ_LOOP;
+        END
+        IF;
+
+        IF VAR_WINNING_POOL = 0 THEN
+            CALL SP_SPEND_CREDITS_UNDO(VAR_BET_PLAYER_ID, 'SIDE-BET');
+        ELSE
+            CALL SP_SPEND_CREDITS(
+                    VAR_BET_PLAYER_ID,
+                    FLOOR(VAR_POOL * VAR_BET_AMOUNT / VAR_WINNING_POOL) * -1,
+                    'SIDE-BET-WIN'
+                );
+        END
+        IF;
+        END LOOP;
+    CLOSE VAR_BET_CURSOR;
+
+    DELETE SB
+    FROM SIDE_BET AS SB
+        INNER JOIN PLAYER AS P ON P.ID = SB.PLAYER_ID
+    WHERE P.LOBBY_ID = VAR_LOBBY_ID;
+END;
\ No newline at end of file
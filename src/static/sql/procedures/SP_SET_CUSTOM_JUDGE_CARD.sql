@@ -0,0 +1,60 @@
+CREATE
+OR REPLACE PROCEDURE SP_SET_CUSTOM_JUDGE_CARD(
+    IN VAR_LOBBY_ID UUID,
+    IN VAR_CARD_TEXT VARCHAR(510)
+)
+BEGIN
+    DECLARE VAR_CARD_ID UUID DEFAULT UUID();
+    DECLARE VAR_OLD_CARD_ID UUID DEFAULT (
+            SELECT
+                CARD_ID
+            FROM JUDGE
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    -- CAPTION CONTESTS ONLY USE IMAGE AND VIDEO PROMPTS
+    IF EXISTS(
+        SELECT
+            LOBBY_ID
+        FROM CJ_LOBBY_SETTINGS
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND CAPTION_MODE = 0
+    ) THEN
+        -- Custom prompts are per-lobby ephemeral cards like wild cards: no deck,
+        -- keyed by LOBBY_ID, deleted by SP_START_NEW_ROUND.
+        INSERT INTO CARD(ID, LOBBY_ID, CATEGORY, TEXT)
+        VALUES (
+            VAR_CARD_ID,
+            VAR_LOBBY_ID,
+            'PROMPT',
+            VAR_CARD_TEXT
+        );
+
+        CALL SP_WITHDRAW_LOBBY(VAR_LOBBY_ID);
+
+        -- RETURN THE REPLACED DECK OR WRITTEN PROMPT TO THE DRAW PILE
+        IF EXISTS(
+            SELECT
+                C.ID
+            FROM CARD AS C
+                LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+            WHERE C.ID = VAR_OLD_CARD_ID
+                AND (C.LOBBY_ID IS NULL OR WC.ID IS NOT NULL)
+        ) THEN
+            INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+            VALUES (VAR_LOBBY_ID, VAR_OLD_CARD_ID);
+        END
+        IF;
+
+        CALL SP_SET_JUDGE_CARD(VAR_LOBBY_ID, VAR_CARD_ID);
+
+        -- DELETE A REPLACED CUSTOM PROMPT
+        DELETE C
+        FROM CARD AS C
+            LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+        WHERE C.ID = VAR_OLD_CARD_ID
+            AND C.LOBBY_ID = VAR_LOBBY_ID
+            AND WC.ID IS NULL;
+    END
+    IF;
+END;
\ No newline at end of file
@@ -0,0 +1,35 @@
+CREATE
+OR REPLACE PROCEDURE SP_SET_LOBBY_DECK_WEIGHTS(
+    IN VAR_LOBBY_ID UUID,
+    IN VAR_DECK_WEIGHTS JSON
+)
+BEGIN
+    -- A DRAW IN BETWEEN MUST NOT SEE THE LOBBY WITH ONLY SOME OF ITS WEIGHTS
+    DECLARE EXIT HANDLER
+    FOR SQLEXCEPTION
+    BEGIN
+        ROLLBACK;
+        RESIGNAL;
+    END;
+
+    START TRANSACTION;
+
+    DELETE
+    FROM CJ_LOBBY_DECK_WEIGHT
+    WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+    INSERT INTO CJ_LOBBY_DECK_WEIGHT(LOBBY_ID, DECK_ID, WEIGHT)
+    SELECT
+        VAR_LOBBY_ID,
+        DW.DECK_ID,
+        DW.WEIGHT
+    FROM JSON_TABLE(
+            VAR_DECK_WEIGHTS,
+            '$[*]' COLUMNS(
+                DECK_ID UUID PATH '$.deckId',
+                WEIGHT INT PATH '$.weight'
+            )
+        ) AS DW;
+
+    COMMIT;
+END;
\ No newline at end of file
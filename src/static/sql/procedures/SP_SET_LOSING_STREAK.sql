@@ -19,16 +19,20 @@ BEGIN
 
     DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
     DECLARE VAR_PLAYER_ID UUID;
+    DECLARE VAR_LOSING_STREAK INT;
 
     DECLARE VAR_PLAYER_CURSOR CURSOR
     FOR
     SELECT
-        P.ID
+        P.ID,
+        CJPS.LOSING_STREAK
     FROM PLAYER AS P
         INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
     WHERE P.LOBBY_ID = VAR_LOBBY_ID
         AND P.IS_ACTIVE = 1
-        AND CJPS.LOSING_STREAK >= VAR_LOBBY_THRESHOLD;
+        AND CJPS.IS_SPECTATOR = 0
+        AND P.ID <> VAR_WINNER_PLAYER_ID
+        AND P.ID <> VAR_JUDGE_PLAYER_ID;
 
     DECLARE CONTINUE HANDLER
     FOR NOT FOUND
@@ -45,6 +49,7 @@ BEGIN
     SET CJPS.LOSING_STREAK = CJPS.LOSING_STREAK + 1
     WHERE P.LOBBY_ID = VAR_LOBBY_ID
         AND P.IS_ACTIVE = 1
+        AND CJPS.IS_SPECTATOR = 0
         AND P.ID <> VAR_WINNER_PLAYER_ID
         AND P.ID <> VAR_JUDGE_PLAYER_ID;
 
@@ -53,26 +58,27 @@ BEGIN
         READ_LOOP: LOOP
         FETCH VAR_PLAYER_CURSOR
         INTO
-            VAR_PLAYER_ID;
+            VAR_PLAYER_ID,
+            VAR_LOSING_STREAK;
 
         IF VAR_LOOP_DONE THEN LEAVE READ_LOOP;
         END
         IF;
 
-        -- AWARD CREDITS AFTER BREAKING THRESHOLD
-        CALL SP_SPEND_CREDITS(
-                VAR_PLAYER_ID,
-                (
-                    FN_GET_SPECIAL_COST('LOSING-STREAK') +
-                    FN_GET_PLAYER_HANDICAP_INVERSE(VAR_PLAYER_ID)
-                ) * -1,
-                'LOSING-STREAK'
-            );
+        -- AWARD CREDITS EVERY TIME THE STREAK BREAKS THE THRESHOLD AGAIN
+        IF MOD(VAR_LOSING_STREAK, VAR_LOBBY_THRESHOLD) = 0 THEN
+            CALL SP_SPEND_CREDITS(
+                    VAR_PLAYER_ID,
+                    (
+                        FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'LOSING-STREAK') +
+                        FN_GET_PLAYER_HANDICAP_INVERSE(VAR_PLAYER_ID)
+                    ) * -1,
+                    'LOSING-STREAK'
+                );
+        END
+        IF;
 
-        -- RESET STREAK AFTER BREAKING THRESHOLD
-        UPDATE CJ_PLAYER_STATE
-        SET LOSING_STREAK = 0
-        WHERE PLAYER_ID = VAR_PLAYER_ID;
+        CALL SP_REWARD_STREAK(VAR_PLAYER_ID, 'LOSING', VAR_LOSING_STREAK);
         END LOOP;
     CLOSE VAR_PLAYER_CURSOR;
 END;
\ No newline at end of file
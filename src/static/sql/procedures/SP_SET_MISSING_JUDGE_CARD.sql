@@ -1,7 +1,10 @@
 CREATE
 OR REPLACE PROCEDURE SP_SET_MISSING_JUDGE_CARD(IN VAR_LOBBY_ID UUID)
 BEGIN
-    IF(SELECT CARD_ID FROM JUDGE WHERE LOBBY_ID = VAR_LOBBY_ID) IS NULL THEN
+    -- NO JUDGE CARD UNTIL THE GAME STARTS
+    IF EXISTS(SELECT ID FROM JUDGE WHERE LOBBY_ID = VAR_LOBBY_ID)
+        AND (SELECT CARD_ID FROM JUDGE WHERE LOBBY_ID = VAR_LOBBY_ID) IS NULL
+        AND NOT EXISTS(SELECT ID FROM PROMPT_CANDIDATE WHERE LOBBY_ID = VAR_LOBBY_ID) THEN
         CALL SP_SET_NEXT_JUDGE_CARD(VAR_LOBBY_ID);
     END
     IF;
@@ -1,7 +1,9 @@
 CREATE
 OR REPLACE PROCEDURE SP_SET_MISSING_JUDGE_PLAYER(IN VAR_LOBBY_ID UUID)
 BEGIN
-    IF FN_GET_LOBBY_JUDGE_PLAYER_ID(VAR_LOBBY_ID) IS NULL THEN
+    -- NO JUDGE UNTIL THE GAME STARTS
+    IF FN_GET_LOBBY_JUDGE_PLAYER_ID(VAR_LOBBY_ID) IS NULL
+        AND EXISTS(SELECT ID FROM JUDGE WHERE LOBBY_ID = VAR_LOBBY_ID) THEN
         CALL SP_SET_NEXT_JUDGE_PLAYER(VAR_LOBBY_ID);
     END
     IF;
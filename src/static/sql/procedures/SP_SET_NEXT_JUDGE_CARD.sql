@@ -1,40 +1,75 @@
 CREATE
 OR REPLACE PROCEDURE SP_SET_NEXT_JUDGE_CARD(IN VAR_LOBBY_ID UUID)
 BEGIN
-    DECLARE VAR_NEW_CARD_ID UUID DEFAULT FN_GET_DRAW_PILE_CARD_ID(
-            'PROMPT',
-            VAR_LOBBY_ID
-        );
-
-    DECLARE VAR_NEW_CARD_TEXT VARCHAR(510) DEFAULT (
-            SELECT
-                TEXT
-            FROM CARD
-            WHERE ID = VAR_NEW_CARD_ID
-        );
-
-    DECLARE VAR_BLANK_COUNT INT DEFAULT (
+    DECLARE VAR_PROMPT_CHOICES INT DEFAULT (
             SELECT
-                ROUND(
-                    (
-                        LENGTH(VAR_NEW_CARD_TEXT) -
-                        LENGTH(REPLACE(VAR_NEW_CARD_TEXT, '_____', ''))
-                    ) / LENGTH('_____')
-                )
+                PROMPT_CHOICES
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
         );
+    DECLARE VAR_CANDIDATE_COUNT INT DEFAULT 0;
+    DECLARE VAR_CARD_ID UUID;
 
-    IF VAR_BLANK_COUNT < 1 THEN
-        SET VAR_BLANK_COUNT = 1;
-    END
-    IF;
-
-    UPDATE JUDGE
-    SET CARD_ID = VAR_NEW_CARD_ID,
-        BLANK_COUNT = COALESCE(VAR_BLANK_COUNT, 1)
+    -- RETURN ANY UNCHOSEN CANDIDATES TO THE DRAW PILE
+    INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+    SELECT
+        LOBBY_ID,
+        CARD_ID
+    FROM PROMPT_CANDIDATE
     WHERE LOBBY_ID = VAR_LOBBY_ID;
 
     DELETE
-    FROM DRAW_PILE
-    WHERE LOBBY_ID = VAR_LOBBY_ID
-        AND CARD_ID = VAR_NEW_CARD_ID;
+    FROM PROMPT_CANDIDATE
+    WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+    CALL SP_RESHUFFLE_DISCARD_PILE(VAR_LOBBY_ID, 'PROMPT');
+
+    IF VAR_PROMPT_CHOICES > 1 THEN
+        SET VAR_CARD_ID = FN_GET_DRAW_PILE_CARD_ID('PROMPT', VAR_LOBBY_ID);
+
+        WHILE VAR_CARD_ID IS NOT NULL
+            AND VAR_CANDIDATE_COUNT < VAR_PROMPT_CHOICES
+        DO
+            INSERT INTO PROMPT_CANDIDATE(LOBBY_ID, CARD_ID)
+            VALUES (VAR_LOBBY_ID, VAR_CARD_ID);
+
+            DELETE
+            FROM DRAW_PILE
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+                AND CARD_ID = VAR_CARD_ID;
+
+            SET VAR_CANDIDATE_COUNT = VAR_CANDIDATE_COUNT + 1;
+            CALL SP_RESHUFFLE_DISCARD_PILE(VAR_LOBBY_ID, 'PROMPT');
+            SET VAR_CARD_ID = FN_GET_DRAW_PILE_CARD_ID('PROMPT', VAR_LOBBY_ID);
+        END
+        WHILE;
+
+        -- NOTHING TO CHOOSE BETWEEN, USE THE ONLY CANDIDATE
+        IF VAR_CANDIDATE_COUNT < 2 THEN
+            SET VAR_CARD_ID = (
+                    SELECT
+                        CARD_ID
+                    FROM PROMPT_CANDIDATE
+                    WHERE LOBBY_ID = VAR_LOBBY_ID
+                );
+
+            DELETE
+            FROM PROMPT_CANDIDATE
+            WHERE LOBBY_ID = VAR_LOBBY_ID;
+
+            CALL SP_SET_JUDGE_CARD(VAR_LOBBY_ID, VAR_CARD_ID);
+        ELSE
+            UPDATE JUDGE
+            SET CARD_ID = NULL,
+                BLANK_COUNT = 1
+            WHERE LOBBY_ID = VAR_LOBBY_ID;
+        END
+        IF;
+    ELSE
+        CALL SP_SET_JUDGE_CARD(
+                VAR_LOBBY_ID,
+                FN_GET_DRAW_PILE_CARD_ID('PROMPT', VAR_LOBBY_ID)
+            );
+    END
+    IF;
 END;
\ No newline at end of file
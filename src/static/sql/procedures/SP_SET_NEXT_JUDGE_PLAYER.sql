@@ -15,6 +15,30 @@ BEGIN
             WHERE LOBBY_ID = VAR_LOBBY_ID
         );
 
+    DECLARE VAR_IDLE_ROUNDS INT DEFAULT (
+            SELECT
+                IDLE_ROUNDS
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    -- JUDGE IMMUNITY ONLY COUNTS WHILE SOMEONE ELSE CAN JUDGE, SO THIS HAS TO
+    -- MATCH WHO THE LOOP BELOW WILL PICK OR THE LOBBY IS LEFT WITHOUT A JUDGE
+    DECLARE VAR_HONOR_IMMUNITY BOOLEAN DEFAULT EXISTS(
+            SELECT
+                P.ID
+            FROM PLAYER AS P
+                INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+            WHERE P.IS_ACTIVE = 1
+                AND CJPS.IS_SPECTATOR = 0
+                AND P.LOBBY_ID = VAR_LOBBY_ID
+                AND (
+                    VAR_IDLE_ROUNDS = 0
+                    OR CJPS.MISSED_ROUNDS <= VAR_IDLE_ROUNDS
+                )
+                AND CJPS.JUDGE_IMMUNITY = 0
+        );
+
     DECLARE VAR_TRY_COUNT INT DEFAULT 0;
     DECLARE VAR_NEXT_POSITION INT DEFAULT VAR_CURRENT_POSITION;
     DECLARE VAR_NEXT_JUDGE_PLAYER_ID UUID;
@@ -31,13 +55,31 @@ BEGIN
         IF;
 
         SELECT
-            ID
+            P.ID
         INTO
             VAR_NEXT_JUDGE_PLAYER_ID
-        FROM PLAYER
-        WHERE IS_ACTIVE = 1
-            AND LOBBY_ID = VAR_LOBBY_ID
-            AND JOIN_ORDER = VAR_NEXT_POSITION;
+        FROM PLAYER AS P
+            INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
+        WHERE P.IS_ACTIVE = 1
+            AND CJPS.IS_SPECTATOR = 0
+            AND P.LOBBY_ID = VAR_LOBBY_ID
+            AND P.JOIN_ORDER = VAR_NEXT_POSITION
+            -- PLAYERS IDLE PAST BEING PLAYED FOR ARE PASSED OVER
+            AND (
+                VAR_IDLE_ROUNDS = 0
+                OR CJPS.MISSED_ROUNDS <= VAR_IDLE_ROUNDS
+            );
+
+        -- PLAYERS WITH JUDGE IMMUNITY ARE PASSED OVER, USING UP ONE TURN OF IT
+        IF VAR_HONOR_IMMUNITY
+        AND (SELECT JUDGE_IMMUNITY FROM CJ_PLAYER_STATE WHERE PLAYER_ID = VAR_NEXT_JUDGE_PLAYER_ID) > 0 THEN
+            UPDATE CJ_PLAYER_STATE
+            SET JUDGE_IMMUNITY = JUDGE_IMMUNITY - 1
+            WHERE PLAYER_ID = VAR_NEXT_JUDGE_PLAYER_ID;
+
+            SET VAR_NEXT_JUDGE_PLAYER_ID = NULL;
+        END
+        IF;
     END
     WHILE;
 
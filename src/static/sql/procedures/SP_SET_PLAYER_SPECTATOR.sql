@@ -0,0 +1,38 @@
+CREATE
+OR REPLACE PROCEDURE SP_SET_PLAYER_SPECTATOR(
+    IN VAR_PLAYER_ID UUID,
+    IN VAR_IS_SPECTATOR BOOLEAN
+)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+
+    UPDATE CJ_PLAYER_STATE
+    SET IS_SPECTATOR = VAR_IS_SPECTATOR
+    WHERE PLAYER_ID = VAR_PLAYER_ID;
+
+    IF VAR_IS_SPECTATOR THEN
+        IF EXISTS(SELECT ID FROM JUDGE WHERE PLAYER_ID = VAR_PLAYER_ID) THEN
+            -- JUDGE STEPPED ASIDE
+            CALL SP_SET_NEXT_JUDGE_PLAYER(VAR_LOBBY_ID);
+            CALL SP_SET_RESPONSES_LOBBY(VAR_LOBBY_ID);
+            ELSE
+            CALL SP_SET_RESPONSES_PLAYER(VAR_PLAYER_ID);
+        END
+        IF;
+
+        CALL SP_RETURN_HAND(VAR_PLAYER_ID);
+        ELSE
+        CALL SP_DRAW_HAND(VAR_PLAYER_ID);
+
+        IF FN_GET_LOBBY_JUDGE_PLAYER_ID(VAR_LOBBY_ID) IS NULL THEN
+            -- FIRST PLAYER AT THE TABLE
+            CALL SP_SET_MISSING_JUDGE_PLAYER(VAR_LOBBY_ID);
+            CALL SP_SET_MISSING_JUDGE_CARD(VAR_LOBBY_ID);
+            CALL SP_SET_RESPONSES_LOBBY(VAR_LOBBY_ID);
+            ELSE
+            CALL SP_SET_RESPONSES_PLAYER(VAR_PLAYER_ID);
+        END
+        IF;
+    END
+    IF;
+END;
\ No newline at end of file
@@ -40,6 +40,18 @@ BEGIN
     END
     IF;
 
+    -- NO RESPONSES UNTIL THE GAME STARTS
+    IF NOT EXISTS(SELECT ID FROM JUDGE WHERE LOBBY_ID = VAR_LOBBY_ID) THEN
+        SET VAR_RESPONSE_COUNT_FINAL = 0;
+    END
+    IF;
+
+    -- CLEAR RESPONSES FOR SPECTATOR
+    IF EXISTS(SELECT PLAYER_ID FROM CJ_PLAYER_STATE WHERE PLAYER_ID = VAR_PLAYER_ID AND IS_SPECTATOR = 1) THEN
+        SET VAR_RESPONSE_COUNT_FINAL = 0;
+    END
+    IF;
+
     -- CLEAR RESPONSES FOR JUDGE
     IF EXISTS(SELECT ID FROM JUDGE WHERE PLAYER_ID = VAR_PLAYER_ID) THEN
         SET VAR_RESPONSE_COUNT_FINAL = 0;
@@ -17,22 +17,7 @@ BEGIN
             WHERE LOBBY_ID = VAR_LOBBY_ID
         );
 
-    DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
-    DECLARE VAR_PLAYER_ID UUID;
-
-    DECLARE VAR_PLAYER_CURSOR CURSOR
-    FOR
-    SELECT
-        P.ID
-    FROM PLAYER AS P
-        INNER JOIN CJ_PLAYER_STATE AS CJPS ON CJPS.PLAYER_ID = P.ID
-    WHERE P.LOBBY_ID = VAR_LOBBY_ID
-        AND P.IS_ACTIVE = 1
-        AND CJPS.WINNING_STREAK >= VAR_LOBBY_THRESHOLD;
-
-    DECLARE CONTINUE HANDLER
-    FOR NOT FOUND
-    SET VAR_LOOP_DONE = TRUE;
+    DECLARE VAR_WINNING_STREAK INT;
 
     -- INCREMENT WINNING STREAK OF WINNER
     UPDATE CJ_PLAYER_STATE
@@ -48,31 +33,25 @@ BEGIN
         AND P.ID <> VAR_WINNER_PLAYER_ID
         AND P.ID <> VAR_JUDGE_PLAYER_ID;
 
-    OPEN VAR_PLAYER_CURSOR;
-
-        READ_LOOP: LOOP
-        FETCH VAR_PLAYER_CURSOR
-        INTO
-            VAR_PLAYER_ID;
-
-        IF VAR_LOOP_DONE THEN LEAVE READ_LOOP;
-        END
-        IF;
+    SELECT
+        WINNING_STREAK
+    INTO
+        VAR_WINNING_STREAK
+    FROM CJ_PLAYER_STATE
+    WHERE PLAYER_ID = VAR_WINNER_PLAYER_ID;
 
-        -- REMOVE CREDITS AFTER BREAKING THRESHOLD
+    -- REMOVE CREDITS EVERY TIME THE STREAK BREAKS THE THRESHOLD AGAIN
+    IF MOD(VAR_WINNING_STREAK, VAR_LOBBY_THRESHOLD) = 0 THEN
         CALL SP_SPEND_CREDITS(
-                VAR_PLAYER_ID,
+                VAR_WINNER_PLAYER_ID,
                 (
-                    FN_GET_SPECIAL_COST('WINNING-STREAK') +
-                    FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
+                    FN_GET_ECONOMY_VALUE(VAR_LOBBY_ID, 'WINNING-STREAK') +
+                    FN_GET_PLAYER_HANDICAP(VAR_WINNER_PLAYER_ID)
                 ),
                 'WINNING-STREAK'
             );
+    END
+    IF;
 
-        -- RESET STREAK AFTER BREAKING THRESHOLD
-        UPDATE CJ_PLAYER_STATE
-        SET WINNING_STREAK = 0
-        WHERE PLAYER_ID = VAR_PLAYER_ID;
-        END LOOP;
-    CLOSE VAR_PLAYER_CURSOR;
+    CALL SP_REWARD_STREAK(VAR_WINNER_PLAYER_ID, 'WINNING', VAR_WINNING_STREAK);
 END;
\ No newline at end of file
@@ -0,0 +1,28 @@
+CREATE
+OR REPLACE PROCEDURE SP_SIDE_BET(
+    IN VAR_PLAYER_ID UUID,
+    IN VAR_RESPONSE_ID UUID,
+    IN VAR_AMOUNT INT
+)
+BEGIN
+    -- ONLY REVEALED RESPONSES OF OTHER PLAYERS IN THE LOBBY CAN BE BET ON, AND
+    -- NOT BY THE JUDGE WHO PICKS THE WINNER
+    IF NOT VAR_PLAYER_ID <=> FN_GET_LOBBY_JUDGE_PLAYER_ID(FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID))
+        AND EXISTS(
+            SELECT
+                R.ID
+            FROM RESPONSE AS R
+                INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
+            WHERE R.ID = VAR_RESPONSE_ID
+                AND R.IS_REVEALED = 1
+                AND R.IS_RULEDOUT = 0
+                AND R.PLAYER_ID <> VAR_PLAYER_ID
+                AND P.LOBBY_ID = FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID)
+        ) THEN
+        INSERT INTO SIDE_BET(PLAYER_ID, RESPONSE_ID, AMOUNT)
+        VALUES (VAR_PLAYER_ID, VAR_RESPONSE_ID, VAR_AMOUNT);
+
+        CALL SP_SPEND_CREDITS(VAR_PLAYER_ID, VAR_AMOUNT, 'SIDE-BET');
+    END
+    IF;
+END;
\ No newline at end of file
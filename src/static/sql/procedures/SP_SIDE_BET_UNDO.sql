@@ -0,0 +1,19 @@
+CREATE
+OR REPLACE PROCEDURE SP_SIDE_BET_UNDO(IN VAR_PLAYER_ID UUID)
+BEGIN
+    DECLARE VAR_AMOUNT INT DEFAULT (
+            SELECT
+                AMOUNT
+            FROM SIDE_BET
+            WHERE PLAYER_ID = VAR_PLAYER_ID
+        );
+
+    IF VAR_AMOUNT > 0 THEN
+        DELETE
+        FROM SIDE_BET
+        WHERE PLAYER_ID = VAR_PLAYER_ID;
+
+        CALL SP_SPEND_CREDITS_UNDO(VAR_PLAYER_ID, 'SIDE-BET');
+    END
+    IF;
+END;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE
+OR REPLACE PROCEDURE SP_SKIP_IDLE_JUDGE(IN VAR_LOBBY_ID UUID)
+BEGIN
+    CALL SP_SET_NEXT_JUDGE_PLAYER(VAR_LOBBY_ID);
+    CALL SP_SET_RESPONSES_LOBBY(VAR_LOBBY_ID);
+END;
\ No newline at end of file
@@ -18,6 +18,18 @@ BEGIN
             INNER JOIN JUDGE AS J ON J.LOBBY_ID = P.LOBBY_ID
         WHERE P.LOBBY_ID = VAR_LOBBY_ID
             AND P.ID = FN_GET_LOBBY_JUDGE_PLAYER_ID(VAR_LOBBY_ID);
+
+        -- SKIPPED DECK AND WRITTEN PROMPTS CAN COME BACK IN A RESHUFFLE
+        INSERT IGNORE INTO DISCARD_PILE(LOBBY_ID, ROUND_ID, CARD_ID)
+        SELECT
+            CJLS.LOBBY_ID,
+            CJLS.ROUND_ID,
+            C.ID
+        FROM CARD AS C
+            INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = VAR_LOBBY_ID
+            LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+        WHERE C.ID = VAR_JUDGE_CARD_ID
+            AND (C.LOBBY_ID IS NULL OR WC.ID IS NOT NULL);
     END
     IF;
 
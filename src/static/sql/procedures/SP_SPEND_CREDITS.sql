@@ -19,7 +19,14 @@ OR REPLACE PROCEDURE SP_SPEND_CREDITS(
         'SURPRISE',
         'FIND',
         'WILD',
-        'PERK'
+        'PERK',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'SIDE-BET',
+        'SIDE-BET-WIN',
+        'CATCH-UP',
+        'STREAK-REWARD'
     )
 ) whole_proc:
 BEGIN
@@ -1,7 +1,6 @@
 CREATE
 OR REPLACE PROCEDURE SP_SPEND_CREDITS_UNDO(
     IN VAR_PLAYER_ID UUID,
-    IN VAR_AMOUNT INT,
     IN VAR_CATEGORY ENUM(
         'WINNING-STREAK',
         'LOSING-STREAK',
@@ -19,13 +18,37 @@ OR REPLACE PROCEDURE SP_SPEND_CREDITS_UNDO(
         'SURPRISE',
         'FIND',
         'WILD',
-        'PERK'
+        'PERK',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'SIDE-BET',
+        'SIDE-BET-WIN',
+        'CATCH-UP',
+        'STREAK-REWARD'
     )
 )
 BEGIN
     DECLARE VAR_LOBBY_ID UUID;
     DECLARE VAR_PLAYER_USER_ID UUID;
 
+    -- REFUND WHAT WAS PAID, NOT TODAY'S PRICE, SO ECONOMY CHANGES CANNOT MAKE CREDITS
+    DECLARE VAR_CREDITS_SPENT_ID UUID DEFAULT (
+            SELECT
+                ID
+            FROM CREDITS_SPENT
+            WHERE PLAYER_ID = VAR_PLAYER_ID
+                AND CATEGORY = VAR_CATEGORY
+            ORDER BY CREATED_ON_DATE DESC
+            LIMIT 1
+        );
+    DECLARE VAR_AMOUNT INT DEFAULT (
+            SELECT
+                AMOUNT
+            FROM CREDITS_SPENT
+            WHERE ID = VAR_CREDITS_SPENT_ID
+        );
+
     SELECT
         LOBBY_ID,
         USER_ID
@@ -35,24 +58,23 @@ BEGIN
     FROM PLAYER
     WHERE ID = VAR_PLAYER_ID;
 
-    UPDATE CJ_PLAYER_STATE
-    SET CREDITS_SPENT = CREDITS_SPENT - VAR_AMOUNT
-    WHERE PLAYER_ID = VAR_PLAYER_ID;
+    IF VAR_CREDITS_SPENT_ID IS NOT NULL THEN
+        UPDATE CJ_PLAYER_STATE
+        SET CREDITS_SPENT = CREDITS_SPENT - VAR_AMOUNT
+        WHERE PLAYER_ID = VAR_PLAYER_ID;
 
-    DELETE
-    FROM CREDITS_SPENT
-    WHERE PLAYER_ID = VAR_PLAYER_ID
-        AND AMOUNT = VAR_AMOUNT
-        AND CATEGORY = VAR_CATEGORY
-    ORDER BY CREATED_ON_DATE DESC
-    LIMIT 1;
+        DELETE
+        FROM CREDITS_SPENT
+        WHERE ID = VAR_CREDITS_SPENT_ID;
 
-    DELETE
-    FROM LOG_CREDITS_SPENT
-    WHERE LOBBY_ID = VAR_LOBBY_ID
-        AND USER_ID = VAR_PLAYER_USER_ID
-        AND AMOUNT = VAR_AMOUNT
-        AND CATEGORY = VAR_CATEGORY
-    ORDER BY CREATED_ON_DATE DESC
-    LIMIT 1;
+        DELETE
+        FROM LOG_CREDITS_SPENT
+        WHERE LOBBY_ID = VAR_LOBBY_ID
+            AND USER_ID = VAR_PLAYER_USER_ID
+            AND AMOUNT = VAR_AMOUNT
+            AND CATEGORY = VAR_CATEGORY
+        ORDER BY CREATED_ON_DATE DESC
+        LIMIT 1;
+    END
+    IF;
 END;
\ No newline at end of file
@@ -1,6 +1,22 @@
 CREATE
 OR REPLACE PROCEDURE SP_START_NEW_ROUND(IN VAR_LOBBY_ID UUID)
 BEGIN
+    -- SEATED PLAYERS WHO DID NOTHING THIS ROUND MISSED IT
+    UPDATE CJ_PLAYER_STATE AS CJPS
+        INNER JOIN PLAYER AS P ON P.ID = CJPS.PLAYER_ID
+        INNER JOIN CJ_LOBBY_SETTINGS AS CJLS ON CJLS.LOBBY_ID = P.LOBBY_ID
+    SET CJPS.MISSED_ROUNDS = CJPS.MISSED_ROUNDS + 1
+    WHERE P.LOBBY_ID = VAR_LOBBY_ID
+        AND P.IS_ACTIVE = 1
+        AND CJPS.IS_SPECTATOR = 0
+        AND NOT CJPS.LAST_ACTION_ROUND_ID <=> CJLS.ROUND_ID
+        AND NOT EXISTS(SELECT ID FROM BOT WHERE PLAYER_ID = P.ID);
+
+    -- A ROUND THAT ENDS WITHOUT A WINNER LEAVES SIDE BETS UNSETTLED
+    CALL SP_REFUND_SIDE_BETS(VAR_LOBBY_ID);
+
+    CALL SP_DISCARD_ROUND(VAR_LOBBY_ID);
+
     UPDATE CJ_LOBBY_SETTINGS
     SET ROUND_ID = UUID()
     WHERE LOBBY_ID = VAR_LOBBY_ID;
@@ -29,8 +45,10 @@ BEGIN
     -- CREATE NEW RESPONSES
     CALL SP_SET_RESPONSES_LOBBY(VAR_LOBBY_ID);
 
-    -- DELETE ANY WILD CARDS
-    DELETE
-    FROM CARD
-    WHERE LOBBY_ID = VAR_LOBBY_ID;
+    -- DELETE ANY WILD CARDS, WRITTEN CARDS LAST THE WHOLE GAME
+    DELETE C
+    FROM CARD AS C
+        LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+    WHERE C.LOBBY_ID = VAR_LOBBY_ID
+        AND WC.ID IS NULL;
 END;
\ No newline at end of file
@@ -0,0 +1,54 @@
+CREATE
+OR REPLACE PROCEDURE SP_SWAP_CARD(
+    IN VAR_PLAYER_ID UUID,
+    IN VAR_TARGET_PLAYER_ID UUID
+)
+BEGIN
+    DECLARE VAR_HAND_ID UUID;
+    DECLARE VAR_TARGET_HAND_ID UUID;
+    DECLARE VAR_CARD_ID UUID;
+    DECLARE VAR_TARGET_CARD_ID UUID;
+
+    SELECT
+        ID,
+        CARD_ID
+    INTO
+        VAR_HAND_ID,
+        VAR_CARD_ID
+    FROM HAND
+    WHERE PLAYER_ID = VAR_PLAYER_ID
+    ORDER BY RAND()
+    LIMIT 1;
+
+    SELECT
+        ID,
+        CARD_ID
+    INTO
+        VAR_TARGET_HAND_ID,
+        VAR_TARGET_CARD_ID
+    FROM HAND
+    WHERE PLAYER_ID = VAR_TARGET_PLAYER_ID
+    ORDER BY RAND()
+    LIMIT 1;
+
+    -- BOTH HANDS NEED A CARD TO TRADE
+    IF VAR_HAND_ID IS NOT NULL AND VAR_TARGET_HAND_ID IS NOT NULL THEN
+        UPDATE HAND
+        SET CARD_ID = VAR_TARGET_CARD_ID
+        WHERE ID = VAR_HAND_ID;
+
+        UPDATE HAND
+        SET CARD_ID = VAR_CARD_ID
+        WHERE ID = VAR_TARGET_HAND_ID;
+
+        CALL SP_SPEND_CREDITS(
+                VAR_PLAYER_ID,
+                (
+                    FN_GET_ECONOMY_VALUE(FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID), 'SWAP') +
+                        FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
+                ),
+                'SWAP'
+            );
+    END
+    IF;
+END;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE
+OR REPLACE PROCEDURE SP_TRANSFER_DECK_OWNER(
+    IN VAR_DECK_ID UUID,
+    IN VAR_USER_ID UUID
+)
+BEGIN
+    -- THE OLD OWNER STAYS ON AS AN EDITOR
+    UPDATE DECK_COLLABORATOR
+    SET ROLE = 'EDITOR'
+    WHERE DECK_ID = VAR_DECK_ID
+        AND ROLE = 'OWNER';
+
+    INSERT INTO DECK_COLLABORATOR(USER_ID, DECK_ID, ROLE)
+    VALUES (VAR_USER_ID, VAR_DECK_ID, 'OWNER')
+    ON DUPLICATE KEY UPDATE ROLE = 'OWNER';
+END;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE
+OR REPLACE PROCEDURE SP_TRANSFER_LOBBY_OWNER(IN VAR_PLAYER_ID UUID)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+    DECLARE VAR_OLD_OWNER_PLAYER_ID UUID DEFAULT FN_GET_LOBBY_OWNER_PLAYER_ID(VAR_LOBBY_ID);
+
+    -- THE OLD OWNER STAYS ON AS A CO-HOST
+    UPDATE CJ_PLAYER_STATE
+    SET IS_CO_HOST = 1
+    WHERE PLAYER_ID = VAR_OLD_OWNER_PLAYER_ID;
+
+    UPDATE CJ_PLAYER_STATE
+    SET IS_CO_HOST = 0
+    WHERE PLAYER_ID = VAR_PLAYER_ID;
+
+    UPDATE CJ_LOBBY_SETTINGS
+    SET OWNER_PLAYER_ID = VAR_PLAYER_ID
+    WHERE LOBBY_ID = VAR_LOBBY_ID;
+END;
\ No newline at end of file
@@ -0,0 +1,36 @@
+CREATE
+OR REPLACE PROCEDURE SP_UNLOCK_COSMETIC(
+    IN VAR_USER_ID UUID,
+    IN VAR_KIND ENUM(
+        'CARD-BACK',
+        'NAME-COLOR',
+        'FLIP-TABLE',
+        'KICK'
+    ),
+    IN VAR_COSMETIC VARCHAR(50),
+    IN VAR_PRICE INT
+)
+BEGIN
+    DECLARE VAR_BALANCE INT DEFAULT (
+            SELECT
+                COALESCE(SUM(AMOUNT), 0)
+            FROM WALLET_LEDGER
+            WHERE USER_ID = VAR_USER_ID
+        );
+
+    IF VAR_BALANCE >= VAR_PRICE
+    AND NOT EXISTS(
+        SELECT
+            ID
+        FROM USER_COSMETIC
+        WHERE USER_ID = VAR_USER_ID
+            AND COSMETIC = VAR_COSMETIC
+    ) THEN
+        INSERT INTO WALLET_LEDGER(USER_ID, AMOUNT, CATEGORY, REFERENCE)
+        VALUES (VAR_USER_ID, VAR_PRICE * -1, 'UNLOCK', VAR_COSMETIC);
+
+        INSERT INTO USER_COSMETIC(USER_ID, KIND, COSMETIC)
+        VALUES (VAR_USER_ID, VAR_KIND, VAR_COSMETIC);
+    END
+    IF;
+END;
\ No newline at end of file
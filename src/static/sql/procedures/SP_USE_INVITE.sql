@@ -0,0 +1,25 @@
+CREATE
+OR REPLACE PROCEDURE SP_USE_INVITE(
+    IN VAR_INVITE_ID UUID,
+    IN VAR_USER_ID UUID
+)
+BEGIN
+    -- ONLY TAKE A USE IF THE INVITE IS STILL GOOD, SO TWO USERS CANNOT
+    -- BOTH TAKE THE LAST ONE
+    UPDATE INVITE
+    SET USE_COUNT = USE_COUNT + 1
+    WHERE ID = VAR_INVITE_ID
+        AND REVOKED_ON_DATE IS NULL
+        AND (EXPIRES_ON_DATE IS NULL OR EXPIRES_ON_DATE > NOW())
+        AND (MAX_USES = 0 OR USE_COUNT < MAX_USES);
+
+    IF ROW_COUNT() > 0 THEN
+        INSERT IGNORE INTO INVITE_USE(INVITE_ID, USER_ID)
+        VALUES (VAR_INVITE_ID, VAR_USER_ID);
+
+        SELECT 1 AS IS_USED;
+        ELSE
+        SELECT 0 AS IS_USED;
+    END
+    IF;
+END;
\ No newline at end of file
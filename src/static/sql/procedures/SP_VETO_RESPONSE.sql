@@ -0,0 +1,49 @@
+CREATE
+OR REPLACE PROCEDURE SP_VETO_RESPONSE(
+    IN VAR_PLAYER_ID UUID,
+    IN VAR_RESPONSE_ID UUID
+)
+BEGIN
+    DECLARE VAR_TARGET_PLAYER_ID UUID;
+    DECLARE VAR_IS_VETOED BOOLEAN DEFAULT FALSE;
+
+    -- ONLY REVEALED RESPONSES OF OTHER PLAYERS IN THE LOBBY THAT ARE STILL IN
+    -- THE RUNNING CAN BE VETOED, AND NOT BY THE JUDGE
+    SELECT
+        R.PLAYER_ID
+    INTO
+        VAR_TARGET_PLAYER_ID
+    FROM RESPONSE AS R
+        INNER JOIN PLAYER AS P ON P.ID = R.PLAYER_ID
+    WHERE R.ID = VAR_RESPONSE_ID
+        AND R.IS_REVEALED = 1
+        AND R.IS_RULEDOUT = 0
+        AND R.PLAYER_ID <> VAR_PLAYER_ID
+        AND P.LOBBY_ID = FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID)
+        AND NOT VAR_PLAYER_ID <=> FN_GET_LOBBY_JUDGE_PLAYER_ID(P.LOBBY_ID);
+
+    IF VAR_TARGET_PLAYER_ID IS NOT NULL THEN
+        SET VAR_IS_VETOED = TRUE;
+
+        -- THE JUDGE CANNOT RULE A VETOED RESPONSE BACK IN
+        UPDATE RESPONSE
+        SET IS_RULEDOUT = 1,
+            IS_VETOED = 1
+        WHERE ID = VAR_RESPONSE_ID;
+
+        CALL SP_SPEND_CREDITS(
+                VAR_PLAYER_ID,
+                (
+                    FN_GET_ECONOMY_VALUE(FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID), 'VETO') +
+                        FN_GET_PLAYER_HANDICAP(VAR_PLAYER_ID)
+                ),
+                'VETO'
+            );
+
+        CALL SP_BET_ON_WIN_UNDO(VAR_TARGET_PLAYER_ID);
+    END
+    IF;
+
+    SELECT
+        VAR_IS_VETOED AS IS_VETOED;
+END;
\ No newline at end of file
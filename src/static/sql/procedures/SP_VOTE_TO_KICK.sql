@@ -5,6 +5,8 @@ OR REPLACE PROCEDURE SP_VOTE_TO_KICK(
 )
 BEGIN
     DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_SUBJECT_PLAYER_ID);
+    DECLARE VAR_LOG_KICK_ID UUID DEFAULT UUID();
+    DECLARE VAR_THRESHOLD INT DEFAULT FN_GET_KICK_THRESHOLD(VAR_SUBJECT_PLAYER_ID);
 
     DECLARE VAR_SUBJECT_USER_ID UUID DEFAULT (
             SELECT
@@ -13,21 +15,64 @@ BEGIN
             WHERE ID = VAR_SUBJECT_PLAYER_ID
         );
 
+    DECLARE VAR_BAN_MINUTES INT DEFAULT (
+            SELECT
+                KICK_BAN_MINUTES
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    DECLARE VAR_VOTE_MINUTES INT DEFAULT (
+            SELECT
+                KICK_VOTE_MINUTES
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+        );
+
+    -- VOTES ONLY LAST SO LONG, SO OLD GRUDGES DO NOT ADD UP
+    IF VAR_VOTE_MINUTES > 0 THEN
+        DELETE
+        FROM KICK
+        WHERE SUBJECT_PLAYER_ID = VAR_SUBJECT_PLAYER_ID
+            AND CREATED_ON_DATE < DATE_SUB(NOW(), INTERVAL VAR_VOTE_MINUTES MINUTE);
+    END
+    IF;
+
     INSERT IGNORE INTO KICK(VOTER_PLAYER_ID, SUBJECT_PLAYER_ID)
     VALUES (VAR_VOTER_PLAYER_ID, VAR_SUBJECT_PLAYER_ID);
 
+    -- VOTES FROM PLAYERS WHO HAVE SINCE LEFT DO NOT COUNT
     IF(
         SELECT
             COUNT(*)
-        FROM KICK
-        WHERE SUBJECT_PLAYER_ID = VAR_SUBJECT_PLAYER_ID
-    ) > 1 THEN
+        FROM KICK AS K
+            INNER JOIN PLAYER AS P ON P.ID = K.VOTER_PLAYER_ID
+        WHERE K.SUBJECT_PLAYER_ID = VAR_SUBJECT_PLAYER_ID
+            AND P.IS_ACTIVE = 1
+    ) >= VAR_THRESHOLD THEN
+        INSERT INTO LOG_KICK(ID, LOBBY_ID, USER_ID, THRESHOLD, BAN_MINUTES)
+        VALUES (VAR_LOG_KICK_ID, VAR_LOBBY_ID, VAR_SUBJECT_USER_ID, VAR_THRESHOLD, VAR_BAN_MINUTES);
+
+        INSERT INTO LOG_KICK_VOTER(LOG_KICK_ID, USER_ID, VOTED_ON_DATE)
+        SELECT
+            VAR_LOG_KICK_ID,
+            P.USER_ID,
+            K.CREATED_ON_DATE
+        FROM KICK AS K
+            INNER JOIN PLAYER AS P ON P.ID = K.VOTER_PLAYER_ID
+        WHERE K.SUBJECT_PLAYER_ID = VAR_SUBJECT_PLAYER_ID
+            AND P.IS_ACTIVE = 1;
+
         DELETE
         FROM KICK
         WHERE SUBJECT_PLAYER_ID = VAR_SUBJECT_PLAYER_ID;
 
-        INSERT INTO LOG_KICK(LOBBY_ID, USER_ID)
-        VALUES (VAR_LOBBY_ID, VAR_SUBJECT_USER_ID);
+        IF VAR_BAN_MINUTES > 0 THEN
+            INSERT INTO LOBBY_BAN(LOBBY_ID, USER_ID, EXPIRES_ON_DATE)
+            VALUES (VAR_LOBBY_ID, VAR_SUBJECT_USER_ID, DATE_ADD(NOW(), INTERVAL VAR_BAN_MINUTES MINUTE))
+            ON DUPLICATE KEY UPDATE EXPIRES_ON_DATE = DATE_ADD(NOW(), INTERVAL VAR_BAN_MINUTES MINUTE);
+        END
+        IF;
 
         CALL SP_SET_PLAYER_INACTIVE(VAR_LOBBY_ID, VAR_SUBJECT_USER_ID);
 
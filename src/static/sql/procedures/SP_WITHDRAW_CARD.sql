@@ -19,11 +19,17 @@ BEGIN
 
     UPDATE RESPONSE
     SET IS_REVEALED = 0,
-        IS_RULEDOUT = 0
+        -- VETOED RESPONSES STAY RULED OUT
+        IS_RULEDOUT = IS_VETOED
     WHERE ID = VAR_RESPONSE_ID;
 
-    INSERT INTO HAND(PLAYER_ID, CARD_ID)
-    VALUES (VAR_PLAYER_ID, VAR_CARD_ID);
+    -- FREE-TEXT ANSWERS ARE LOBBY CARDS AND NEVER GO BACK TO A HAND
+    IF (SELECT LOBBY_ID FROM CARD WHERE ID = VAR_CARD_ID) IS NULL
+        OR EXISTS(SELECT ID FROM WRITTEN_CARD WHERE CARD_ID = VAR_CARD_ID) THEN
+        INSERT INTO HAND(PLAYER_ID, CARD_ID)
+        VALUES (VAR_PLAYER_ID, VAR_CARD_ID);
+    END
+    IF;
 
     DELETE
     FROM RESPONSE_CARD
@@ -32,4 +38,11 @@ BEGIN
     DELETE
     FROM LOG_RESPONSE_CARD
     WHERE RESPONSE_CARD_ID = VAR_RESPONSE_CARD_ID;
+
+    DELETE C
+    FROM CARD AS C
+        LEFT JOIN WRITTEN_CARD AS WC ON WC.CARD_ID = C.ID
+    WHERE C.ID = VAR_CARD_ID
+        AND C.LOBBY_ID IS NOT NULL
+        AND WC.ID IS NULL;
 END;
\ No newline at end of file
@@ -0,0 +1,42 @@
+CREATE
+OR REPLACE PROCEDURE SP_WRITE_CARD(
+    IN VAR_PLAYER_ID UUID,
+    IN VAR_CATEGORY ENUM('PROMPT', 'RESPONSE'),
+    IN VAR_CARD_TEXT VARCHAR(510)
+)
+BEGIN
+    DECLARE VAR_LOBBY_ID UUID DEFAULT FN_GET_PLAYER_LOBBY_ID(VAR_PLAYER_ID);
+    DECLARE VAR_CARD_ID UUID DEFAULT UUID();
+
+    -- CAPTION CONTESTS ONLY USE IMAGE AND VIDEO PROMPTS
+    IF VAR_CATEGORY = 'RESPONSE'
+        OR EXISTS(
+            SELECT
+                LOBBY_ID
+            FROM CJ_LOBBY_SETTINGS
+            WHERE LOBBY_ID = VAR_LOBBY_ID
+                AND CAPTION_MODE = 0
+        ) THEN
+        -- Written cards are lobby cards that last for the whole game, so they are
+        -- tracked in WRITTEN_CARD to keep them out of the end of round cleanup.
+        INSERT INTO CARD(ID, LOBBY_ID, CATEGORY, TEXT)
+        VALUES (
+            VAR_CARD_ID,
+            VAR_LOBBY_ID,
+            VAR_CATEGORY,
+            VAR_CARD_TEXT
+        );
+
+        INSERT INTO WRITTEN_CARD(LOBBY_ID, CARD_ID, USER_ID)
+        SELECT
+            LOBBY_ID,
+            VAR_CARD_ID,
+            USER_ID
+        FROM PLAYER
+        WHERE ID = VAR_PLAYER_ID;
+
+        INSERT INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+        VALUES (VAR_LOBBY_ID, VAR_CARD_ID);
+    END
+    IF;
+END;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ACCESS_FAILURE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    IP_ADDRESS VARCHAR(255) NOT NULL,
+    USER_ID UUID NOT NULL,
+    LOBBY_ID UUID NULL,
+    DECK_ID UUID NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(USER_ID) REFERENCES USER(ID) ON DELETE CASCADE,
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    FOREIGN KEY(DECK_ID) REFERENCES DECK(ID) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS BOT(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    PLAYER_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    PERSONALITY VARCHAR(255) NOT NULL DEFAULT 'RANDOM',
+    DIFFICULTY ENUM('EASY', 'NORMAL', 'HARD') NOT NULL DEFAULT 'NORMAL',
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    FOREIGN KEY(PLAYER_ID) REFERENCES PLAYER(ID) ON DELETE CASCADE,
+    CONSTRAINT PLAYER_UNIQUE UNIQUE(PLAYER_ID)
+);
\ No newline at end of file
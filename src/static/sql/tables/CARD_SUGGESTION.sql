@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS CARD_SUGGESTION(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    DECK_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    CATEGORY ENUM('PROMPT', 'RESPONSE') NOT NULL DEFAULT 'PROMPT',
+    TEXT VARCHAR(510) NOT NULL,
+    YOUTUBE CHAR(11) NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(DECK_ID) REFERENCES DECK(ID) ON DELETE CASCADE,
+    FOREIGN KEY(USER_ID) REFERENCES USER(ID) ON DELETE CASCADE,
+    CONSTRAINT DECK_TEXT_UNIQUE UNIQUE(DECK_ID, TEXT)
+);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS CJ_LOBBY_DECK_WEIGHT(
+    ID UUID NOT NULL DEFAULT UUID(),
+    LOBBY_ID UUID NOT NULL,
+    DECK_ID UUID NOT NULL,
+    WEIGHT INT NOT NULL DEFAULT 1,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    FOREIGN KEY(DECK_ID) REFERENCES DECK(ID) ON DELETE CASCADE,
+    CONSTRAINT LOBBY_DECK_UNIQUE UNIQUE(LOBBY_ID, DECK_ID)
+);
\ No newline at end of file
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS CJ_LOBBY_DISABLED_SPECIAL(
+    ID UUID NOT NULL DEFAULT UUID(),
+    LOBBY_ID UUID NOT NULL,
+    SPECIAL ENUM(
+        'ALERT',
+        'GAMBLE',
+        'BET',
+        'SKIP-JUDGE',
+        'EXTRA-RESPONSE',
+        'BLOCK-RESPONSE',
+        'SURPRISE',
+        'STEAL',
+        'FIND',
+        'WILD',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'SIDE-BET',
+        'PERK-HAND-SIZE',
+        'PERK-DISCARD',
+        'PERK-HANDICAP',
+        'PERK-SPY'
+    ) NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    CONSTRAINT LOBBY_SPECIAL_UNIQUE UNIQUE(LOBBY_ID, SPECIAL)
+);
\ No newline at end of file
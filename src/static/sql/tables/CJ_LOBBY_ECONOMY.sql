@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS CJ_LOBBY_ECONOMY(
+    ID UUID NOT NULL DEFAULT UUID(),
+    LOBBY_ID UUID NOT NULL,
+    CATEGORY ENUM(
+        'WINNING-STREAK',
+        'LOSING-STREAK',
+        'PURCHASE',
+        'SKIP-JUDGE',
+        'EXTRA-RESPONSE',
+        'BLOCK-RESPONSE',
+        'STEAL',
+        'STEAL-VICTIM',
+        'SURPRISE',
+        'FIND',
+        'WILD',
+        'PERK',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'BET-WIN',
+        'GAMBLE-WIN',
+        'GAMBLE-CHANCE'
+    ) NOT NULL,
+    AMOUNT INT NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    CONSTRAINT LOBBY_CATEGORY_UNIQUE UNIQUE(LOBBY_ID, CATEGORY)
+);
\ No newline at end of file
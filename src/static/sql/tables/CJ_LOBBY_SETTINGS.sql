@@ -7,6 +7,32 @@ CREATE TABLE IF NOT EXISTS CJ_LOBBY_SETTINGS(
     FREE_SPECIAL_CARDS BOOLEAN NOT NULL DEFAULT FALSE,
     WIN_STREAK_THRESHOLD INT NOT NULL DEFAULT 3,
     LOSE_STREAK_THRESHOLD INT NOT NULL DEFAULT 3,
+    FREE_TEXT_MODE BOOLEAN NOT NULL DEFAULT FALSE,
+    FREE_TEXT_HANDS BOOLEAN NOT NULL DEFAULT TRUE,
+    FREE_TEXT_MAX_LENGTH INT NOT NULL DEFAULT 140,
+    FREE_TEXT_WORD_FILTER VARCHAR(1020) NULL,
+    CAPTION_MODE BOOLEAN NOT NULL DEFAULT FALSE,
+    PROMPT_CHOICES INT NOT NULL DEFAULT 1,
+    CUSTOM_PROMPTS BOOLEAN NOT NULL DEFAULT FALSE,
+    WRITE_PROMPT_COUNT INT NOT NULL DEFAULT 0,
+    WRITE_RESPONSE_COUNT INT NOT NULL DEFAULT 0,
+    WRITE_PHASE_END DATETIME NULL,
+    WRITE_PHASE_MINUTES INT NOT NULL DEFAULT 5,
+    ALLOW_SPECTATORS BOOLEAN NOT NULL DEFAULT TRUE,
+    MAX_SPECTATORS INT NOT NULL DEFAULT 10,
+    MIN_PLAYERS INT NOT NULL DEFAULT 3,
+    IS_STARTED BOOLEAN NOT NULL DEFAULT FALSE,
+    PAUSED_ON_DATE DATETIME NULL,
+    OWNER_PLAYER_ID UUID NULL,
+    KICK_THRESHOLD_MODE ENUM('COUNT', 'MAJORITY') NOT NULL DEFAULT 'COUNT',
+    KICK_VOTE_COUNT INT NOT NULL DEFAULT 2,
+    KICK_VOTE_MINUTES INT NOT NULL DEFAULT 10,
+    KICK_BAN_MINUTES INT NOT NULL DEFAULT 15,
+    IDLE_ROUNDS INT NOT NULL DEFAULT 3,
+    HANDICAP_MODE ENUM('OFF', 'RANK', 'POINT-GAP') NOT NULL DEFAULT 'RANK',
+    HANDICAP_SCALE DECIMAL(4, 2) NOT NULL DEFAULT 1.00,
+    CATCH_UP_MODE ENUM('OFF', 'POINTS', 'CREDITS') NOT NULL DEFAULT 'OFF',
+    RESHUFFLE_EXCLUDE_ROUNDS INT NOT NULL DEFAULT 0,
     ROUND_ID UUID NOT NULL DEFAULT UUID(),
     PRIMARY KEY(LOBBY_ID),
     FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE
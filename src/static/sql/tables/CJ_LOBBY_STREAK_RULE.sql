@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS CJ_LOBBY_STREAK_RULE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    LOBBY_ID UUID NOT NULL,
+    STREAK ENUM('WINNING', 'LOSING') NOT NULL,
+    LENGTH INT NOT NULL,
+    REWARD ENUM(
+        'CREDITS',
+        'HAND-CARD',
+        'JUDGE-IMMUNITY',
+        'PERK-HAND-SIZE',
+        'PERK-DISCARD',
+        'PERK-HANDICAP',
+        'PERK-SPY'
+    ) NOT NULL,
+    AMOUNT INT NOT NULL DEFAULT 1,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    CONSTRAINT LOBBY_STREAK_REWARD_UNIQUE UNIQUE(LOBBY_ID, STREAK, LENGTH, REWARD)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS CJ_MIGRATION(
+    NAME VARCHAR(255) NOT NULL,
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY(NAME)
+);
\ No newline at end of file
@@ -9,6 +9,14 @@ CREATE TABLE IF NOT EXISTS CJ_PLAYER_STATE(
     DISCARD_ADVANTAGE BOOLEAN NOT NULL DEFAULT 0,
     HANDICAP_ADVANTAGE BOOLEAN NOT NULL DEFAULT 0,
     SPY_ADVANTAGE BOOLEAN NOT NULL DEFAULT 0,
+    IS_SPECTATOR BOOLEAN NOT NULL DEFAULT 0,
+    IS_READY_TO_START BOOLEAN NOT NULL DEFAULT 0,
+    IS_CO_HOST BOOLEAN NOT NULL DEFAULT 0,
+    LAST_ACTION_ROUND_ID UUID NULL,
+    MISSED_ROUNDS INT NOT NULL DEFAULT 0,
+    IDLE_WARNED_ROUND_ID UUID NULL,
+    JUDGE_IMMUNITY INT NOT NULL DEFAULT 0,
+    BONUS_POINTS INT NOT NULL DEFAULT 0,
     PRIMARY KEY(PLAYER_ID),
     FOREIGN KEY(PLAYER_ID) REFERENCES PLAYER(ID) ON DELETE CASCADE
 );
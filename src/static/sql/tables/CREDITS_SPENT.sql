@@ -20,7 +20,14 @@ CREATE TABLE IF NOT EXISTS CREDITS_SPENT(
         'SURPRISE',
         'FIND',
         'WILD',
-        'PERK'
+        'PERK',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'SIDE-BET',
+        'SIDE-BET-WIN',
+        'CATCH-UP',
+        'STREAK-REWARD'
     ) NOT NULL,
     PRIMARY KEY(ID),
     FOREIGN KEY(PLAYER_ID) REFERENCES PLAYER(ID) ON DELETE CASCADE
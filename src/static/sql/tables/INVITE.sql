@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS INVITE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CREATED_BY_USER_ID UUID NULL,
+    LOBBY_ID UUID NULL,
+    DECK_ID UUID NULL,
+    TOKEN_HASH CHAR(64) NOT NULL,
+    ACCESS ENUM('READ', 'EDIT') NOT NULL DEFAULT 'EDIT',
+    EXPIRES_ON_DATE DATETIME NULL,
+    MAX_USES INT NOT NULL DEFAULT 0,
+    USE_COUNT INT NOT NULL DEFAULT 0,
+    REVOKED_ON_DATE DATETIME NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(CREATED_BY_USER_ID) REFERENCES USER(ID) ON DELETE SET NULL,
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    FOREIGN KEY(DECK_ID) REFERENCES DECK(ID) ON DELETE CASCADE,
+    CONSTRAINT TOKEN_HASH_UNIQUE UNIQUE(TOKEN_HASH)
+);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS INVITE_USE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INVITE_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(INVITE_ID) REFERENCES INVITE(ID) ON DELETE CASCADE,
+    FOREIGN KEY(USER_ID) REFERENCES USER(ID) ON DELETE CASCADE,
+    CONSTRAINT INVITE_USER_UNIQUE UNIQUE(INVITE_ID, USER_ID)
+);
\ No newline at end of file
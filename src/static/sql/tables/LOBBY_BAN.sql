@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS LOBBY_BAN(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    EXPIRES_ON_DATE DATETIME NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    FOREIGN KEY(USER_ID) REFERENCES USER(ID) ON DELETE CASCADE,
+    CONSTRAINT LOBBY_USER_UNIQUE UNIQUE(LOBBY_ID, USER_ID)
+);
\ No newline at end of file
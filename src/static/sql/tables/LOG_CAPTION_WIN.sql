@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS LOG_CAPTION_WIN(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    JUDGE_CARD_ID UUID NOT NULL,
+    PLAYER_USER_ID UUID NOT NULL,
+    CAPTION_TEXT VARCHAR(1530) NOT NULL,
+    PRIMARY KEY(ID)
+);
\ No newline at end of file
@@ -21,7 +21,14 @@ CREATE TABLE IF NOT EXISTS LOG_CREDITS_SPENT(
         'SURPRISE',
         'FIND',
         'WILD',
-        'PERK'
+        'PERK',
+        'SWAP',
+        'VETO',
+        'MULLIGAN',
+        'SIDE-BET',
+        'SIDE-BET-WIN',
+        'CATCH-UP',
+        'STREAK-REWARD'
     ) NOT NULL,
     PRIMARY KEY(ID)
 );
\ No newline at end of file
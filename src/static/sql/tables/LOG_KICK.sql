@@ -3,5 +3,7 @@ CREATE TABLE IF NOT EXISTS LOG_KICK(
     CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
     LOBBY_ID UUID NOT NULL,
     USER_ID UUID NOT NULL,
+    THRESHOLD INT NULL,
+    BAN_MINUTES INT NULL,
     PRIMARY KEY(ID)
 );
\ No newline at end of file
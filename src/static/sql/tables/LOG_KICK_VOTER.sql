@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS LOG_KICK_VOTER(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOG_KICK_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    VOTED_ON_DATE DATETIME(6) NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOG_KICK_ID) REFERENCES LOG_KICK(ID) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS LOG_PAUSE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    ROUND_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    RESUMED_ON_DATE DATETIME(6) NULL,
+    DURATION_SECONDS INT NULL,
+    PRIMARY KEY(ID)
+);
\ No newline at end of file
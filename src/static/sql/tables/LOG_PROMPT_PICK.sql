@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS LOG_PROMPT_PICK(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    CARD_ID UUID NOT NULL,
+    CANDIDATE_COUNT INT NOT NULL,
+    PRIMARY KEY(ID)
+);
\ No newline at end of file
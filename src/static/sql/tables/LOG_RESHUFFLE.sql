@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS LOG_RESHUFFLE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    CATEGORY ENUM('PROMPT', 'RESPONSE') NOT NULL,
+    CARD_COUNT INT NOT NULL,
+    ANNOUNCE_ID UUID NULL,
+    PRIMARY KEY(ID),
+    KEY LOBBY_ANNOUNCE_INDEX (LOBBY_ID, ANNOUNCE_ID)
+);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS LOG_STREAK_MILESTONE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    ROUND_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    STREAK ENUM('WINNING', 'LOSING') NOT NULL,
+    LENGTH INT NOT NULL,
+    REWARD VARCHAR(20) NOT NULL,
+    AMOUNT INT NOT NULL,
+    PRIMARY KEY(ID)
+);
\ No newline at end of file
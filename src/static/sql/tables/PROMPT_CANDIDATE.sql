@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS PROMPT_CANDIDATE(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    CARD_ID UUID NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    FOREIGN KEY(CARD_ID) REFERENCES CARD(ID) ON DELETE CASCADE,
+    CONSTRAINT LOBBY_CARD_UNIQUE UNIQUE(LOBBY_ID, CARD_ID)
+);
\ No newline at end of file
@@ -4,6 +4,7 @@ CREATE TABLE IF NOT EXISTS RESPONSE(
     PLAYER_ID UUID NOT NULL,
     IS_REVEALED BOOLEAN NOT NULL DEFAULT 0,
     IS_RULEDOUT BOOLEAN NOT NULL DEFAULT 0,
+    IS_VETOED BOOLEAN NOT NULL DEFAULT 0,
     PRIMARY KEY(ID),
     FOREIGN KEY(PLAYER_ID) REFERENCES PLAYER(ID) ON DELETE CASCADE
 );
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS SIDE_BET(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PLAYER_ID UUID NOT NULL,
+    RESPONSE_ID UUID NOT NULL,
+    AMOUNT INT NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(PLAYER_ID) REFERENCES PLAYER(ID) ON DELETE CASCADE,
+    FOREIGN KEY(RESPONSE_ID) REFERENCES RESPONSE(ID) ON DELETE CASCADE,
+    CONSTRAINT PLAYER_UNIQUE UNIQUE(PLAYER_ID)
+);
\ No newline at end of file
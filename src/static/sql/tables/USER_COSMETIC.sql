@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS USER_COSMETIC(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    USER_ID UUID NOT NULL,
+    KIND ENUM(
+        'CARD-BACK',
+        'NAME-COLOR',
+        'FLIP-TABLE',
+        'KICK'
+    ) NOT NULL,
+    COSMETIC VARCHAR(50) NOT NULL,
+    IS_EQUIPPED BOOLEAN NOT NULL DEFAULT 0,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(USER_ID) REFERENCES USER(ID) ON DELETE CASCADE,
+    CONSTRAINT USER_COSMETIC_UNIQUE UNIQUE(USER_ID, COSMETIC)
+);
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS WALLET_LEDGER(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    USER_ID UUID NOT NULL,
+    AMOUNT INT NOT NULL,
+    CATEGORY ENUM(
+        'ROUND-WIN',
+        'GAME-WIN',
+        'ACHIEVEMENT',
+        'UNLOCK'
+    ) NOT NULL,
+    REFERENCE VARCHAR(255) NULL,
+    ACHIEVEMENT VARCHAR(255) AS (IF(CATEGORY = 'ACHIEVEMENT', REFERENCE, NULL)) PERSISTENT,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(USER_ID) REFERENCES USER(ID) ON DELETE CASCADE,
+    CONSTRAINT USER_ACHIEVEMENT_UNIQUE UNIQUE(USER_ID, ACHIEVEMENT)
+);
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS WRITTEN_CARD(
+    ID UUID NOT NULL DEFAULT UUID(),
+    CREATED_ON_DATE DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    LOBBY_ID UUID NOT NULL,
+    CARD_ID UUID NOT NULL,
+    USER_ID UUID NOT NULL,
+    PRIMARY KEY(ID),
+    FOREIGN KEY(LOBBY_ID) REFERENCES LOBBY(ID) ON DELETE CASCADE,
+    FOREIGN KEY(CARD_ID) REFERENCES CARD(ID) ON DELETE CASCADE,
+    CONSTRAINT CARD_UNIQUE UNIQUE(CARD_ID)
+);
\ No newline at end of file
@@ -3,7 +3,28 @@ OR REPLACE TRIGGER TR_CJ_LOBBY_SETTINGS_AFTER_UPDATE
 AFTER UPDATE ON CJ_LOBBY_SETTINGS
 FOR EACH ROW
 BEGIN
-    IF NEW.HAND_SIZE > OLD.HAND_SIZE THEN
+    DECLARE VAR_OLD_HANDS BOOLEAN DEFAULT (NOT OLD.FREE_TEXT_MODE) OR OLD.FREE_TEXT_HANDS;
+    DECLARE VAR_NEW_HANDS BOOLEAN DEFAULT (NOT NEW.FREE_TEXT_MODE) OR NEW.FREE_TEXT_HANDS;
+
+    -- HANDS TURNED OFF, RETURN THEM TO THE DRAW PILE
+    IF VAR_OLD_HANDS AND NOT VAR_NEW_HANDS THEN
+        INSERT IGNORE INTO DRAW_PILE(LOBBY_ID, CARD_ID)
+        SELECT
+            NEW.LOBBY_ID AS LOBBY_ID,
+            H.CARD_ID AS CARD_ID
+        FROM HAND AS H
+            INNER JOIN PLAYER AS P ON P.ID = H.PLAYER_ID
+        WHERE P.LOBBY_ID = NEW.LOBBY_ID;
+
+        DELETE H
+        FROM HAND AS H
+            INNER JOIN PLAYER AS P ON P.ID = H.PLAYER_ID
+        WHERE P.LOBBY_ID = NEW.LOBBY_ID;
+    END
+    IF;
+
+    IF NEW.HAND_SIZE > OLD.HAND_SIZE
+        OR (VAR_NEW_HANDS AND NOT VAR_OLD_HANDS) THEN
         BEGIN
             DECLARE VAR_LOOP_DONE BOOLEAN DEFAULT FALSE;
             DECLARE VAR_PLAYER_ID UUID;
@@ -16,23 +16,48 @@ var SQLFiles = []string{
 	"sql/tables/CARD.sql",
 	"sql/tables/CJ_LOBBY_SETTINGS.sql",
 	"sql/tables/DRAW_PILE.sql",
+	"sql/tables/DISCARD_PILE.sql",
+	"sql/tables/PROMPT_CANDIDATE.sql",
+	"sql/tables/WRITTEN_CARD.sql",
 	"sql/tables/CJ_PLAYER_STATE.sql",
+	"sql/tables/BOT.sql",
 	"sql/tables/JUDGE.sql",
 	"sql/tables/HAND.sql",
 	"sql/tables/RESPONSE.sql",
 	"sql/tables/RESPONSE_CARD.sql",
+	"sql/tables/SIDE_BET.sql",
 	"sql/tables/REVIEW_CARD.sql",
 	"sql/tables/WIN.sql",
 	"sql/tables/CREDITS_SPENT.sql",
 	"sql/tables/KICK.sql",
+	"sql/tables/LOBBY_BAN.sql",
 	"sql/tables/LOG_CREDITS_SPENT.sql",
 	"sql/tables/LOG_DISCARD.sql",
 	"sql/tables/LOG_SKIP.sql",
 	"sql/tables/LOG_RESPONSE_CARD.sql",
 	"sql/tables/LOG_WIN.sql",
 	"sql/tables/LOG_KICK.sql",
+	"sql/tables/LOG_KICK_VOTER.sql",
 	"sql/tables/LOG_FLIP_TABLE.sql",
+	"sql/tables/LOG_CAPTION_WIN.sql",
+	"sql/tables/LOG_PROMPT_PICK.sql",
+	"sql/tables/LOG_PAUSE.sql",
+	"sql/tables/LOG_STREAK_MILESTONE.sql",
+	"sql/tables/LOG_RESHUFFLE.sql",
 	"sql/tables/AUDIT_CARD.sql",
+	"sql/tables/INVITE.sql",
+	"sql/tables/INVITE_USE.sql",
+	"sql/tables/USER_READ_ACCESS_DECK.sql",
+	"sql/tables/ACCESS_FAILURE.sql",
+	"sql/tables/DECK_COLLABORATOR.sql",
+	"sql/tables/CARD_SUGGESTION.sql",
+	"sql/tables/CJ_LOBBY_ECONOMY.sql",
+	"sql/tables/CJ_LOBBY_DISABLED_SPECIAL.sql",
+	"sql/tables/CJ_LOBBY_STREAK_RULE.sql",
+	"sql/tables/CJ_LOBBY_DECK_WEIGHT.sql",
+	"sql/tables/WALLET_LEDGER.sql",
+	"sql/tables/USER_COSMETIC.sql",
+	"sql/tables/CJ_MIGRATION.sql",
 
 	// migrations (idempotent ALTERs for pre-existing databases; run after tables
 	// so the target exists, and before triggers/procedures that reference the
@@ -40,20 +65,62 @@ var SQLFiles = []string{
 	"sql/migrations/MIG_CARD_ADD_LOBBY_ID.sql",
 	"sql/migrations/MIG_CARD_DECK_ID_NULLABLE.sql",
 	"sql/migrations/MIG_CARD_ADD_LOBBY_FK.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_FREE_TEXT.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_CAPTION_MODE.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_PROMPT_CHOICES.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_CUSTOM_PROMPTS.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_WRITING_PHASE.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_SPECTATORS.sql",
+	"sql/migrations/MIG_CJ_PLAYER_STATE_ADD_IS_SPECTATOR.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_WAITING_ROOM.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_MARK_STARTED.sql",
+	"sql/migrations/MIG_CJ_PLAYER_STATE_ADD_IS_READY_TO_START.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_PAUSED_ON_DATE.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_OWNER_PLAYER_ID.sql",
+	"sql/migrations/MIG_CJ_PLAYER_STATE_ADD_IS_CO_HOST.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_KICK_RULES.sql",
+	"sql/migrations/MIG_LOG_KICK_ADD_THRESHOLD.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_IDLE_ROUNDS.sql",
+	"sql/migrations/MIG_CJ_PLAYER_STATE_ADD_IDLE_TRACKING.sql",
+	"sql/migrations/MIG_DECK_COLLABORATOR_ADD_OWNERS.sql",
+	"sql/migrations/MIG_DECK_COLLABORATOR_ADD_OWNERS_DONE.sql",
+	"sql/migrations/MIG_DROP_TR_SET_DECK_OWNER_AF_IN_USER_ACCESS_DECK.sql",
+	"sql/migrations/MIG_CREDITS_SPENT_CATEGORY_VALUES.sql",
+	"sql/migrations/MIG_LOG_CREDITS_SPENT_CATEGORY_VALUES.sql",
+	"sql/migrations/MIG_CJ_LOBBY_ECONOMY_CATEGORY_VALUES.sql",
+	"sql/migrations/MIG_CJ_LOBBY_DISABLED_SPECIAL_SPECIAL_VALUES.sql",
+	"sql/migrations/MIG_RESPONSE_ADD_IS_VETOED.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_HANDICAP_RULES.sql",
+	"sql/migrations/MIG_CJ_PLAYER_STATE_ADD_JUDGE_IMMUNITY.sql",
+	"sql/migrations/MIG_CJ_PLAYER_STATE_ADD_BONUS_POINTS.sql",
+	"sql/migrations/MIG_CJ_LOBBY_SETTINGS_ADD_RESHUFFLE_EXCLUDE_ROUNDS.sql",
+	"sql/migrations/MIG_LOG_RESHUFFLE_DROP_IS_ANNOUNCED.sql",
+	"sql/migrations/MIG_LOG_RESHUFFLE_ADD_ANNOUNCE_ID.sql",
+	"sql/migrations/MIG_LOG_RESHUFFLE_CLAIM_EXISTING.sql",
+	"sql/migrations/MIG_LOG_RESHUFFLE_CLAIM_EXISTING_DONE.sql",
+	"sql/migrations/MIG_WALLET_LEDGER_REMOVE_DUPLICATE_ACHIEVEMENTS.sql",
+	"sql/migrations/MIG_WALLET_LEDGER_ADD_ACHIEVEMENT_UNIQUE.sql",
 
 	// views
 	"sql/views/V_ROUND_WINNER.sql",
 	"sql/views/V_GAME_WINNER.sql",
 
 	// functions
+	"sql/functions/FN_GET_ACCESS_BACKOFF_SECONDS.sql",
+	"sql/functions/FN_GET_ACCESS_WAIT_SECONDS.sql",
+	"sql/functions/FN_GET_DEFAULT_ECONOMY_VALUE.sql",
 	"sql/functions/FN_GET_DRAW_PILE_CARD_ID.sql",
+	"sql/functions/FN_GET_ECONOMY_VALUE.sql",
+	"sql/functions/FN_GET_KICK_THRESHOLD.sql",
 	"sql/functions/FN_GET_LOBBY_JUDGE_BLANK_COUNT.sql",
 	"sql/functions/FN_GET_LOBBY_JUDGE_PLAYER_ID.sql",
+	"sql/functions/FN_GET_LOBBY_OWNER_PLAYER_ID.sql",
 	"sql/functions/FN_GET_PLAYER_HANDICAP.sql",
 	"sql/functions/FN_GET_PLAYER_HANDICAP_INVERSE.sql",
+	"sql/functions/FN_GET_PLAYER_LOBBY_ROLE.sql",
 	"sql/functions/FN_GET_PLAYER_RESPONSE_CARD_COUNT.sql",
 	"sql/functions/FN_GET_PLAYER_RESPONSE_COUNT.sql",
-	"sql/functions/FN_GET_SPECIAL_COST.sql",
+	"sql/functions/FN_GET_USER_DECK_ROLE.sql",
 
 	// procedures
 	"sql/procedures/SP_ADD_EXTRA_RESPONSE.sql",
@@ -62,57 +129,89 @@ var SQLFiles = []string{
 	"sql/procedures/SP_BET_ON_WIN.sql",
 	"sql/procedures/SP_BET_ON_WIN_UNDO.sql",
 	"sql/procedures/SP_BLOCK_RESPONSE.sql",
+	"sql/procedures/SP_CJ_CATCH_UP_PLAYER.sql",
 	"sql/procedures/SP_CJ_CLEANUP_LOBBY.sql",
 	"sql/procedures/SP_CJ_INIT_LOBBY.sql",
 	"sql/procedures/SP_CJ_INIT_PLAYER.sql",
+	"sql/procedures/SP_CJ_OPEN_LOBBY.sql",
 	"sql/procedures/SP_CJ_PLAYER_ACTIVE.sql",
 	"sql/procedures/SP_CJ_PLAYER_INACTIVE.sql",
 	"sql/procedures/SP_DISCARD_CARD.sql",
+	"sql/procedures/SP_DISCARD_ROUND.sql",
 	"sql/procedures/SP_DRAW_HAND.sql",
+	"sql/procedures/SP_END_WRITING_PHASE.sql",
 	"sql/procedures/SP_FLIP_TABLE.sql",
 	"sql/procedures/SP_GAMBLE_CREDITS.sql",
+	"sql/procedures/SP_MULLIGAN.sql",
+	"sql/procedures/SP_PAUSE_LOBBY.sql",
+	"sql/procedures/SP_PAY_ACHIEVEMENT_REWARD.sql",
 	"sql/procedures/SP_PERK_DISCARD_ADVANTAGE.sql",
 	"sql/procedures/SP_PERK_HANDICAP_ADVANTAGE.sql",
 	"sql/procedures/SP_PERK_HAND_SIZE_ADVANTAGE.sql",
 	"sql/procedures/SP_PERK_SPY_ADVANTAGE.sql",
+	"sql/procedures/SP_PICK_PROMPT_CANDIDATE.sql",
 	"sql/procedures/SP_PICK_RANDOM_WINNER.sql",
 	"sql/procedures/SP_PICK_WINNER.sql",
 	"sql/procedures/SP_PURCHASE_CREDITS.sql",
+	"sql/procedures/SP_REFUND_SIDE_BETS.sql",
 	"sql/procedures/SP_RESET_RESPONSES.sql",
+	"sql/procedures/SP_RESHUFFLE_DISCARD_PILE.sql",
 	"sql/procedures/SP_RESPOND_WITH_CARD.sql",
 	"sql/procedures/SP_RESPOND_WITH_FIND_CARD.sql",
 	"sql/procedures/SP_RESPOND_WITH_FORCE_CARD.sql",
+	"sql/procedures/SP_RESPOND_WITH_FREE_TEXT.sql",
 	"sql/procedures/SP_RESPOND_WITH_STEAL_CARD.sql",
 	"sql/procedures/SP_RESPOND_WITH_SURPRISE_CARD.sql",
 	"sql/procedures/SP_RESPOND_WITH_WILD_CARD.sql",
+	"sql/procedures/SP_RESUME_LOBBY.sql",
+	"sql/procedures/SP_RETURN_HAND.sql",
+	"sql/procedures/SP_REWARD_STREAK.sql",
+	"sql/procedures/SP_SET_CUSTOM_JUDGE_CARD.sql",
+	"sql/procedures/SP_SET_JUDGE_CARD.sql",
+	"sql/procedures/SP_SET_LOBBY_DECK_WEIGHTS.sql",
 	"sql/procedures/SP_SET_LOSING_STREAK.sql",
 	"sql/procedures/SP_SET_MISSING_JUDGE_CARD.sql",
 	"sql/procedures/SP_SET_MISSING_JUDGE_PLAYER.sql",
 	"sql/procedures/SP_SET_NEXT_JUDGE_CARD.sql",
 	"sql/procedures/SP_SET_NEXT_JUDGE_PLAYER.sql",
+	"sql/procedures/SP_SET_PLAYER_SPECTATOR.sql",
 	"sql/procedures/SP_SET_RESPONSE_COUNT.sql",
 	"sql/procedures/SP_SET_RESPONSES_LOBBY.sql",
 	"sql/procedures/SP_SET_RESPONSES_PLAYER.sql",
 	"sql/procedures/SP_SET_WINNING_STREAK.sql",
+	"sql/procedures/SP_SETTLE_SIDE_BETS.sql",
+	"sql/procedures/SP_SIDE_BET.sql",
+	"sql/procedures/SP_SIDE_BET_UNDO.sql",
+	"sql/procedures/SP_SKIP_IDLE_JUDGE.sql",
 	"sql/procedures/SP_SKIP_JUDGE.sql",
 	"sql/procedures/SP_SKIP_PROMPT.sql",
 	"sql/procedures/SP_SPEND_CREDITS.sql",
 	"sql/procedures/SP_SPEND_CREDITS_UNDO.sql",
 	"sql/procedures/SP_START_NEW_ROUND.sql",
+	"sql/procedures/SP_SWAP_CARD.sql",
+	"sql/procedures/SP_TRANSFER_DECK_OWNER.sql",
+	"sql/procedures/SP_TRANSFER_LOBBY_OWNER.sql",
+	"sql/procedures/SP_UNLOCK_COSMETIC.sql",
+	"sql/procedures/SP_USE_INVITE.sql",
+	"sql/procedures/SP_VETO_RESPONSE.sql",
 	"sql/procedures/SP_VOTE_TO_KICK.sql",
 	"sql/procedures/SP_VOTE_TO_KICK_UNDO.sql",
 	"sql/procedures/SP_WITHDRAW_CARD.sql",
 	"sql/procedures/SP_WITHDRAW_LOBBY.sql",
 	"sql/procedures/SP_WITHDRAW_RESPONSE.sql",
+	"sql/procedures/SP_WRITE_CARD.sql",
 
 	// events
+	"sql/events/EVT_CLEAN_ACCESS_FAILURES.sql",
 	"sql/events/EVT_CLEAN_BAD_PROMPT_CARDS.sql",
 	"sql/events/EVT_CLEAN_CJ_AUDIT_TABLES.sql",
 	"sql/events/EVT_CLEAN_BAD_RESPONSE_CARDS.sql",
+	"sql/events/EVT_EXPIRE_KICK_VOTES.sql",
 
 	// triggers
 	"sql/triggers/TR_AUDIT_CARD_DELETE.sql",
 	"sql/triggers/TR_AUDIT_CARD_UPDATE.sql",
 	"sql/triggers/TR_CJ_LOBBY_SETTINGS_AFTER_UPDATE.sql",
+	"sql/triggers/TR_REVOKE_READ_ACCESS_AF_UP_DECK.sql",
 	"sql/triggers/TR_SET_CHANGED_ON_DATE_BF_UP_CARD.sql",
 }